@@ -0,0 +1,49 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+// FilterByAccess returns a copy of doc without the properties which a user
+// with the given role may not see, including those of nested objects and of
+// objects in arrays. Values which aren't described by s are kept.
+func (s *Schema) FilterByAccess(doc map[string]interface{}, role string) map[string]interface{} {
+	out, _ := s.filterByAccess(doc, role).(map[string]interface{})
+	return out
+}
+
+func (s *Schema) filterByAccess(x interface{}, role string) interface{} {
+	if s == nil {
+		return x
+	}
+	switch x := x.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(x))
+		for name, v := range x {
+			allowed := true
+			for _, ps := range propertySchemas(s, name) {
+				if !ps.accessibleBy(role) {
+					allowed = false
+					break
+				}
+				v = ps.filterByAccess(v, role)
+			}
+			if allowed {
+				out[name] = v
+			}
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(x))
+		for i, item := range x {
+			out[i] = itemSchema(s, i).filterByAccess(item, role)
+		}
+		return out
+	}
+	return x
+}
+
+// accessibleBy reports whether a user with the given role may see or set a
+// property with schema s.
+func (s *Schema) accessibleBy(role string) bool {
+	return s.Access == "" || s.Access == role
+}
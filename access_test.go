@@ -0,0 +1,71 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type AccessSuite struct{}
+
+var _ = gc.Suite(AccessSuite{})
+
+const accessSchema = `
+type: object
+properties:
+  name:
+    type: string
+  api-key:
+    type: string
+    access: admin
+  nodes:
+    type: array
+    items:
+      type: object
+      properties:
+        address:
+          type: string
+        password:
+          type: string
+          access: admin
+`
+
+func (AccessSuite) TestFilterByAccess(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(accessSchema))
+	c.Assert(err, jc.ErrorIsNil)
+	doc := map[string]interface{}{
+		"name":    "db",
+		"api-key": "secret",
+		"nodes": []interface{}{
+			map[string]interface{}{"address": "10.0.0.1", "password": "hunter2"},
+		},
+	}
+
+	c.Check(s.FilterByAccess(doc, "read-only"), jc.DeepEquals, map[string]interface{}{
+		"name": "db",
+		"nodes": []interface{}{
+			map[string]interface{}{"address": "10.0.0.1"},
+		},
+	})
+	c.Check(s.FilterByAccess(doc, "admin"), jc.DeepEquals, doc)
+	// The original document is unchanged.
+	c.Check(doc["api-key"], gc.Equals, "secret")
+}
+
+func (AccessSuite) TestValidateWithRole(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(accessSchema))
+	c.Assert(err, jc.ErrorIsNil)
+	doc := map[string]interface{}{
+		"nodes": []interface{}{
+			map[string]interface{}{"password": "hunter2"},
+		},
+	}
+	c.Check(s.Validate(doc), jc.ErrorIsNil)
+	c.Check(s.ValidateWith(ValidationOptions{Role: "admin"}, doc), jc.ErrorIsNil)
+	err = s.ValidateWith(ValidationOptions{Role: "read-only"}, doc)
+	c.Check(err, gc.ErrorMatches, `nodes\[0\].password: cannot be set without "admin" access`)
+}
@@ -0,0 +1,140 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+var (
+	typeAliasesMu sync.RWMutex
+	typeAliases   = make(map[string]map[string]interface{})
+)
+
+// RegisterTypeAlias registers a name which schemas may use in place of a
+// standard type, as in "type": "storage-size". When a schema is loaded, any
+// schema with that type is replaced by s, with the other keywords given
+// alongside the alias, such as description or default, taking precedence
+// over those in s. This allows a definition that is used by many schemas to
+// be maintained in one place. Registering an alias that already exists
+// replaces it; RegisterTypeAlias panics if alias names a standard type.
+func RegisterTypeAlias(alias string, s *Schema) {
+	if isStandardType(alias) {
+		panic(fmt.Sprintf("cannot register type alias for standard type %q", alias))
+	}
+	data, err := s.MarshalJSON()
+	if err != nil {
+		panic(fmt.Sprintf("cannot register type alias %q: %v", alias, err))
+	}
+	var m map[string]interface{}
+	if err := json.Unmarshal(data, &m); err != nil {
+		panic(fmt.Sprintf("cannot register type alias %q: %v", alias, err))
+	}
+	typeAliasesMu.Lock()
+	defer typeAliasesMu.Unlock()
+	typeAliases[alias] = m
+}
+
+// isStandardType reports whether name is the name of a standard type.
+func isStandardType(name string) bool {
+	for t := NullType; t <= NumberType; t++ {
+		if t.String() == name {
+			return true
+		}
+	}
+	return false
+}
+
+// expandTypeAliases returns the json schema in data with any registered
+// type aliases expanded.
+func expandTypeAliases(data []byte) ([]byte, error) {
+	typeAliasesMu.RLock()
+	defer typeAliasesMu.RUnlock()
+	if len(typeAliases) == 0 {
+		return data, nil
+	}
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, err
+	}
+	v, changed := expandTypeAlias(v)
+	if !changed {
+		return data, nil
+	}
+	return json.Marshal(v)
+}
+
+// Keywords whose values hold a schema, a list of schemas or a map of
+// schemas. These are the positions searched for type aliases.
+var (
+	schemaKeywords     = []string{"additionalItems", "additionalProperties", "contains", "else", "if", "items", "not", "propertyNames", "then", "unevaluatedItems", "unevaluatedProperties"}
+	schemaListKeywords = []string{"allOf", "anyOf", "items", "oneOf", "prefixItems"}
+	schemaMapKeywords  = []string{"$defs", "definitions", "dependencies", "dependentSchemas", "patternProperties", "profiles", "properties", "variants"}
+)
+
+// expandTypeAlias returns the schema v with any type aliases within it
+// expanded, and reports whether any were found. The caller must hold
+// typeAliasesMu.
+func expandTypeAlias(v interface{}) (interface{}, bool) {
+	return rewriteRawSchema(v, func(m map[string]interface{}) (map[string]interface{}, bool) {
+		t, ok := m["type"].(string)
+		if !ok {
+			return m, false
+		}
+		alias, ok := typeAliases[t]
+		if !ok {
+			return m, false
+		}
+		expanded := make(map[string]interface{}, len(alias)+len(m))
+		for k, v := range alias {
+			expanded[k] = v
+		}
+		for k, v := range m {
+			if k != "type" {
+				expanded[k] = v
+			}
+		}
+		return expanded, true
+	})
+}
+
+// rewriteRawSchema calls fn for the json schema v, as decoded into an
+// interface{}, and for every schema nested within it, replacing each with
+// the result. It returns the rewritten schema and reports whether fn
+// reported changing any of them.
+func rewriteRawSchema(v interface{}, fn func(map[string]interface{}) (map[string]interface{}, bool)) (interface{}, bool) {
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return v, false
+	}
+	m, changed := fn(m)
+	for _, k := range schemaKeywords {
+		if sub, ok := m[k].(map[string]interface{}); ok {
+			if sub, subChanged := rewriteRawSchema(sub, fn); subChanged {
+				m[k], changed = sub, true
+			}
+		}
+	}
+	for _, k := range schemaListKeywords {
+		if l, ok := m[k].([]interface{}); ok {
+			for i, sub := range l {
+				if sub, subChanged := rewriteRawSchema(sub, fn); subChanged {
+					l[i], changed = sub, true
+				}
+			}
+		}
+	}
+	for _, k := range schemaMapKeywords {
+		if subs, ok := m[k].(map[string]interface{}); ok {
+			for name, sub := range subs {
+				if sub, subChanged := rewriteRawSchema(sub, fn); subChanged {
+					subs[name], changed = sub, true
+				}
+			}
+		}
+	}
+	return m, changed
+}
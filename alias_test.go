@@ -0,0 +1,94 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"regexp"
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type AliasSuite struct{}
+
+var _ = gc.Suite(AliasSuite{})
+
+func init() {
+	RegisterTypeAlias("test-storage-size", &Schema{
+		Type:        []Type{StringType},
+		Description: "A size such as 512M or 8G.",
+		Pattern:     regexp.MustCompile(`^[0-9]+[MGT]$`),
+	})
+}
+
+const aliasSchema = `
+type: object
+properties:
+  root-disk:
+    type: test-storage-size
+    default: 8G
+  volumes:
+    type: array
+    items:
+      type: test-storage-size
+      description: The size of a volume.
+`
+
+func (AliasSuite) TestTypeAlias(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(aliasSchema))
+	c.Assert(err, jc.ErrorIsNil)
+
+	disk := s.Properties["root-disk"]
+	c.Check(disk.Type, jc.DeepEquals, []Type{StringType})
+	c.Check(disk.Description, gc.Equals, "A size such as 512M or 8G.")
+	c.Check(disk.Default, gc.Equals, "8G")
+	volume := s.Properties["volumes"].Items.Schemas[0]
+	c.Check(volume.Description, gc.Equals, "The size of a volume.")
+	c.Check(volume.Pattern.String(), gc.Equals, `^[0-9]+[MGT]$`)
+
+	c.Check(s.Validate(map[string]interface{}{
+		"root-disk": "16G",
+		"volumes":   []interface{}{"512M"},
+	}), jc.ErrorIsNil)
+	c.Check(s.Validate(map[string]interface{}{
+		"volumes": []interface{}{"lots"},
+	}), gc.ErrorMatches, `volumes\[0\]: .*`)
+}
+
+func (AliasSuite) TestTypeAliasInConditionalsAndDependents(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(`
+type: object
+properties:
+  kind:
+    type: string
+if:
+  properties:
+    kind:
+      enum: [disk]
+then:
+  properties:
+    size:
+      type: test-storage-size
+dependentSchemas:
+  cache:
+    properties:
+      cache-size:
+        type: test-storage-size
+`))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.Then.Properties["size"].Type, jc.DeepEquals, []Type{StringType})
+	c.Check(s.DependentSchemas["cache"].Properties["cache-size"].Type, jc.DeepEquals, []Type{StringType})
+	c.Check(s.Validate(map[string]interface{}{"kind": "disk", "size": "lots"}), gc.ErrorMatches, `size: .*`)
+}
+
+func (AliasSuite) TestUnknownType(c *gc.C) {
+	_, err := FromYAML(strings.NewReader(`type: no-such-type`))
+	c.Check(err, gc.NotNil)
+}
+
+func (AliasSuite) TestRegisterStandardType(c *gc.C) {
+	c.Check(func() { RegisterTypeAlias("string", &Schema{}) }, gc.PanicMatches,
+		`cannot register type alias for standard type "string"`)
+}
@@ -0,0 +1,106 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// AuditSink records validation failures, for example so that repeated
+// attempts to supply invalid credentials can be investigated. See
+// ValidationOptions.Audit.
+type AuditSink interface {
+	RecordFailure(f AuditRecord)
+}
+
+// AuditRecord describes a single validation failure. It identifies the
+// document by a keyed hash, so that secrets are never recorded. A document
+// which fails validation in more than one place produces a record for each
+// failure.
+type AuditRecord struct {
+	// SchemaFingerprint holds the hex-encoded SHA-256 hash of the json form
+	// of the schema.
+	SchemaFingerprint string
+
+	// DocumentHash holds the hex-encoded HMAC-SHA256 of the json form of
+	// the document, keyed by ValidationOptions.AuditKey. Records of the
+	// same document hold the same hash, but without the key it can't be
+	// used to guess the document, even one holding only a short password.
+	// It is empty if no key is given or the document can't be encoded as
+	// json.
+	DocumentHash string
+
+	// Path and Keyword identify the value that failed validation and the
+	// keyword which rejected it, where known. See ValidationError.
+	Path    string
+	Keyword string
+
+	// Time holds the time at which validation failed.
+	Time time.Time
+}
+
+// audit records each failure in err, returned when validating x against s,
+// in opts.Audit if set, and returns err.
+func (opts ValidationOptions) audit(s *Schema, x interface{}, err error) error {
+	if err == nil || opts.Audit == nil {
+		return err
+	}
+	template := AuditRecord{
+		SchemaFingerprint: hashJSON(s),
+		DocumentHash:      hmacJSON(opts.AuditKey, x),
+		Time:              time.Now(),
+	}
+	var errs ValidationErrors
+	var verr *ValidationError
+	switch {
+	case errors.As(err, &errs):
+	case errors.As(err, &verr):
+		errs = ValidationErrors{verr}
+	default:
+		opts.Audit.RecordFailure(template)
+		return err
+	}
+	for _, e := range errs {
+		record := template
+		record.Path, record.Keyword = e.Path, e.Keyword
+		opts.Audit.RecordFailure(record)
+	}
+	return err
+}
+
+// hashJSON returns the hex-encoded SHA-256 hash of the json form of v, or ""
+// if v can't be encoded.
+func hashJSON(v interface{}) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return ""
+	}
+	return hashBytes(data)
+}
+
+// hmacJSON returns the hex-encoded HMAC-SHA256 of the json form of v keyed by
+// key, or "" if key is empty or v can't be encoded.
+func hmacJSON(key []byte, v interface{}) string {
+	if len(key) == 0 {
+		return ""
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return ""
+	}
+	mac := hmac.New(sha256.New, key)
+	mac.Write(data)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// hashBytes returns the hex-encoded SHA-256 hash of data.
+func hashBytes(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
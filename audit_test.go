@@ -0,0 +1,94 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type AuditSuite struct{}
+
+var _ = gc.Suite(AuditSuite{})
+
+type recordingSink struct {
+	records []AuditRecord
+}
+
+func (s *recordingSink) RecordFailure(r AuditRecord) {
+	s.records = append(s.records, r)
+}
+
+func (AuditSuite) TestAuditFailures(c *gc.C) {
+	s := &Schema{
+		Type: []Type{ObjectType},
+		Properties: map[string]*Schema{
+			"password": {Type: []Type{StringType}, Secret: true},
+			"cloud":    {Type: []Type{StringType}, Access: "admin"},
+		},
+	}
+	sink := &recordingSink{}
+	opts := ValidationOptions{Role: "user", Audit: sink, AuditKey: []byte("key")}
+
+	c.Assert(s.ValidateWith(opts, map[string]interface{}{"password": "ok"}), jc.ErrorIsNil)
+	c.Assert(sink.records, gc.HasLen, 0)
+
+	before := time.Now()
+	c.Assert(s.ValidateWith(opts, map[string]interface{}{"password": 1}), gc.NotNil)
+	c.Assert(s.ValidateWith(opts, map[string]interface{}{"cloud": "aws"}), gc.NotNil)
+	c.Assert(sink.records, gc.HasLen, 2)
+
+	first, second := sink.records[0], sink.records[1]
+	c.Check(first.SchemaFingerprint, gc.Matches, "[0-9a-f]{64}")
+	c.Check(first.SchemaFingerprint, gc.Equals, second.SchemaFingerprint)
+	c.Check(first.DocumentHash, gc.Matches, "[0-9a-f]{64}")
+	c.Check(first.DocumentHash, gc.Not(gc.Equals), second.DocumentHash)
+	c.Check(first.Time.Before(before), jc.IsFalse)
+	c.Check(second.Path, gc.Equals, "cloud")
+	c.Check(second.Keyword, gc.Equals, "access")
+
+	// The hash depends on the key, so it can't be recomputed without it.
+	c.Check(first.DocumentHash, gc.Not(gc.Equals), hashJSON(map[string]interface{}{"password": 1}))
+	sink.records = nil
+	opts.AuditKey = []byte("other")
+	c.Assert(s.ValidateWith(opts, map[string]interface{}{"password": 1}), gc.NotNil)
+	c.Check(sink.records[0].DocumentHash, gc.Not(gc.Equals), first.DocumentHash)
+
+	// Without a key, documents aren't identified.
+	sink.records = nil
+	opts.AuditKey = nil
+	c.Assert(s.ValidateWith(opts, map[string]interface{}{"password": 1}), gc.NotNil)
+	c.Check(sink.records[0].DocumentHash, gc.Equals, "")
+}
+
+func (AuditSuite) TestAuditEachFailure(c *gc.C) {
+	s := &Schema{
+		Type: []Type{ObjectType},
+		Properties: map[string]*Schema{
+			"a": {Type: []Type{StringType}},
+			"b": {Type: []Type{StringType}},
+		},
+		Required: []string{"a", "b"},
+	}
+	sink := &recordingSink{}
+	c.Assert(s.ValidateWith(ValidationOptions{Audit: sink, AuditKey: []byte("key")}, map[string]interface{}{}), gc.NotNil)
+	c.Assert(sink.records, gc.HasLen, 2)
+	c.Check(sink.records[0].Path, gc.Equals, "a")
+	c.Check(sink.records[1].Path, gc.Equals, "b")
+	for _, r := range sink.records {
+		c.Check(r.Keyword, gc.Equals, "required")
+		c.Check(r.SchemaFingerprint, gc.Equals, sink.records[0].SchemaFingerprint)
+		c.Check(r.DocumentHash, gc.Equals, sink.records[0].DocumentHash)
+	}
+}
+
+func (AuditSuite) TestAuditValidator(c *gc.C) {
+	v, err := NewValidator(&Schema{Type: []Type{StringType}})
+	c.Assert(err, jc.ErrorIsNil)
+	sink := &recordingSink{}
+	c.Check(v.ValidateWith(ValidationOptions{Audit: sink}, 1), gc.NotNil)
+	c.Check(sink.records, gc.HasLen, 1)
+}
@@ -0,0 +1,119 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// BatchResult holds the result of ValidateBatch.
+type BatchResult struct {
+	// Errors holds the error for each document which failed validation,
+	// keyed by the name of the document.
+	Errors map[string]error
+}
+
+// OK reports whether every document was valid.
+func (r BatchResult) OK() bool {
+	return len(r.Errors) == 0
+}
+
+// Err returns an error describing every failure in r, ordered by the name
+// of the document, or nil if every document was valid. The error unwraps to
+// the error for each document.
+func (r BatchResult) Err() error {
+	if r.OK() {
+		return nil
+	}
+	names := make([]string, 0, len(r.Errors))
+	for name := range r.Errors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	err := &batchError{errs: make([]error, len(names))}
+	msgs := make([]string, len(names))
+	for i, name := range names {
+		err.errs[i] = r.Errors[name]
+		msgs[i] = fmt.Sprintf("%s: %v", name, r.Errors[name])
+	}
+	err.msg = strings.Join(msgs, "; ")
+	return err
+}
+
+// batchError is the error returned by BatchResult.Err.
+type batchError struct {
+	msg  string
+	errs []error
+}
+
+func (e *batchError) Error() string {
+	return e.msg
+}
+
+func (e *batchError) Unwrap() []error {
+	return e.errs
+}
+
+// ValidateBatch validates each of the named documents against the schema
+// with the same name, as a pre-flight check before the documents are used
+// together, for example when deploying a bundle with its model config. A
+// schema with no document is checked against an empty document, so that
+// missing required properties are reported, and a document with no schema
+// is an error.
+//
+// Each document is validated with every other document available in
+// ValidationOptions.Documents, so that properties may refer to values in
+// the other documents with the key-of keyword, and custom validators may
+// check any other constraint across them.
+func ValidateBatch(schemas map[string]*Schema, docs map[string]map[string]interface{}) BatchResult {
+	result := BatchResult{Errors: make(map[string]error)}
+	opts := ValidationOptions{Documents: docs}
+	for name, s := range schemas {
+		doc, ok := docs[name]
+		if !ok {
+			doc = map[string]interface{}{}
+		}
+		if err := s.ValidateWith(opts, doc); err != nil {
+			result.Errors[name] = err
+		}
+	}
+	for name := range docs {
+		if _, ok := schemas[name]; !ok {
+			result.Errors[name] = errors.New("no schema for document")
+		}
+	}
+	return result
+}
+
+// Document returns the named document from opts.Documents, or nil if there
+// is no such document.
+func (opts ValidationOptions) Document(name string) map[string]interface{} {
+	return opts.Documents[name]
+}
+
+// checkKeyOf checks that x is the name of a property of the object at the
+// dotted path ref in opts.Documents, as required by the key-of keyword.
+func (opts ValidationOptions) checkKeyOf(ref string, x interface{}) error {
+	elems := strings.Split(ref, ".")
+	doc, ok := opts.Documents[elems[0]]
+	if !ok {
+		return fmt.Errorf("document %q not found", elems[0])
+	}
+	obj := doc
+	for i, name := range elems[1:] {
+		next, ok := asObject(obj[name])
+		if !ok {
+			return fmt.Errorf("no object at %s", strings.Join(elems[:i+2], "."))
+		}
+		obj = next
+	}
+	key := formatFlatValue(x)
+	if _, ok := obj[key]; !ok {
+		return fmt.Errorf("%q is not defined in %s", key, ref)
+	}
+	return nil
+}
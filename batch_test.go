@@ -0,0 +1,129 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"errors"
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type BatchSuite struct{}
+
+var _ = gc.Suite(BatchSuite{})
+
+const batchBundleSchema = `
+type: object
+properties:
+  machines:
+    type: object
+    additionalProperties:
+      type: object
+      properties:
+        series:
+          type: string
+  applications:
+    type: object
+    additionalProperties:
+      type: object
+      properties:
+        to:
+          type: array
+          items:
+            type: string
+            key-of: bundle.machines
+`
+
+const batchModelSchema = `
+type: object
+required: [name]
+properties:
+  name:
+    type: string
+  default-series:
+    type: string
+    validators: [known-series]
+`
+
+func batchSchemas(c *gc.C) map[string]*Schema {
+	bundle, err := FromYAML(strings.NewReader(batchBundleSchema))
+	c.Assert(err, jc.ErrorIsNil)
+	model, err := FromYAML(strings.NewReader(batchModelSchema))
+	c.Assert(err, jc.ErrorIsNil)
+	return map[string]*Schema{"bundle": bundle, "model": model}
+}
+
+func init() {
+	// known-series requires the default series to be used by a machine
+	// in the bundle.
+	RegisterValidator("known-series", func(opts ValidationOptions, value interface{}) error {
+		if opts.Documents == nil {
+			return nil
+		}
+		machines, _ := opts.Document("bundle")["machines"].(map[string]interface{})
+		for _, m := range machines {
+			if m, ok := m.(map[string]interface{}); ok && m["series"] == value {
+				return nil
+			}
+		}
+		return errors.New("no machine uses this series")
+	})
+}
+
+func (BatchSuite) TestValidateBatch(c *gc.C) {
+	docs := map[string]map[string]interface{}{
+		"bundle": {
+			"machines": map[string]interface{}{
+				"0": map[string]interface{}{"series": "jammy"},
+			},
+			"applications": map[string]interface{}{
+				"db": map[string]interface{}{"to": []interface{}{"0"}},
+			},
+		},
+		"model": {"name": "prod", "default-series": "jammy"},
+	}
+	result := ValidateBatch(batchSchemas(c), docs)
+	c.Check(result.OK(), jc.IsTrue)
+	c.Check(result.Err(), jc.ErrorIsNil)
+}
+
+func (BatchSuite) TestValidateBatchErrors(c *gc.C) {
+	docs := map[string]map[string]interface{}{
+		"bundle": {
+			"machines": map[string]interface{}{
+				"0": map[string]interface{}{"series": "jammy"},
+			},
+			"applications": map[string]interface{}{
+				"db": map[string]interface{}{"to": []interface{}{"0", "1"}},
+			},
+		},
+		"extra": {},
+	}
+	result := ValidateBatch(batchSchemas(c), docs)
+	c.Check(result.OK(), jc.IsFalse)
+	c.Check(result.Errors, gc.HasLen, 3)
+	c.Check(result.Err(), gc.ErrorMatches, ``+
+		`bundle: applications.db.to\[1\]: "1" is not defined in bundle.machines; `+
+		`extra: no schema for document; `+
+		`model: .*name.*`)
+	c.Check(errors.Is(result.Err(), ErrRequired), jc.IsTrue)
+
+	docs["model"] = map[string]interface{}{"name": "prod", "default-series": "focal"}
+	delete(docs, "extra")
+	docs["bundle"]["applications"] = map[string]interface{}{}
+	result = ValidateBatch(batchSchemas(c), docs)
+	c.Check(result.Err(), gc.ErrorMatches, `model: default-series: no machine uses this series`)
+}
+
+func (BatchSuite) TestKeyOfOutsideBatch(c *gc.C) {
+	s := batchSchemas(c)["bundle"]
+	err := s.Validate(map[string]interface{}{
+		"applications": map[string]interface{}{
+			"db": map[string]interface{}{"to": []interface{}{"7"}},
+		},
+	})
+	c.Check(err, jc.ErrorIsNil)
+}
@@ -0,0 +1,129 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"github.com/lestrrat/go-jsschema"
+)
+
+// exclusiveBounds holds the exclusive bound keywords, along with the
+// inclusive bound that they are given with in draft 4 and the key under
+// which a numeric bound is held while jsschema decodes the schema, as it
+// only accepts the boolean form. It is a function rather than a package
+// variable, as schemas are decoded into some package variables while the
+// package is initialized.
+func exclusiveBounds() []struct{ keyword, bound, held string } {
+	return []struct{ keyword, bound, held string }{
+		{"exclusiveMinimum", "minimum", "$exclusiveMinimum"},
+		{"exclusiveMaximum", "maximum", "$exclusiveMaximum"},
+	}
+}
+
+// normalizeExclusiveBounds holds the numeric exclusiveMinimum and
+// exclusiveMaximum of the raw schema m under other keys, for
+// takeExclusiveBounds to take once jsschema has decoded the schema, and
+// reports whether it changed any. The boolean form of draft 4 is left for
+// jsschema to decode.
+func normalizeExclusiveBounds(m map[string]interface{}) bool {
+	changed := false
+	for _, b := range exclusiveBounds() {
+		v, ok := m[b.keyword]
+		if _, isBool := v.(bool); !ok || isBool {
+			continue
+		}
+		delete(m, b.keyword)
+		m[b.held] = v
+		changed = true
+	}
+	return changed
+}
+
+// takeExclusiveBounds sets the numeric exclusive bounds of s from those held
+// by normalizeExclusiveBounds in the extras decoded by jsschema, and returns
+// a copy of the extras without them.
+func takeExclusiveBounds(extras map[string]interface{}, s *Schema) map[string]interface{} {
+	rest := make(map[string]interface{}, len(extras))
+	for k, v := range extras {
+		rest[k] = v
+	}
+	for _, b := range exclusiveBounds() {
+		v, ok := rest[b.held]
+		if !ok {
+			continue
+		}
+		delete(rest, b.held)
+		if f, ok := v.(float64); ok {
+			if b.keyword == "exclusiveMinimum" {
+				s.ExclusiveMinimumValue = &f
+			} else {
+				s.ExclusiveMaximumValue = &f
+			}
+		}
+	}
+	return rest
+}
+
+// exclusiveLower returns the exclusive lower bound of s, given in either
+// form of exclusiveMinimum, and whether it has one.
+func (s *Schema) exclusiveLower() (float64, bool) {
+	switch {
+	case s.ExclusiveMinimumValue != nil:
+		return *s.ExclusiveMinimumValue, true
+	case s.ExclusiveMinimum != nil && *s.ExclusiveMinimum && s.Minimum != nil:
+		return *s.Minimum, true
+	}
+	return 0, false
+}
+
+// exclusiveUpper returns the exclusive upper bound of s in the same way as
+// exclusiveLower.
+func (s *Schema) exclusiveUpper() (float64, bool) {
+	switch {
+	case s.ExclusiveMaximumValue != nil:
+		return *s.ExclusiveMaximumValue, true
+	case s.ExclusiveMaximum != nil && *s.ExclusiveMaximum && s.Maximum != nil:
+		return *s.Maximum, true
+	}
+	return 0, false
+}
+
+// lowerBound returns the lowest value allowed by the minimum and
+// exclusiveMinimum of s, whichever is tighter, and whether the value itself
+// is excluded. Ok is false if s has neither.
+func (s *Schema) lowerBound() (bound float64, exclusive, ok bool) {
+	if lo, ok := s.exclusiveLower(); ok && (s.Minimum == nil || lo >= *s.Minimum) {
+		return lo, true, true
+	}
+	if s.Minimum != nil {
+		return *s.Minimum, false, true
+	}
+	return 0, false, false
+}
+
+// upperBound returns the highest value allowed by the maximum and
+// exclusiveMaximum of s in the same way as lowerBound.
+func (s *Schema) upperBound() (bound float64, exclusive, ok bool) {
+	if hi, ok := s.exclusiveUpper(); ok && (s.Maximum == nil || hi <= *s.Maximum) {
+		return hi, true, true
+	}
+	if s.Maximum != nil {
+		return *s.Maximum, false, true
+	}
+	return 0, false, false
+}
+
+// boundsInternal adjusts in, the internal form of s, if s has an exclusive
+// bound, giving it in the boolean form that jsschema checks, in place of
+// the inclusive bound if that is looser. The internal form is only adjusted
+// for validation, so that s is still marshaled with its own keywords.
+func boundsInternal(s *Schema, in *schema.Schema) {
+	if lo, exclusive, ok := s.lowerBound(); ok && exclusive {
+		in.Minimum = schema.Number{Val: lo, Initialized: true}
+		in.ExclusiveMinimum = schema.Bool{Val: true, Initialized: true}
+	}
+	if hi, exclusive, ok := s.upperBound(); ok && exclusive {
+		in.Maximum = schema.Number{Val: hi, Initialized: true}
+		in.ExclusiveMaximum = schema.Bool{Val: true, Initialized: true}
+	}
+}
@@ -0,0 +1,153 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"encoding/json"
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type BoundsSuite struct{}
+
+var _ = gc.Suite(BoundsSuite{})
+
+var boundsSchema = `
+type: object
+properties:
+  ratio:
+    type: number
+    exclusiveMinimum: 0
+    exclusiveMaximum: 1
+  weight:
+    type: number
+    minimum: 0
+    exclusiveMinimum: 0.5
+    maximum: 10
+  legacy:
+    type: integer
+    minimum: 0
+    exclusiveMinimum: true
+    maximum: 100
+    exclusiveMaximum: false
+`
+
+func (BoundsSuite) TestDecode(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(boundsSchema))
+	c.Assert(err, jc.ErrorIsNil)
+	ratio := s.Properties["ratio"]
+	c.Check(ratio.Minimum, gc.IsNil)
+	c.Check(ratio.ExclusiveMinimumValue, jc.DeepEquals, Float(0))
+	c.Check(ratio.ExclusiveMaximumValue, jc.DeepEquals, Float(1))
+	c.Check(ratio.ExclusiveMinimum, gc.IsNil)
+	weight := s.Properties["weight"]
+	c.Check(weight.Minimum, jc.DeepEquals, Float(0))
+	c.Check(weight.ExclusiveMinimumValue, jc.DeepEquals, Float(0.5))
+	legacy := s.Properties["legacy"]
+	c.Check(legacy.Minimum, jc.DeepEquals, Float(0))
+	c.Check(legacy.ExclusiveMinimum, jc.DeepEquals, Bool(true))
+	c.Check(legacy.ExclusiveMinimumValue, gc.IsNil)
+	c.Check(legacy.Maximum, jc.DeepEquals, Float(100))
+	c.Check(legacy.ExclusiveMaximum, jc.DeepEquals, Bool(false))
+	c.Check(s.Unknown, gc.IsNil)
+	c.Check(ratio.Unknown, gc.IsNil)
+}
+
+func (BoundsSuite) TestValidate(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(boundsSchema))
+	c.Assert(err, jc.ErrorIsNil)
+	for i, test := range []struct {
+		doc map[string]interface{}
+		err string
+	}{{
+		doc: map[string]interface{}{"ratio": 0.5, "weight": 0.6, "legacy": 1},
+	}, {
+		doc: map[string]interface{}{"legacy": 100},
+	}, {
+		doc: map[string]interface{}{"ratio": 0.0},
+		err: `ratio: .*exclusive minimum.*`,
+	}, {
+		doc: map[string]interface{}{"ratio": 1.0},
+		err: `ratio: .*exclusive maximum.*`,
+	}, {
+		doc: map[string]interface{}{"weight": 0.5},
+		err: `weight: .*exclusive minimum.*`,
+	}, {
+		doc: map[string]interface{}{"legacy": 0},
+		err: `legacy: .*exclusive minimum.*`,
+	}} {
+		c.Logf("test %d: %v", i, test.doc)
+		err := s.Validate(test.doc)
+		if test.err == "" {
+			c.Check(err, jc.ErrorIsNil)
+			continue
+		}
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (BoundsSuite) TestInclusiveBoundTighter(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(`{type: number, minimum: 5, exclusiveMinimum: 1}`))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.Validate(5.0), jc.ErrorIsNil)
+	c.Check(s.Validate(4.0), gc.ErrorMatches, `.*less than the minimum`)
+}
+
+func (BoundsSuite) TestRoundTrip(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(boundsSchema))
+	c.Assert(err, jc.ErrorIsNil)
+	data, err := json.Marshal(s)
+	c.Assert(err, jc.ErrorIsNil)
+	var m map[string]interface{}
+	c.Assert(json.Unmarshal(data, &m), jc.ErrorIsNil)
+	props := m["properties"].(map[string]interface{})
+	c.Check(props["ratio"], jc.DeepEquals, map[string]interface{}{
+		"type":               "number",
+		"exclusiveMinimum":   0.0,
+		"exclusiveMaximum":   1.0,
+		"min-reader-version": 2.0,
+	})
+	// The boolean form of draft 4 is kept as it is.
+	c.Check(props["legacy"], jc.DeepEquals, map[string]interface{}{
+		"type":             "integer",
+		"minimum":          0.0,
+		"exclusiveMinimum": true,
+		"maximum":          100.0,
+		"exclusiveMaximum": false,
+	})
+	var out Schema
+	c.Assert(json.Unmarshal(data, &out), jc.ErrorIsNil)
+	c.Check(out.Properties["weight"].Minimum, jc.DeepEquals, Float(0))
+	c.Check(out.Properties["weight"].ExclusiveMinimumValue, jc.DeepEquals, Float(0.5))
+	// The reader version is implied by the numeric form, so it isn't kept.
+	c.Check(out.Properties["weight"].MinReaderVersion, gc.Equals, 0)
+	c.Check(out.Properties["legacy"], jc.DeepEquals, s.Properties["legacy"])
+
+	// A higher version set by the author is kept.
+	data, err = json.Marshal(&Schema{ExclusiveMinimumValue: Float(1), MinReaderVersion: 5})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(json.Unmarshal(data, &m), jc.ErrorIsNil)
+	c.Check(m["min-reader-version"], gc.Equals, 5.0)
+}
+
+func (BoundsSuite) TestLint(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(`
+type: object
+properties:
+  open: {type: number, exclusiveMinimum: 1, exclusiveMaximum: 1}
+  crossed: {type: number, exclusiveMinimum: 5, maximum: 2}
+`))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.Lint(), jc.DeepEquals, []string{
+		`#/properties/crossed: exclusiveMinimum 5 is greater than maximum 2`,
+		`#/properties/open: exclusive bounds of 1 allow no value`,
+	})
+}
+
+func (BoundsSuite) TestBothForms(c *gc.C) {
+	_, err := json.Marshal(&Schema{Minimum: Float(1), ExclusiveMinimum: Bool(true), ExclusiveMinimumValue: Float(2)})
+	c.Check(err, gc.ErrorMatches, ".*exclusive bound given in both its boolean and numeric forms")
+}
@@ -0,0 +1,122 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"sync"
+	"time"
+)
+
+// ResultCache caches the results of validation, so that agents which
+// validate the same unchanged document on every poll cycle only pay for
+// validating it once. Results are keyed by the hash of the document, the
+// fingerprint of the schema and the values in the ValidationOptions that
+// affect them, and expire after a fixed time. See ValidationOptions.Cache.
+//
+// A cached result is returned without validating the document again, so
+// defaults aren't inserted into it and warnings aren't issued, and custom
+// validators whose decisions depend on anything other than the document and
+// the options should not be used with a cache. A ResultCache is safe for
+// concurrent use.
+type ResultCache struct {
+	ttl time.Duration
+	now func() time.Time
+
+	mu      sync.Mutex
+	entries map[resultKey]resultEntry
+}
+
+type resultKey struct {
+	schema, document, options string
+}
+
+type resultEntry struct {
+	err     error
+	expires time.Time
+}
+
+// NewResultCache returns a ResultCache which keeps results for the given
+// time.
+func NewResultCache(ttl time.Duration) *ResultCache {
+	return &ResultCache{
+		ttl:     ttl,
+		now:     time.Now,
+		entries: make(map[resultKey]resultEntry),
+	}
+}
+
+// Len returns the number of results held in the cache, including any
+// which have expired but not yet been removed.
+func (c *ResultCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.entries)
+}
+
+// Clear removes all results from the cache, for example after registering
+// a validator which changes how documents are checked.
+func (c *ResultCache) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = make(map[resultKey]resultEntry)
+}
+
+func (c *ResultCache) get(key resultKey) (error, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if !c.now().Before(e.expires) {
+		delete(c.entries, key)
+		return nil, false
+	}
+	return e.err, true
+}
+
+func (c *ResultCache) put(key resultKey, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.now()
+	for k, e := range c.entries {
+		if !now.Before(e.expires) {
+			delete(c.entries, k)
+		}
+	}
+	c.entries[key] = resultEntry{err: err, expires: now.Add(c.ttl)}
+}
+
+// cached returns the result of validating x against the schema with the
+// given fingerprint from opts.Cache if it holds one, and otherwise the
+// result of validate, storing it in the cache. Documents and contexts which
+// can't be encoded as json are never cached.
+func (opts ValidationOptions) cached(fingerprint string, x interface{}, validate func() error) error {
+	if opts.Cache == nil || fingerprint == "" {
+		return validate()
+	}
+	key := resultKey{
+		schema:   fingerprint,
+		document: hashJSON(x),
+		options: hashJSON(struct {
+			Values         map[string]interface{}
+			FeatureFlags   []string
+			Profile        string
+			Role           string
+			LenientDates   bool
+			AllowNonFinite bool
+			Documents      map[string]map[string]interface{}
+			Language       string
+		}{opts.Values, opts.FeatureFlags, opts.Profile, opts.Role, opts.LenientDates, opts.AllowNonFinite, opts.Documents, opts.Language}),
+	}
+	if key.document == "" || key.options == "" {
+		return validate()
+	}
+	if err, ok := opts.Cache.get(key); ok {
+		return err
+	}
+	err := validate()
+	opts.Cache.put(key, err)
+	return err
+}
@@ -0,0 +1,96 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"fmt"
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type CacheSuite struct{}
+
+var _ = gc.Suite(CacheSuite{})
+
+// cacheSchema returns a schema whose name property is checked by a custom
+// validator that counts its calls in *calls.
+func cacheSchema(calls *int) *Schema {
+	RegisterValidator("test-counted", func(_ ValidationOptions, v interface{}) error {
+		*calls++
+		if v == "bad" {
+			return fmt.Errorf("bad name")
+		}
+		return nil
+	})
+	return &Schema{
+		Type: []Type{ObjectType},
+		Properties: map[string]*Schema{
+			"name": {Type: []Type{StringType}, Validators: []string{"test-counted"}},
+		},
+	}
+}
+
+func (CacheSuite) TestResultCache(c *gc.C) {
+	var calls int
+	s := cacheSchema(&calls)
+	v, err := NewValidator(s)
+	c.Assert(err, jc.ErrorIsNil)
+	for _, validate := range []func(ValidationOptions, interface{}) error{s.ValidateWith, v.ValidateWith} {
+		calls = 0
+		cache := NewResultCache(time.Minute)
+		now := time.Now()
+		cache.now = func() time.Time { return now }
+		opts := ValidationOptions{Cache: cache}
+
+		for i := 0; i < 3; i++ {
+			c.Check(validate(opts, map[string]interface{}{"name": "juju"}), jc.ErrorIsNil)
+			c.Check(validate(opts, map[string]interface{}{"name": "bad"}), gc.ErrorMatches, `name: bad name`)
+		}
+		c.Check(calls, gc.Equals, 2)
+		c.Check(cache.Len(), gc.Equals, 2)
+
+		// The values in the context are part of the key.
+		c.Check(validate(ValidationOptions{Cache: cache, Role: "admin"}, map[string]interface{}{"name": "juju"}), jc.ErrorIsNil)
+		c.Check(calls, gc.Equals, 3)
+
+		// Results expire after the TTL.
+		now = now.Add(time.Minute)
+		c.Check(validate(opts, map[string]interface{}{"name": "juju"}), jc.ErrorIsNil)
+		c.Check(calls, gc.Equals, 4)
+		c.Check(cache.Len(), gc.Equals, 1)
+
+		cache.Clear()
+		c.Check(validate(opts, map[string]interface{}{"name": "juju"}), jc.ErrorIsNil)
+		c.Check(calls, gc.Equals, 5)
+	}
+}
+
+func (CacheSuite) TestResultCacheSchemaFingerprint(c *gc.C) {
+	var calls int
+	s := cacheSchema(&calls)
+	opts := ValidationOptions{Cache: NewResultCache(time.Minute)}
+	doc := map[string]interface{}{"name": "juju"}
+	c.Assert(s.ValidateWith(opts, doc), jc.ErrorIsNil)
+
+	// Changing the schema changes its fingerprint.
+	maxLength := 2
+	s.Properties["name"].MaxLength = &maxLength
+	c.Check(s.ValidateWith(opts, doc), gc.NotNil)
+	c.Check(calls, gc.Equals, 1)
+}
+
+func (CacheSuite) TestResultCacheAudit(c *gc.C) {
+	var calls int
+	s := cacheSchema(&calls)
+	sink := &recordingSink{}
+	opts := ValidationOptions{Cache: NewResultCache(time.Minute), Audit: sink}
+	for i := 0; i < 2; i++ {
+		c.Check(s.ValidateWith(opts, map[string]interface{}{"name": "bad"}), gc.NotNil)
+	}
+	// Cached failures are still audited.
+	c.Check(calls, gc.Equals, 1)
+	c.Check(sink.records, gc.HasLen, 2)
+}
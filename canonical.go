@@ -0,0 +1,204 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"reflect"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Style selects the output format of FormatSchema.
+type Style int
+
+const (
+	// StyleYAML formats schemas as yaml.
+	StyleYAML Style = iota
+
+	// StyleJSON formats schemas as json.
+	StyleJSON
+)
+
+// FormatSchema parses the json or yaml schema in r and returns it in the
+// canonical form for the given style, for use as a formatter for schema
+// files. In canonical form:
+//
+//   - keywords are ordered as in the Schema struct, with standard keywords
+//     before juju extensions, followed by unknown keywords in sorted order;
+//   - properties, definitions and other named schemas are sorted by name;
+//   - types given as a list are sorted, with duplicates removed, and a list
+//     of one type is replaced by the type itself;
+//   - nested values are indented by two spaces.
+//
+// Formatting is idempotent. Comments are not preserved; see YAMLFile.
+func FormatSchema(r io.Reader, style Style) ([]byte, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	val, err := readYAML(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	if _, err := FromGo(val); err != nil {
+		return nil, fmt.Errorf("invalid schema: %v", err)
+	}
+	canonical := canonicalSchema(val)
+	switch style {
+	case StyleYAML:
+		var buf bytes.Buffer
+		enc := yaml.NewEncoder(&buf)
+		enc.SetIndent(2)
+		if err := enc.Encode(canonical); err != nil {
+			return nil, err
+		}
+		if err := enc.Close(); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	case StyleJSON:
+		out, err := json.MarshalIndent(canonical, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		return append(out, '\n'), nil
+	}
+	return nil, fmt.Errorf("unknown style %d", style)
+}
+
+// keywordOrder maps each keyword known to this package to its position in
+// the Schema struct.
+var keywordOrder = func() map[string]int {
+	order := make(map[string]int)
+	t := reflect.TypeOf(Schema{})
+	for i := 0; i < t.NumField(); i++ {
+		if name := fieldKeyword(t.Field(i)); name != "" {
+			order[name] = i
+		}
+	}
+	return order
+}()
+
+// orderedMap holds a json object whose keys are marshaled in a given order.
+type orderedMap struct {
+	keys   []string
+	values map[string]interface{}
+}
+
+// MarshalJSON implements json.Marshaler.
+func (m orderedMap) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, k := range m.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, err := json.Marshal(k)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(m.values[k])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// MarshalYAML implements yaml.Marshaler.
+func (m orderedMap) MarshalYAML() (interface{}, error) {
+	node := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	for _, k := range m.keys {
+		var key, value yaml.Node
+		if err := key.Encode(k); err != nil {
+			return nil, err
+		}
+		if err := value.Encode(m.values[k]); err != nil {
+			return nil, err
+		}
+		node.Content = append(node.Content, &key, &value)
+	}
+	return node, nil
+}
+
+// canonicalSchema returns the json schema v, as decoded into an
+// interface{}, in canonical form.
+func canonicalSchema(v interface{}) interface{} {
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return v
+	}
+	out := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		out[k] = v
+	}
+	if types, ok := out["type"].([]interface{}); ok {
+		out["type"] = canonicalTypes(types)
+	}
+	for _, k := range schemaKeywords {
+		if sub, ok := out[k].(map[string]interface{}); ok {
+			out[k] = canonicalSchema(sub)
+		}
+	}
+	for _, k := range schemaListKeywords {
+		if l, ok := out[k].([]interface{}); ok {
+			subs := make([]interface{}, len(l))
+			for i, sub := range l {
+				subs[i] = canonicalSchema(sub)
+			}
+			out[k] = subs
+		}
+	}
+	for _, k := range schemaMapKeywords {
+		if subs, ok := out[k].(map[string]interface{}); ok {
+			canonical := make(map[string]interface{}, len(subs))
+			for name, sub := range subs {
+				canonical[name] = canonicalSchema(sub)
+			}
+			out[k] = orderedMap{keys: sortedObjectKeys(canonical), values: canonical}
+		}
+	}
+	keys := sortedObjectKeys(out)
+	sort.SliceStable(keys, func(i, j int) bool {
+		oi, iKnown := keywordOrder[keys[i]]
+		oj, jKnown := keywordOrder[keys[j]]
+		if iKnown != jKnown {
+			return iKnown
+		}
+		return iKnown && oi < oj
+	})
+	return orderedMap{keys: keys, values: out}
+}
+
+// canonicalTypes returns the list of types in canonical form.
+func canonicalTypes(types []interface{}) interface{} {
+	seen := make(map[string]bool)
+	var names []string
+	for _, t := range types {
+		name := fmt.Sprint(t)
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	if len(names) == 1 {
+		return names[0]
+	}
+	out := make([]interface{}, len(names))
+	for i, name := range names {
+		out[i] = name
+	}
+	return out
+}
@@ -0,0 +1,82 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"bytes"
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type CanonicalSuite struct{}
+
+var _ = gc.Suite(CanonicalSuite{})
+
+const messySchema = `{
+"properties": {"zone": {"type": ["string"], "secret": true, "x-ui": 1, "description": "The zone."},
+  "count": {"maximum": 9, "type": ["number", "integer", "number"], "minimum": 1}},
+    "required": ["zone"], "type": "object", "title": "Placement"}`
+
+func (CanonicalSuite) TestFormatSchemaYAML(c *gc.C) {
+	out, err := FormatSchema(strings.NewReader(messySchema), StyleYAML)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(out), gc.Equals, `title: Placement
+type: object
+required:
+  - zone
+properties:
+  count:
+    type:
+      - integer
+      - number
+    minimum: 1
+    maximum: 9
+  zone:
+    description: The zone.
+    type: string
+    secret: true
+    x-ui: 1
+`)
+
+	// Formatting is idempotent.
+	again, err := FormatSchema(bytes.NewReader(out), StyleYAML)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(again), gc.Equals, string(out))
+}
+
+func (CanonicalSuite) TestFormatSchemaJSON(c *gc.C) {
+	out, err := FormatSchema(strings.NewReader(messySchema), StyleJSON)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(out), gc.Equals, `{
+  "title": "Placement",
+  "type": "object",
+  "required": [
+    "zone"
+  ],
+  "properties": {
+    "count": {
+      "type": [
+        "integer",
+        "number"
+      ],
+      "minimum": 1,
+      "maximum": 9
+    },
+    "zone": {
+      "description": "The zone.",
+      "type": "string",
+      "secret": true,
+      "x-ui": 1
+    }
+  }
+}
+`)
+}
+
+func (CanonicalSuite) TestFormatInvalidSchema(c *gc.C) {
+	_, err := FormatSchema(strings.NewReader(`{"type": "strin"}`), StyleYAML)
+	c.Check(err, gc.ErrorMatches, `invalid schema: .*`)
+}
@@ -0,0 +1,101 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// catalogVersion is the version of the format produced by ExportCatalog. It
+// is incremented whenever a change is made that consumers would need to
+// handle.
+const catalogVersion = 1
+
+// catalog is the format produced by ExportCatalog.
+type catalog struct {
+	Version int             `json:"version"`
+	Schemas []catalogSchema `json:"schemas"`
+}
+
+// catalogSchema summarizes one schema in a catalog.
+type catalogSchema struct {
+	Name        string            `json:"name"`
+	Version     string            `json:"version,omitempty"`
+	Fingerprint string            `json:"fingerprint"`
+	Title       string            `json:"title,omitempty"`
+	Description string            `json:"description,omitempty"`
+	Properties  []catalogProperty `json:"properties"`
+}
+
+// catalogProperty summarizes one leaf property of a schema in a catalog.
+type catalogProperty struct {
+	Key         string        `json:"key"`
+	Type        []string      `json:"type,omitempty"`
+	Description string        `json:"description,omitempty"`
+	Default     interface{}   `json:"default,omitempty"`
+	Enum        []interface{} `json:"enum,omitempty"`
+	Unit        Unit          `json:"unit,omitempty"`
+	Required    bool          `json:"required,omitempty"`
+	Secret      bool          `json:"secret,omitempty"`
+	Immutable   bool          `json:"immutable,omitempty"`
+}
+
+// ExportCatalog returns a json catalog of the given schemas, for tools that
+// render a reference of the configuration they describe. Each schema is
+// keyed by its name, optionally followed by "@" and a version, as in
+// "aws@3.1". The catalog holds the name, version, fingerprint (the
+// hex-encoded SHA-256 hash of the schema's json form), title and
+// description of each schema, ordered by name and version, along with a
+// summary of each of its leaf properties, in the order given by Keys. The
+// defaults of secret properties are left out.
+func ExportCatalog(schemas map[string]*Schema) ([]byte, error) {
+	c := catalog{Version: catalogVersion, Schemas: []catalogSchema{}}
+	for key, s := range schemas {
+		fingerprint := hashJSON(s)
+		if fingerprint == "" {
+			return nil, fmt.Errorf("cannot export schema %q: cannot encode as json", key)
+		}
+		entry := catalogSchema{
+			Name:        key,
+			Fingerprint: fingerprint,
+			Title:       s.Title,
+			Description: s.Description,
+			Properties:  []catalogProperty{},
+		}
+		if i := strings.LastIndex(key, "@"); i >= 0 {
+			entry.Name, entry.Version = key[:i], key[i+1:]
+		}
+		for _, leaf := range s.leaves() {
+			ps := leaf.schema
+			p := catalogProperty{
+				Key:         dottedKey(leaf.path),
+				Description: ps.Description,
+				Enum:        ps.Enum,
+				Unit:        ps.Unit,
+				Required:    leaf.required,
+				Secret:      ps.Secret,
+				Immutable:   ps.Immutable,
+			}
+			for _, t := range ps.Type {
+				p.Type = append(p.Type, t.String())
+			}
+			if !ps.Secret {
+				p.Default = ps.Default
+			}
+			entry.Properties = append(entry.Properties, p)
+		}
+		c.Schemas = append(c.Schemas, entry)
+	}
+	sort.Slice(c.Schemas, func(i, j int) bool {
+		a, b := c.Schemas[i], c.Schemas[j]
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		return a.Version < b.Version
+	})
+	return json.MarshalIndent(c, "", "  ")
+}
@@ -0,0 +1,107 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"encoding/json"
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type CatalogSuite struct{}
+
+var _ = gc.Suite(CatalogSuite{})
+
+const catalogYAML = `
+title: AWS
+description: Settings for the AWS provider.
+type: object
+required: [region]
+properties:
+  region:
+    type: string
+    description: The region to deploy to.
+    enum: [us-east-1, eu-west-2]
+    immutable: true
+  secret-key:
+    type: string
+    secret: true
+    default: changeme
+  disk:
+    type: integer
+    unit: MiB
+    default: 8192
+  nodes:
+    type: array
+    items:
+      type: object
+      properties:
+        address:
+          type: string
+`
+
+func (CatalogSuite) TestExportCatalog(c *gc.C) {
+	aws, err := FromYAML(strings.NewReader(catalogYAML))
+	c.Assert(err, jc.ErrorIsNil)
+	lxd := &Schema{Type: []Type{ObjectType}}
+	data, err := ExportCatalog(map[string]*Schema{
+		"aws@3.1": aws,
+		"aws@2.9": aws,
+		"lxd":     lxd,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	var got map[string]interface{}
+	err = json.Unmarshal(data, &got)
+	c.Assert(err, jc.ErrorIsNil)
+	awsProperties := []interface{}{
+		map[string]interface{}{
+			"key":     "disk",
+			"type":    []interface{}{"integer"},
+			"default": float64(8192),
+			"unit":    "MiB",
+		},
+		map[string]interface{}{
+			"key":  "nodes[*].address",
+			"type": []interface{}{"string"},
+		},
+		map[string]interface{}{
+			"key":         "region",
+			"type":        []interface{}{"string"},
+			"description": "The region to deploy to.",
+			"enum":        []interface{}{"us-east-1", "eu-west-2"},
+			"required":    true,
+			"immutable":   true,
+		},
+		map[string]interface{}{
+			"key":    "secret-key",
+			"type":   []interface{}{"string"},
+			"secret": true,
+		},
+	}
+	awsEntry := func(version string) map[string]interface{} {
+		return map[string]interface{}{
+			"name":        "aws",
+			"version":     version,
+			"fingerprint": hashJSON(aws),
+			"title":       "AWS",
+			"description": "Settings for the AWS provider.",
+			"properties":  awsProperties,
+		}
+	}
+	c.Check(got, jc.DeepEquals, map[string]interface{}{
+		"version": float64(1),
+		"schemas": []interface{}{
+			awsEntry("2.9"),
+			awsEntry("3.1"),
+			map[string]interface{}{
+				"name":        "lxd",
+				"fingerprint": hashJSON(lxd),
+				"properties":  []interface{}{},
+			},
+		},
+	})
+}
@@ -0,0 +1,112 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	"gopkg.in/yaml.v3"
+)
+
+// charmConfig mirrors the format of a charm's config.yaml.
+type charmConfig struct {
+	Options map[string]charmOption `yaml:"options"`
+}
+
+type charmOption struct {
+	Type        string      `yaml:"type"`
+	Description string      `yaml:"description,omitempty"`
+	Default     interface{} `yaml:"default,omitempty"`
+}
+
+// charmTypes maps charm option types to the equivalent jsonschema types.
+var charmTypes = map[string]Type{
+	"string":  StringType,
+	"int":     IntegerType,
+	"float":   NumberType,
+	"boolean": BooleanType,
+	// Secret options hold the URI of a juju secret.
+	"secret": StringType,
+}
+
+// FromCharmConfig returns a schema created from the charm config.yaml in r.
+// Each option becomes a property of an object schema, with its description
+// and default. Options of type secret hold the URI of a juju secret, and
+// become strings with FormatSecretURI.
+func FromCharmConfig(r io.Reader) (*Schema, error) {
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	var config charmConfig
+	if err := yaml.Unmarshal(b, &config); err != nil {
+		return nil, err
+	}
+	s := &Schema{
+		Type:       []Type{ObjectType},
+		Properties: make(map[string]*Schema),
+	}
+	for name, opt := range config.Options {
+		if opt.Type == "" {
+			// Juju treats options without a type as strings.
+			opt.Type = "string"
+		}
+		t, ok := charmTypes[opt.Type]
+		if !ok {
+			return nil, fmt.Errorf("option %q has unknown type %q", name, opt.Type)
+		}
+		def := opt.Default
+		if i, ok := def.(int); ok && t == NumberType {
+			def = float64(i)
+		}
+		ps := &Schema{
+			Type:        []Type{t},
+			Description: opt.Description,
+			Default:     def,
+		}
+		if opt.Type == "secret" {
+			ps.Format = FormatSecretURI
+		}
+		s.Properties[name] = ps
+	}
+	return s, nil
+}
+
+// ToCharmConfig returns s in the format of a charm's config.yaml. The schema
+// must be an object whose properties all have a single simple type. Only
+// strings with FormatSecretURI become options of type secret; other strings,
+// including those marked as Secret, become options of type string.
+func ToCharmConfig(s *Schema) ([]byte, error) {
+	config := charmConfig{Options: make(map[string]charmOption)}
+	for _, name := range sortedKeys(s.Properties) {
+		ps := s.Properties[name]
+		if len(ps.Type) != 1 {
+			return nil, fmt.Errorf("property %q must have exactly one type", name)
+		}
+		var optType string
+		switch ps.Type[0] {
+		case StringType:
+			optType = "string"
+			if ps.Format == FormatSecretURI {
+				optType = "secret"
+			}
+		case IntegerType:
+			optType = "int"
+		case NumberType:
+			optType = "float"
+		case BooleanType:
+			optType = "boolean"
+		default:
+			return nil, fmt.Errorf("property %q has type %s, which charm config does not support", name, ps.Type[0])
+		}
+		config.Options[name] = charmOption{
+			Type:        optType,
+			Description: ps.Description,
+			Default:     ps.Default,
+		}
+	}
+	return yaml.Marshal(config)
+}
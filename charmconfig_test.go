@@ -0,0 +1,98 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"bytes"
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type CharmConfigSuite struct{}
+
+var _ = gc.Suite(CharmConfigSuite{})
+
+const charmConfigExample = `
+options:
+  log-level:
+    type: string
+    description: The log level.
+    default: info
+  workers:
+    type: int
+    default: 4
+  ratio:
+    type: float
+    default: 1
+  debug:
+    type: boolean
+  api-key:
+    type: secret
+    description: The API key.
+  legacy:
+    description: An option without a type.
+`
+
+func (CharmConfigSuite) TestFromCharmConfig(c *gc.C) {
+	s, err := FromCharmConfig(strings.NewReader(charmConfigExample))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s, jc.DeepEquals, &Schema{
+		Type: []Type{ObjectType},
+		Properties: map[string]*Schema{
+			"log-level": {Type: []Type{StringType}, Description: "The log level.", Default: "info"},
+			"workers":   {Type: []Type{IntegerType}, Default: 4},
+			"ratio":     {Type: []Type{NumberType}, Default: float64(1)},
+			"debug":     {Type: []Type{BooleanType}},
+			"api-key":   {Type: []Type{StringType}, Description: "The API key.", Format: FormatSecretURI},
+			"legacy":    {Type: []Type{StringType}, Description: "An option without a type."},
+		},
+	})
+
+	c.Check(s.Validate(map[string]interface{}{"workers": 2, "debug": true}), jc.ErrorIsNil)
+	c.Check(s.Validate(map[string]interface{}{"workers": "two"}), gc.NotNil)
+}
+
+func (CharmConfigSuite) TestFromCharmConfigUnknownType(c *gc.C) {
+	_, err := FromCharmConfig(strings.NewReader("options: {a: {type: list}}"))
+	c.Check(err, gc.ErrorMatches, `option "a" has unknown type "list"`)
+}
+
+func (CharmConfigSuite) TestCharmConfigRoundTrip(c *gc.C) {
+	s, err := FromCharmConfig(strings.NewReader(charmConfigExample))
+	c.Assert(err, jc.ErrorIsNil)
+
+	b, err := ToCharmConfig(s)
+	c.Assert(err, jc.ErrorIsNil)
+	s2, err := FromCharmConfig(bytes.NewReader(b))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s2, jc.DeepEquals, s)
+}
+
+func (CharmConfigSuite) TestToCharmConfigSensitiveString(c *gc.C) {
+	b, err := ToCharmConfig(&Schema{
+		Properties: map[string]*Schema{
+			"admin-password": {Type: []Type{StringType}, Secret: true},
+			"api-key":        {Type: []Type{StringType}, Format: FormatSecretURI},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(b), gc.Equals, `
+options:
+    admin-password:
+        type: string
+    api-key:
+        type: secret
+`[1:])
+}
+
+func (CharmConfigSuite) TestToCharmConfigUnsupported(c *gc.C) {
+	_, err := ToCharmConfig(&Schema{
+		Properties: map[string]*Schema{
+			"nested": {Type: []Type{ObjectType}},
+		},
+	})
+	c.Check(err, gc.ErrorMatches, `property "nested" has type object, which charm config does not support`)
+}
@@ -0,0 +1,221 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import "encoding/json"
+
+// ChangeKind describes how a value differs between two documents.
+type ChangeKind string
+
+const (
+	// ChangeAdded means that the value is only set in the new document.
+	ChangeAdded ChangeKind = "added"
+
+	// ChangeRemoved means that the value is only set in the old document.
+	ChangeRemoved ChangeKind = "removed"
+
+	// ChangeModified means that the value is set in both documents, but
+	// differs.
+	ChangeModified ChangeKind = "modified"
+)
+
+// DocChange describes a value which differs between two documents described
+// by the same schema.
+type DocChange struct {
+	// Path holds the dotted path of the value, as used by FlattenDoc.
+	Path string
+
+	// Kind holds the kind of change.
+	Kind ChangeKind
+
+	// Type holds the type of the value: the type declared by its schema,
+	// if it declares only one, or the type of the value itself otherwise.
+	Type Type
+
+	// Old and New hold the old and new values, formatted as by
+	// FlattenDoc, or are empty if the value isn't set. Arrays and objects
+	// are formatted as json.
+	Old, New string
+
+	// Secret reports whether the value is secret. If so, Old and New are
+	// redacted.
+	Secret bool
+
+	// Immutable reports whether the value is immutable, or is part of an
+	// immutable value, so that the change is not allowed.
+	Immutable bool
+}
+
+// CompareDocs returns the changes between the documents a and b, both
+// described by s, ordered as the properties are ordered by s (see
+// Schema.Order). Objects are compared property by property, and arrays
+// with the item-key keyword item by item, with paths such as
+// "endpoints[name=api].port"; other arrays, and the values of secret
+// properties, are compared as a whole, regardless of order for sets.
+func CompareDocs(s *Schema, a, b map[string]interface{}) []DocChange {
+	var changes []DocChange
+	compareObjects(s, s, a, b, "", false, &changes)
+	return changes
+}
+
+func compareObjects(root, s *Schema, a, b map[string]interface{}, path string, immutable bool, changes *[]DocChange) {
+	union := make(map[string]interface{}, len(a)+len(b))
+	for k, v := range a {
+		union[k] = v
+	}
+	for k, v := range b {
+		union[k] = v
+	}
+	if s != nil {
+		s = s.WithVariants(union)
+	}
+	var keys []string
+	if s != nil {
+		keys = objectKeysInOrder(s, union)
+	} else {
+		keys = sortedObjectKeys(union)
+	}
+	for _, name := range keys {
+		var ps *Schema
+		if s != nil {
+			if schemas := propertySchemas(s, name); len(schemas) > 0 {
+				ps = derefLocal(root, schemas[0])
+			}
+		}
+		before, inBefore := a[name]
+		after, inAfter := b[name]
+		compareValues(root, ps, before, after, inBefore, inAfter, propertyPath(path, name), immutable, changes)
+	}
+}
+
+func compareValues(root, s *Schema, before, after interface{}, inBefore, inAfter bool, path string, immutable bool, changes *[]DocChange) {
+	if inBefore && inAfter && valuesEqual(before, after) {
+		return
+	}
+	if s != nil && s.SetOf {
+		beforeArr, beforeOK := before.([]interface{})
+		afterArr, afterOK := after.([]interface{})
+		if beforeOK && afterOK && setsEqual(s, beforeArr, afterArr) {
+			return
+		}
+		if beforeOK {
+			before = canonicalItems(s, beforeArr)
+		}
+		if afterOK {
+			after = canonicalItems(s, afterArr)
+		}
+	}
+	secret := s != nil && s.Secret
+	immutable = immutable || (s != nil && s.Immutable)
+	if !secret {
+		beforeObj, beforeOK := before.(map[string]interface{})
+		afterObj, afterOK := after.(map[string]interface{})
+		if (beforeOK || !inBefore) && (afterOK || !inAfter) {
+			compareObjects(root, s, beforeObj, afterObj, path, immutable, changes)
+			return
+		}
+		if s != nil && s.ItemKey != "" && compareKeyedItems(root, s, before, after, path, immutable, changes) {
+			return
+		}
+	}
+	change := DocChange{
+		Path:      path,
+		Kind:      ChangeModified,
+		Secret:    secret,
+		Immutable: immutable,
+	}
+	switch {
+	case !inBefore:
+		change.Kind = ChangeAdded
+	case !inAfter:
+		change.Kind = ChangeRemoved
+	}
+	if inBefore {
+		change.Old = formatChangeValue(before, secret)
+	}
+	if inAfter {
+		change.New = formatChangeValue(after, secret)
+		change.Type = valueType(s, after)
+	} else {
+		change.Type = valueType(s, before)
+	}
+	*changes = append(*changes, change)
+}
+
+// compareKeyedItems compares the arrays before and after, described by s,
+// item by item, matching items by the key named by the item-key keyword of
+// s. Either array may be missing. It returns false, having made no
+// comparison, if an item doesn't have a key.
+func compareKeyedItems(root, s *Schema, before, after interface{}, path string, immutable bool, changes *[]DocChange) bool {
+	beforeArr, beforeOK := before.([]interface{})
+	afterArr, afterOK := after.([]interface{})
+	if (before != nil && !beforeOK) || (after != nil && !afterOK) {
+		return false
+	}
+	beforeKeys, beforeItems, ok := keyedItems(s, beforeArr)
+	if !ok {
+		return false
+	}
+	afterKeys, afterItems, ok := keyedItems(s, afterArr)
+	if !ok {
+		return false
+	}
+	item := derefLocal(root, itemSchema(s, 0))
+	compare := func(key string) {
+		v1, in1 := beforeItems[key]
+		v2, in2 := afterItems[key]
+		compareValues(root, item, v1, v2, in1, in2, keyedItemPath(path, s.ItemKey, key), immutable, changes)
+	}
+	for _, key := range beforeKeys {
+		compare(key)
+	}
+	for _, key := range afterKeys {
+		if _, ok := beforeItems[key]; !ok {
+			compare(key)
+		}
+	}
+	return true
+}
+
+// formatChangeValue returns x formatted for a DocChange.
+func formatChangeValue(x interface{}, secret bool) string {
+	if secret {
+		return redacted
+	}
+	switch x := normalizeValue(x).(type) {
+	case map[string]interface{}, []interface{}:
+		data, err := json.Marshal(x)
+		if err != nil {
+			return formatFlatValue(x)
+		}
+		return string(data)
+	}
+	return formatFlatValue(x)
+}
+
+// valueType returns the type of x, which is described by s: the type
+// declared by s if there is only one, or the type of x itself otherwise.
+func valueType(s *Schema, x interface{}) Type {
+	if s != nil && len(s.Type) == 1 {
+		return s.Type[0]
+	}
+	switch x := normalizeValue(x).(type) {
+	case nil:
+		return NullType
+	case bool:
+		return BooleanType
+	case string:
+		return StringType
+	case float64:
+		if x == float64(int64(x)) {
+			return IntegerType
+		}
+		return NumberType
+	case []interface{}:
+		return ArrayType
+	case map[string]interface{}:
+		return ObjectType
+	}
+	return UnspecifiedType
+}
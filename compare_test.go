@@ -0,0 +1,115 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type CompareSuite struct{}
+
+var _ = gc.Suite(CompareSuite{})
+
+const compareSchema = `
+type: object
+order: [name, region]
+properties:
+  name:
+    type: string
+  region:
+    type: string
+    immutable: true
+  password:
+    type: string
+    secret: true
+  replicas:
+    type: integer
+  tags:
+    type: array
+    items:
+      type: string
+  network:
+    type: object
+    properties:
+      mtu:
+        type: integer
+      fan:
+        type: boolean
+`
+
+func (CompareSuite) TestCompareDocs(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(compareSchema))
+	c.Assert(err, jc.ErrorIsNil)
+	a := map[string]interface{}{
+		"name":     "db",
+		"region":   "north",
+		"password": "hunter2",
+		"replicas": 3,
+		"tags":     []interface{}{"a", "b"},
+		"network":  map[string]interface{}{"mtu": 1500, "fan": true},
+	}
+	b := map[string]interface{}{
+		"name":     "db",
+		"region":   "south",
+		"password": "swordfish",
+		"tags":     []interface{}{"a"},
+		"network":  map[string]interface{}{"mtu": float64(9000), "fan": true},
+		"extra":    1.5,
+	}
+	c.Check(CompareDocs(s, a, b), jc.DeepEquals, []DocChange{{
+		Path:      "region",
+		Kind:      ChangeModified,
+		Type:      StringType,
+		Old:       "north",
+		New:       "south",
+		Immutable: true,
+	}, {
+		Path: "network.mtu",
+		Kind: ChangeModified,
+		Type: IntegerType,
+		Old:  "1500",
+		New:  "9000",
+	}, {
+		Path:   "password",
+		Kind:   ChangeModified,
+		Type:   StringType,
+		Old:    "<redacted>",
+		New:    "<redacted>",
+		Secret: true,
+	}, {
+		Path: "replicas",
+		Kind: ChangeRemoved,
+		Type: IntegerType,
+		Old:  "3",
+	}, {
+		Path: "tags",
+		Kind: ChangeModified,
+		Type: ArrayType,
+		Old:  `["a","b"]`,
+		New:  `["a"]`,
+	}, {
+		Path: "extra",
+		Kind: ChangeAdded,
+		Type: NumberType,
+		New:  "1.5",
+	}})
+}
+
+func (CompareSuite) TestCompareDocsNestedAdded(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(compareSchema))
+	c.Assert(err, jc.ErrorIsNil)
+	b := map[string]interface{}{
+		"network": map[string]interface{}{"fan": false},
+	}
+	c.Check(CompareDocs(s, nil, b), jc.DeepEquals, []DocChange{{
+		Path: "network.fan",
+		Kind: ChangeAdded,
+		Type: BooleanType,
+		New:  "false",
+	}})
+	c.Check(CompareDocs(s, b, b), gc.HasLen, 0)
+}
@@ -0,0 +1,378 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"regexp"
+
+	"github.com/lestrrat/go-jsschema"
+)
+
+// composeSchemas adjusts the internal forms, held in cache, of s and the
+// allOf, anyOf and oneOf schemas that it is composed of, so that properties
+// declared by one of them aren't rejected by the others as additional
+// properties. Without this, the properties of a base schema and of the
+// extensions or alternatives composed with it could never be given
+// together. The properties declared by the if, then and else schemas of s
+// are allowed in the same way, regardless of which of them applies.
+//
+// Each schema which doesn't allow additional properties is given the
+// properties declared by the others that it doesn't declare itself, and a
+// schema without a type is left to the schemas it is composed of to
+// constrain the kinds of value it doesn't describe itself, rather than being
+// taken for an empty object or array. The schemas it is composed of are
+// copied for the purpose, as they may also be used elsewhere on their own.
+//
+// The schema that s must not match, and those that depend on the presence
+// of a property, are adjusted as described by openCopy.
+func composeSchemas(root, s *Schema, cache map[*Schema]*schema.Schema) error {
+	union, err := propertyUnion(root, s, cache)
+	if err != nil {
+		return err
+	}
+	in, err := toInternal(s, cache)
+	if err != nil {
+		return err
+	}
+	return composeInternal(root, s, in, cache, union, leftUnevaluated{}.add(s), map[*Schema]bool{s: true})
+}
+
+// propertyUnion returns the internal forms, held in cache, of the properties
+// declared by s, found within root, and by the schemas it is composed of.
+func propertyUnion(root, s *Schema, cache map[*Schema]*schema.Schema) (map[string]*schema.Schema, error) {
+	union := make(map[string]*schema.Schema)
+	for _, cs := range composedSchemas(root, s, true) {
+		for _, name := range sortedKeys(cs.Properties) {
+			if _, ok := union[name]; ok {
+				continue
+			}
+			ps, err := toInternal(cs.Properties[name], cache)
+			if err != nil {
+				return nil, err
+			}
+			union[name] = ps
+		}
+	}
+	return union, nil
+}
+
+// composeInternal adjusts in, the internal form of s, found within root, to
+// allow the properties in union, replacing the schemas it is composed of
+// with similarly adjusted copies. They are also opened as described by
+// left, when they are composed into a schema with unevaluatedProperties or
+// unevaluatedItems. Seen holds the schemas being adjusted, so that
+// recursive schemas aren't copied forever.
+func composeInternal(root, s *Schema, in *schema.Schema, cache map[*Schema]*schema.Schema, union map[string]*schema.Schema, left leftUnevaluated, seen map[*Schema]bool) error {
+	if err := positionalInternal(s, in, cache); err != nil {
+		return err
+	}
+	boundsInternal(s, in)
+	constInternal(s, in)
+	multipleOfInternal(s, in)
+	openUntyped(s, in)
+	left.open(s, in)
+	if in.AdditionalProperties == nil {
+		properties := make(map[string]*schema.Schema)
+		for name, ps := range in.Properties {
+			properties[name] = ps
+		}
+		for name, ps := range union {
+			if _, ok := properties[name]; !ok && !matchesPattern(in, name) {
+				properties[name] = ps
+			}
+		}
+		in.Properties = properties
+	}
+	compose := func(l []*Schema) (schema.SchemaList, error) {
+		out := make(schema.SchemaList, len(l))
+		for i, sub := range l {
+			sub = derefLocal(root, sub)
+			var err error
+			if seen[sub] {
+				if out[i], err = toInternal(sub, cache); err != nil {
+					return nil, err
+				}
+				continue
+			}
+			// The internal form of a copy of sub is a new schema,
+			// which can be adjusted without affecting uses of sub
+			// elsewhere.
+			c := *sub
+			if out[i], err = toInternal(&c, cache); err != nil {
+				return nil, err
+			}
+			seen[sub] = true
+			err = composeInternal(root, sub, out[i], cache, union, left.add(sub), seen)
+			delete(seen, sub)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return out, nil
+	}
+	var err error
+	if len(s.AllOf) > 0 {
+		if in.AllOf, err = compose(s.AllOf); err != nil {
+			return err
+		}
+	}
+	if len(s.AnyOf) > 0 {
+		if in.AnyOf, err = compose(s.AnyOf); err != nil {
+			return err
+		}
+	}
+	if len(s.OneOf) > 0 {
+		if in.OneOf, err = compose(s.OneOf); err != nil {
+			return err
+		}
+	}
+	if s.Not != nil {
+		if in.Not, err = openCopy(root, s.Not, cache, union, seen); err != nil {
+			return err
+		}
+	}
+	if deps := s.dependentSchemas(); len(deps) > 0 {
+		schemas := make(map[string]*schema.Schema)
+		for name, l := range deps {
+			list := make(schema.SchemaList, len(l))
+			for i, dep := range l {
+				if list[i], err = openCopy(root, dep, cache, union, seen); err != nil {
+					return err
+				}
+			}
+			schemas[name] = list[0]
+			if len(list) > 1 {
+				schemas[name] = openSchema(func(w *schema.Schema) { w.AllOf = list })
+			}
+		}
+		in.Dependencies.Schemas = schemas
+	}
+	// jsschema only checks one of allOf, anyOf, oneOf and not, so when
+	// there is more than one the others are checked as part of allOf.
+	kinds := nonEmpty(in.AllOf) + nonEmpty(in.AnyOf) + nonEmpty(in.OneOf)
+	if in.Not != nil {
+		kinds++
+	}
+	if kinds > 1 {
+		if len(in.AnyOf) > 0 {
+			in.AllOf = append(in.AllOf, openSchema(func(w *schema.Schema) { w.AnyOf = in.AnyOf }))
+			in.AnyOf = nil
+		}
+		if len(in.OneOf) > 0 {
+			in.AllOf = append(in.AllOf, openSchema(func(w *schema.Schema) { w.OneOf = in.OneOf }))
+			in.OneOf = nil
+		}
+		if in.Not != nil {
+			in.AllOf = append(in.AllOf, openSchema(func(w *schema.Schema) { w.Not = in.Not }))
+			in.Not = nil
+		}
+	}
+	return nil
+}
+
+// openCopy returns the internal form of a copy of sub, adjusted as
+// described by composeInternal with the properties in union, which also
+// allows any additional properties and items unless sub says otherwise.
+// This suits schemas which constrain an object rather than describe it,
+// such as not, so that not: {required: [password]} rejects any object
+// holding a password.
+func openCopy(root, sub *Schema, cache map[*Schema]*schema.Schema, union map[string]*schema.Schema, seen map[*Schema]bool) (*schema.Schema, error) {
+	sub = derefLocal(root, sub)
+	c := *sub
+	in, err := toInternal(&c, cache)
+	if err != nil {
+		return nil, err
+	}
+	if !seen[sub] {
+		subUnion, err := propertyUnion(root, sub, cache)
+		if err != nil {
+			return nil, err
+		}
+		seen[sub] = true
+		err = composeInternal(root, sub, in, cache, subUnion, leftUnevaluated{}.add(sub), seen)
+		delete(seen, sub)
+		if err != nil {
+			return nil, err
+		}
+	}
+	// jsschema only checks that the properties it has schemas for are
+	// present, so the required properties must have them.
+	properties := make(map[string]*schema.Schema)
+	for name, ps := range in.Properties {
+		properties[name] = ps
+	}
+	for _, name := range sub.Required {
+		if _, ok := properties[name]; ok {
+			continue
+		}
+		if ps, ok := union[name]; ok {
+			properties[name] = ps
+		} else {
+			properties[name] = openSchema(func(*schema.Schema) {})
+		}
+	}
+	in.Properties = properties
+	if in.AdditionalProperties == nil {
+		in.AdditionalProperties = &schema.AdditionalProperties{}
+	}
+	if in.AdditionalItems == nil {
+		in.AdditionalItems = &schema.AdditionalItems{}
+	}
+	return in, nil
+}
+
+// nonEmpty returns 1 if l holds any schemas and 0 otherwise.
+func nonEmpty(l schema.SchemaList) int {
+	if len(l) > 0 {
+		return 1
+	}
+	return 0
+}
+
+// openSchema returns an internal schema which accepts any value, as set up
+// by init.
+func openSchema(init func(s *schema.Schema)) *schema.Schema {
+	s := schema.New()
+	s.AdditionalItems = &schema.AdditionalItems{}
+	s.AdditionalProperties = &schema.AdditionalProperties{}
+	init(s)
+	return s
+}
+
+// openUntyped adjusts in, the internal form of s, if s has no type. Without
+// a type, jsschema guesses which kinds of value s describes, taking it to
+// describe objects and arrays with nothing in them unless told otherwise,
+// and so rejecting every other kind of value; s is made to allow the kinds
+// of value it doesn't describe instead.
+func openUntyped(s *Schema, in *schema.Schema) {
+	if len(s.Type) > 0 {
+		return
+	}
+	if !describesArray(s) {
+		in.AdditionalItems = &schema.AdditionalItems{}
+	}
+	if !describesObject(s) {
+		in.AdditionalProperties = &schema.AdditionalProperties{}
+	}
+}
+
+// describesObject reports whether s has any of the keywords which
+// describe objects.
+func describesObject(s *Schema) bool {
+	return len(s.Properties) > 0 || len(s.PatternProperties) > 0 || s.AdditionalProperties != nil ||
+		len(s.Required) > 0 || s.MinProperties != nil || s.MaxProperties != nil
+}
+
+// describesArray reports whether s has any of the keywords which describe
+// arrays.
+func describesArray(s *Schema) bool {
+	return s.Items != nil || len(s.PrefixItems) > 0 || s.AdditionalItems != nil ||
+		s.MinItems != nil || s.MaxItems != nil || s.UniqueItems != nil
+}
+
+// matchesPattern reports whether name matches one of the pattern properties
+// of s.
+func matchesPattern(s *schema.Schema, name string) bool {
+	for re := range s.PatternProperties {
+		if re.MatchString(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// composedSchemas returns s, found within root, followed by the allOf
+// schemas it is composed of, and those they are composed of in turn, with
+// any references followed. If alternatives is set, the anyOf and oneOf
+// alternatives, the if, then and else schemas, and the schemas depending on
+// properties are included too.
+func composedSchemas(root, s *Schema, alternatives bool) []*Schema {
+	var composed []*Schema
+	seen := make(map[*Schema]bool)
+	var add func(s *Schema)
+	add = func(s *Schema) {
+		s = derefLocal(root, s)
+		if seen[s] {
+			return
+		}
+		seen[s] = true
+		composed = append(composed, s)
+		for _, sub := range s.AllOf {
+			add(sub)
+		}
+		if alternatives {
+			for _, sub := range s.AnyOf {
+				add(sub)
+			}
+			for _, sub := range s.OneOf {
+				add(sub)
+			}
+			for _, sub := range []*Schema{s.If, s.Then, s.Else} {
+				if sub != nil {
+					add(sub)
+				}
+			}
+			for _, name := range sortedDependents(s) {
+				for _, sub := range s.dependentSchemas()[name] {
+					add(sub)
+				}
+			}
+		}
+	}
+	add(s)
+	return composed
+}
+
+// withAlternative returns a schema which validates values against alt, one
+// of the alternatives of s, found within root, such as an anyOf alternative
+// or its then schema, in the context of the other schemas in its
+// composition, whose properties they may also hold. If s has
+// unevaluatedProperties or unevaluatedItems, which are checked separately,
+// any other properties or items are allowed too.
+func withAlternative(root, s, alt *Schema) *Schema {
+	ds := derefLocal(root, s)
+	out := &Schema{
+		AllOf:                 []*Schema{alt},
+		UnevaluatedProperties: ds.UnevaluatedProperties,
+		UnevaluatedItems:      ds.UnevaluatedItems,
+	}
+	for _, cs := range composedSchemas(root, s, true) {
+		for name, ps := range cs.Properties {
+			if out.Properties == nil {
+				out.Properties = make(map[string]*Schema)
+			}
+			if _, ok := out.Properties[name]; !ok {
+				out.Properties[name] = ps
+			}
+		}
+		for re, ps := range cs.PatternProperties {
+			if out.PatternProperties == nil {
+				out.PatternProperties = make(map[*regexp.Regexp]*Schema)
+			}
+			out.PatternProperties[re] = ps
+		}
+		if out.AdditionalProperties == nil {
+			out.AdditionalProperties = cs.AdditionalProperties
+		}
+	}
+	return out
+}
+
+// admitsValue reports whether s might be meant to describe x: whether it
+// accepts values of the type of x, and if x is an object, whether x has all
+// the properties s requires.
+func admitsValue(s *Schema, x interface{}) bool {
+	t := valueType(nil, x)
+	if len(s.Type) > 0 && !hasType(s, t) && !(t == IntegerType && hasType(s, NumberType)) {
+		return false
+	}
+	if obj, ok := asObject(x); ok {
+		for _, name := range s.Required {
+			if _, ok := obj[name]; !ok {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,284 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type ComposeSuite struct{}
+
+var _ = gc.Suite(ComposeSuite{})
+
+// allOfSchema composes a base schema shared by all providers with an
+// extension for a single provider.
+const allOfSchema = `
+type: object
+properties:
+  region:
+    type: string
+allOf:
+- $ref: "#/definitions/base"
+- type: object
+  required: [zone]
+  properties:
+    zone:
+      type: string
+    instance-type:
+      type: string
+      default: m5.large
+definitions:
+  base:
+    type: object
+    required: [name]
+    properties:
+      name:
+        type: string
+      port:
+        type: integer
+        maximum: 65535
+        default: 17070
+`
+
+func (ComposeSuite) TestAllOf(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(allOfSchema))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.Validate(map[string]interface{}{
+		"name":   "controller",
+		"zone":   "us-east-1a",
+		"region": "us-east-1",
+		"port":   17070,
+	}), jc.ErrorIsNil)
+	c.Check(s.Validate(map[string]interface{}{
+		"name": "controller",
+	}), gc.ErrorMatches, `zone: property is required`)
+	c.Check(s.Validate(map[string]interface{}{
+		"zone": "us-east-1a",
+		"port": 70000,
+	}), gc.ErrorMatches, `name: property is required; port: numeric value is greater than maximum`)
+	c.Check(s.Validate(map[string]interface{}{
+		"name":    "controller",
+		"zone":    "us-east-1a",
+		"flavour": "large",
+	}), gc.ErrorMatches, `additional properties are not allowed`)
+
+	// The base schema is still strict on its own.
+	c.Check(s.Definitions["base"].Validate(map[string]interface{}{
+		"name": "controller",
+		"zone": "us-east-1a",
+	}), gc.ErrorMatches, `additional properties are not allowed`)
+
+	v, err := NewValidator(s)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(v.Validate(map[string]interface{}{"name": "controller", "zone": "us-east-1a"}), jc.ErrorIsNil)
+}
+
+func (ComposeSuite) TestAllOfScalars(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(`
+allOf:
+- type: integer
+  minimum: 1
+- maximum: 5
+`))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.Validate(3), jc.ErrorIsNil)
+	c.Check(s.Validate(0), gc.ErrorMatches, `numeric value is less than the minimum`)
+	c.Check(s.Validate(7), gc.NotNil)
+}
+
+func (ComposeSuite) TestAllOfInsertDefaults(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(allOfSchema))
+	c.Assert(err, jc.ErrorIsNil)
+	doc := map[string]interface{}{"name": "controller"}
+	s.InsertDefaults(doc)
+	c.Check(doc, jc.DeepEquals, map[string]interface{}{
+		"name":          "controller",
+		"port":          float64(17070),
+		"instance-type": "m5.large",
+	})
+
+	// Objects composed only from other schemas are created to hold
+	// their defaults.
+	outer := &Schema{
+		Type: []Type{ObjectType},
+		Properties: map[string]*Schema{
+			"controller": {AllOf: []*Schema{s.Definitions["base"]}},
+		},
+	}
+	doc = map[string]interface{}{}
+	outer.InsertDefaults(doc)
+	c.Check(doc, jc.DeepEquals, map[string]interface{}{
+		"controller": map[string]interface{}{"port": float64(17070)},
+	})
+}
+
+const anyOfSchema = `
+type: object
+properties:
+  hosts:
+    anyOf:
+    - type: string
+    - type: array
+      items:
+        type: string
+  auth:
+    type: object
+    properties:
+      user:
+        type: string
+    anyOf:
+    - required: [password]
+      properties:
+        password:
+          type: string
+    - required: [key]
+      properties:
+        key:
+          type: string
+`
+
+func (ComposeSuite) TestAnyOf(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(anyOfSchema))
+	c.Assert(err, jc.ErrorIsNil)
+	for _, hosts := range []interface{}{"a", []interface{}{"a", "b"}} {
+		c.Check(s.Validate(map[string]interface{}{"hosts": hosts}), jc.ErrorIsNil)
+	}
+	c.Check(s.Validate(map[string]interface{}{"hosts": 3}),
+		gc.ErrorMatches, `hosts: does not match any of the alternatives`)
+	c.Check(s.Validate(map[string]interface{}{"hosts": []interface{}{"a", 3}}),
+		gc.ErrorMatches, `hosts\[1\]: .*`)
+
+	for _, auth := range []map[string]interface{}{
+		{"user": "admin", "password": "secret"},
+		{"key": "ssh-rsa AAAA"},
+		{"password": "secret", "key": "ssh-rsa AAAA"},
+	} {
+		c.Check(s.Validate(map[string]interface{}{"auth": auth}), jc.ErrorIsNil, gc.Commentf("%v", auth))
+	}
+	err = s.Validate(map[string]interface{}{"auth": map[string]interface{}{"user": "admin"}})
+	c.Check(err, gc.ErrorMatches, `auth: does not match any of the alternatives`)
+	c.Check(err.(*ValidationError).Keyword, gc.Equals, "anyOf")
+	c.Check(s.Validate(map[string]interface{}{"auth": map[string]interface{}{"key": 3}}),
+		gc.ErrorMatches, `auth.key: .*`)
+	c.Check(s.Validate(map[string]interface{}{"auth": map[string]interface{}{"key": "k", "token": "t"}}),
+		gc.NotNil)
+
+	v, err := NewValidator(s)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(v.Validate(map[string]interface{}{"hosts": []interface{}{"a"}}), jc.ErrorIsNil)
+}
+
+// oneOfSchema describes mutually exclusive authentication blocks, along
+// with settings shared by all of them.
+const oneOfSchema = `
+type: object
+properties:
+  auth:
+    type: object
+    properties:
+      user:
+        type: string
+    allOf:
+    - required: [user]
+    oneOf:
+    - title: password
+      required: [password]
+      properties:
+        password:
+          type: string
+    - title: key
+      required: [key]
+      properties:
+        key:
+          type: string
+`
+
+func (ComposeSuite) TestOneOf(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(oneOfSchema))
+	c.Assert(err, jc.ErrorIsNil)
+	v, err := NewValidator(s)
+	c.Assert(err, jc.ErrorIsNil)
+	for _, validate := range []func(interface{}) error{s.Validate, v.Validate} {
+		for _, auth := range []map[string]interface{}{
+			{"user": "admin", "password": "secret"},
+			{"user": "admin", "key": "ssh-rsa AAAA"},
+		} {
+			c.Check(validate(map[string]interface{}{"auth": auth}), jc.ErrorIsNil, gc.Commentf("%v", auth))
+		}
+
+		err := validate(map[string]interface{}{"auth": map[string]interface{}{"user": "admin"}})
+		c.Check(err, gc.ErrorMatches, `auth: does not match any of the alternatives`)
+		c.Check(err.(*ValidationError).Keyword, gc.Equals, "oneOf")
+
+		err = validate(map[string]interface{}{"auth": map[string]interface{}{
+			"user":     "admin",
+			"password": "secret",
+			"key":      "ssh-rsa AAAA",
+		}})
+		c.Check(err, gc.ErrorMatches, `auth: matches more than one of the alternatives \("password" and "key"\)`)
+		c.Check(err.(*ValidationError).Keyword, gc.Equals, "oneOf")
+
+		// The allOf schemas still apply alongside the alternatives.
+		err = validate(map[string]interface{}{"auth": map[string]interface{}{"key": "ssh-rsa AAAA"}})
+		c.Check(err, gc.ErrorMatches, `auth.user: property is required`)
+
+		c.Check(validate(map[string]interface{}{"auth": map[string]interface{}{"user": "admin", "key": 3}}),
+			gc.ErrorMatches, `auth.key: .*`)
+	}
+}
+
+// notSchema describes credentials which may be given in any form except
+// a plain password.
+const notSchema = `
+type: object
+properties:
+  credentials:
+    type: object
+    properties:
+      user:
+        type: string
+      password:
+        type: string
+      key:
+        type: string
+    not:
+      title: password
+      required: [password]
+  port:
+    type: integer
+    allOf:
+    - minimum: 1
+    not:
+      type: integer
+      enum: [22]
+`
+
+func (ComposeSuite) TestNot(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(notSchema))
+	c.Assert(err, jc.ErrorIsNil)
+	v, err := NewValidator(s)
+	c.Assert(err, jc.ErrorIsNil)
+	for _, validate := range []func(interface{}) error{s.Validate, v.Validate} {
+		for _, doc := range []map[string]interface{}{
+			{"credentials": map[string]interface{}{"user": "admin", "key": "ssh-rsa AAAA"}},
+			{"credentials": map[string]interface{}{}},
+			{"port": 8080},
+		} {
+			c.Check(validate(doc), jc.ErrorIsNil, gc.Commentf("%v", doc))
+		}
+
+		err := validate(map[string]interface{}{
+			"credentials": map[string]interface{}{"user": "admin", "password": "secret"},
+		})
+		c.Check(err, gc.ErrorMatches, `credentials: must not match "password"`)
+		c.Check(err.(*ValidationError).Keyword, gc.Equals, "not")
+
+		// The not schema applies alongside allOf.
+		c.Check(validate(map[string]interface{}{"port": 22}), gc.ErrorMatches, `port: must not match the schema`)
+		c.Check(validate(map[string]interface{}{"port": 0}), gc.ErrorMatches, `port: .*`)
+	}
+}
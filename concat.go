@@ -0,0 +1,173 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// Concat combines object schemas with disjoint properties, such as controller,
+// model and provider config, into a single schema that validates them all at
+// once. The properties, required properties, order, groups, definitions,
+// pattern properties, dependencies and profiles of each schema are combined,
+// and any other constraints they hold, such as allOf or if, are kept as
+// entries of allOf. Each property records the id or title of the schema it
+// came from in its Provenance, unless it already has one. An error naming
+// both sources is returned if two schemas define the same property,
+// definition, pattern, dependency or profile, or different
+// additionalProperties.
+//
+// Schemas are identified by their $id or id, or failing that their title,
+// or otherwise by their position in the arguments.
+func Concat(schemas ...*Schema) (*Schema, error) {
+	out := &Schema{
+		Type:       []Type{ObjectType},
+		Properties: make(map[string]*Schema),
+	}
+	c := concatenation{out: out, sources: make(map[string]map[string]string)}
+	for i, s := range schemas {
+		source := schemaSource(s, i)
+		if len(s.Type) > 0 && !hasType(s, ObjectType) {
+			return nil, fmt.Errorf("cannot concatenate %s: not an object schema", source)
+		}
+		for _, name := range sortedKeys(s.Properties) {
+			if err := c.claim("property", name, source); err != nil {
+				return nil, err
+			}
+			ps := *s.Properties[name]
+			if ps.Provenance == "" {
+				ps.Provenance = schemaName(s, i)
+			}
+			out.Properties[name] = &ps
+		}
+		if err := c.schemas("definition", &out.Definitions, s.Definitions, source); err != nil {
+			return nil, err
+		}
+		if err := c.schemas("$defs definition", &out.Defs, s.Defs, source); err != nil {
+			return nil, err
+		}
+		if err := c.schemas("dependency", &out.Dependencies.Schemas, s.Dependencies.Schemas, source); err != nil {
+			return nil, err
+		}
+		if err := c.schemas("dependent schema", &out.DependentSchemas, s.DependentSchemas, source); err != nil {
+			return nil, err
+		}
+		if err := c.schemas("profile", &out.Profiles, s.Profiles, source); err != nil {
+			return nil, err
+		}
+		if err := c.names("dependency", &out.Dependencies.Names, s.Dependencies.Names, source); err != nil {
+			return nil, err
+		}
+		if err := c.names("dependent required property", &out.DependentRequired, s.DependentRequired, source); err != nil {
+			return nil, err
+		}
+		for _, re := range sortedPatterns(s.PatternProperties) {
+			if err := c.claim("pattern", re.String(), source); err != nil {
+				return nil, err
+			}
+			if out.PatternProperties == nil {
+				out.PatternProperties = make(map[*regexp.Regexp]*Schema)
+			}
+			out.PatternProperties[re] = s.PatternProperties[re]
+		}
+		if s.AdditionalProperties != nil {
+			if out.AdditionalProperties != nil && hashJSON(out.AdditionalProperties) != hashJSON(s.AdditionalProperties) {
+				return nil, fmt.Errorf("cannot concatenate %s: additionalProperties differ from those of %s", source, c.additionalSource)
+			}
+			out.AdditionalProperties, c.additionalSource = s.AdditionalProperties, source
+		}
+		out.AllOf = append(out.AllOf, s.AllOf...)
+		if s.AnyOf != nil || s.OneOf != nil || s.Not != nil || s.If != nil {
+			out.AllOf = append(out.AllOf, &Schema{
+				AnyOf: s.AnyOf,
+				OneOf: s.OneOf,
+				Not:   s.Not,
+				If:    s.If,
+				Then:  s.Then,
+				Else:  s.Else,
+			})
+		}
+		out.Required = appendMissing(out.Required, s.Required...)
+		out.Order = appendMissing(out.Order, s.Order...)
+		out.GroupSpecs = append(out.GroupSpecs, s.GroupSpecs...)
+	}
+	return out, nil
+}
+
+// concatenation holds the state of a call to Concat.
+type concatenation struct {
+	out *Schema
+
+	// sources holds the source of each named part of out, such as a
+	// property, keyed by the kind of part and then by its name.
+	sources map[string]map[string]string
+
+	// additionalSource holds the source of out.AdditionalProperties.
+	additionalSource string
+}
+
+// claim records that the named part of the given kind comes from source,
+// returning an error if another source already defined it.
+func (c concatenation) claim(kind, name, source string) error {
+	if other, ok := c.sources[kind][name]; ok {
+		return fmt.Errorf("cannot concatenate %s: %s %q already defined by %s", source, kind, name, other)
+	}
+	if c.sources[kind] == nil {
+		c.sources[kind] = make(map[string]string)
+	}
+	c.sources[kind][name] = source
+	return nil
+}
+
+// schemas adds the schemas in src, of the given kind, to *dst.
+func (c concatenation) schemas(kind string, dst *map[string]*Schema, src map[string]*Schema, source string) error {
+	for _, name := range sortedKeys(src) {
+		if err := c.claim(kind, name, source); err != nil {
+			return err
+		}
+		if *dst == nil {
+			*dst = make(map[string]*Schema)
+		}
+		(*dst)[name] = src[name]
+	}
+	return nil
+}
+
+// names adds the lists of property names in src, of the given kind, to *dst.
+func (c concatenation) names(kind string, dst *map[string][]string, src map[string][]string, source string) error {
+	for _, name := range sortedNameKeys(src) {
+		if err := c.claim(kind, name, source); err != nil {
+			return err
+		}
+		if *dst == nil {
+			*dst = make(map[string][]string)
+		}
+		(*dst)[name] = src[name]
+	}
+	return nil
+}
+
+// schemaSource returns a name for the i'th schema s, for use in errors.
+func schemaSource(s *Schema, i int) string {
+	switch {
+	case s.id() != "":
+		return fmt.Sprintf("schema %q", s.id())
+	case s.Title != "":
+		return fmt.Sprintf("%q schema", s.Title)
+	}
+	return fmt.Sprintf("schema %d", i+1)
+}
+
+// schemaName returns the $id or id of the i'th schema s, or its title if it
+// has no id, or otherwise its position.
+func schemaName(s *Schema, i int) string {
+	if id := s.id(); id != "" {
+		return id
+	}
+	if s.Title != "" {
+		return s.Title
+	}
+	return fmt.Sprintf("schema %d", i+1)
+}
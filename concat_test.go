@@ -0,0 +1,115 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type ConcatSuite struct{}
+
+var _ = gc.Suite(ConcatSuite{})
+
+var (
+	controllerConfigSchema = &Schema{
+		Title: "controller config",
+		Type:  []Type{ObjectType},
+		Properties: map[string]*Schema{
+			"api-port": {Type: []Type{IntegerType}},
+		},
+		Required: []string{"api-port"},
+	}
+	modelConfigSchema = &Schema{
+		Title: "model config",
+		Type:  []Type{ObjectType},
+		Properties: map[string]*Schema{
+			"default-base": {Type: []Type{StringType}},
+		},
+		Order: []string{"default-base"},
+	}
+	openstackConfigSchema = &Schema{
+		ID:   "https://juju.is/schemas/openstack",
+		Type: []Type{ObjectType},
+		Properties: map[string]*Schema{
+			"region":   {Type: []Type{StringType}},
+			"api-port": {Type: []Type{StringType}},
+		},
+	}
+)
+
+func (ConcatSuite) TestConcat(c *gc.C) {
+	s, err := Concat(controllerConfigSchema, modelConfigSchema)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.Properties, gc.HasLen, 2)
+	c.Check(s.Required, jc.DeepEquals, []string{"api-port"})
+	c.Check(s.Order, jc.DeepEquals, []string{"default-base"})
+
+	c.Check(s.Validate(map[string]interface{}{
+		"api-port":     17070,
+		"default-base": "ubuntu@22.04",
+	}), jc.ErrorIsNil)
+	c.Check(s.Validate(map[string]interface{}{
+		"default-base": "ubuntu@22.04",
+	}), gc.NotNil)
+}
+
+func (ConcatSuite) TestConcatCollision(c *gc.C) {
+	_, err := Concat(controllerConfigSchema, modelConfigSchema, openstackConfigSchema)
+	c.Check(err, gc.ErrorMatches, `cannot concatenate schema "https://juju.is/schemas/openstack": property "api-port" already defined by "controller config" schema`)
+
+	_, err = Concat(&Schema{Type: []Type{StringType}})
+	c.Check(err, gc.ErrorMatches, `cannot concatenate schema 1: not an object schema`)
+}
+
+func (ConcatSuite) TestConcatSchemaID(c *gc.C) {
+	lxd := &Schema{
+		SchemaID: "https://juju.is/schemas/lxd",
+		Type:     []Type{ObjectType},
+		Properties: map[string]*Schema{
+			"api-port": {Type: []Type{StringType}},
+		},
+	}
+	s, err := Concat(modelConfigSchema, lxd)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.Properties["api-port"].Provenance, gc.Equals, "https://juju.is/schemas/lxd")
+
+	_, err = Concat(controllerConfigSchema, lxd)
+	c.Check(err, gc.ErrorMatches, `cannot concatenate schema "https://juju.is/schemas/lxd": property "api-port" already defined by "controller config" schema`)
+}
+
+func (ConcatSuite) TestConcatConstraints(c *gc.C) {
+	tls := &Schema{
+		Type: []Type{ObjectType},
+		Properties: map[string]*Schema{
+			"cert": {Type: []Type{StringType}},
+			"key":  {Type: []Type{StringType}},
+		},
+		DependentRequired: map[string][]string{"cert": {"key"}},
+		AnyOf: []*Schema{
+			{Required: []string{"cert"}},
+			{Required: []string{"key"}},
+		},
+	}
+	s, err := Concat(controllerConfigSchema, tls)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.Properties["cert"].Provenance, gc.Equals, "schema 2")
+
+	c.Check(s.Validate(map[string]interface{}{"api-port": 17070, "cert": "c", "key": "k"}), jc.ErrorIsNil)
+	// Each of the constraints of tls still applies.
+	c.Check(s.Validate(map[string]interface{}{"api-port": 17070}), gc.NotNil)
+	c.Check(s.Validate(map[string]interface{}{"api-port": 17070, "cert": "c"}), gc.NotNil)
+}
+
+func (ConcatSuite) TestConcatConflicts(c *gc.C) {
+	a := &Schema{
+		Title:                "a",
+		AdditionalProperties: &Schema{Type: []Type{StringType}},
+		Profiles:             map[string]*Schema{"dev": {}},
+	}
+	_, err := Concat(a, &Schema{Title: "b", AdditionalProperties: &Schema{Type: []Type{IntegerType}}})
+	c.Check(err, gc.ErrorMatches, `cannot concatenate "b" schema: additionalProperties differ from those of "a" schema`)
+	_, err = Concat(a, &Schema{Title: "b", Profiles: map[string]*Schema{"dev": {}}})
+	c.Check(err, gc.ErrorMatches, `cannot concatenate "b" schema: profile "dev" already defined by "a" schema`)
+}
@@ -0,0 +1,63 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+// hasConditional reports whether s has an if schema, with then or else
+// schemas for it to choose between.
+func (s *Schema) hasConditional() bool {
+	return s.If != nil && (s.Then != nil || s.Else != nil)
+}
+
+// validateConditional checks x, found at path, against the then or else
+// schema of s, according to whether x matches its if schema. Neither is
+// known to jsschema, so x is checked against them in full.
+func (v *validation) validateConditional(s *Schema, x interface{}, path string) {
+	branch, keyword := chooseBranch(v.root, s, x)
+	if branch == nil {
+		return
+	}
+	if err := validateInternal(v.root, withAlternative(v.root, s, branch), x); err != nil {
+		for _, e := range v.explanation.explain(branch, x, path, err) {
+			if e.Keyword == "" {
+				e.Keyword = keyword
+			}
+			v.errs = append(v.errs, e)
+		}
+		return
+	}
+	v.validate(branch, x, path)
+}
+
+// chooseBranch returns the then or else schema of s, found within root,
+// according to whether x matches its if schema, along with its keyword. The
+// schema returned is nil if s doesn't give the one chosen.
+//
+// The if schema constrains x rather than describing it, so it is checked
+// as the schema given by not is, allowing the properties it doesn't
+// declare. This also means that the choice isn't affected by problems
+// found elsewhere in x.
+func chooseBranch(root, s *Schema, x interface{}) (*Schema, string) {
+	if validateInternal(root, &Schema{Not: s.If}, x) == nil {
+		return s.Else, "else"
+	}
+	return s.Then, "then"
+}
+
+// explainConditionals returns the errors found in the properties and items
+// of x, found at path, by the then or else schemas chosen by the
+// conditionals in the composed schemas.
+func (e *explanation) explainConditionals(composed []*Schema, x interface{}, path string) ValidationErrors {
+	root := e.root
+	var errs ValidationErrors
+	for _, cs := range composed {
+		if !cs.hasConditional() {
+			continue
+		}
+		if branch, _ := chooseBranch(root, cs, x); branch != nil {
+			branch = derefLocal(root, branch)
+			errs = append(errs, e.explainSchemas(composedSchemas(root, branch, false), x, path)...)
+		}
+	}
+	return errs
+}
@@ -0,0 +1,96 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type ConditionalSuite struct{}
+
+var _ = gc.Suite(ConditionalSuite{})
+
+// conditionalSchema requires a client secret for oauth credentials, and a
+// password otherwise.
+const conditionalSchema = `
+type: object
+properties:
+  type:
+    type: string
+    enum: [oauth, userpass]
+  client-id:
+    type: string
+if:
+  properties:
+    type:
+      enum: [oauth]
+  required: [type]
+then:
+  required: [client-id, client-secret]
+  properties:
+    client-secret:
+      type: string
+    expires:
+      type: string
+      format: date
+else:
+  required: [password]
+  properties:
+    password:
+      type: string
+`
+
+func (ConditionalSuite) TestIfThenElse(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(conditionalSchema))
+	c.Assert(err, jc.ErrorIsNil)
+	v, err := NewValidator(s)
+	c.Assert(err, jc.ErrorIsNil)
+	for _, validate := range []func(interface{}) error{s.Validate, v.Validate} {
+		for _, doc := range []map[string]interface{}{
+			{"type": "oauth", "client-id": "juju", "client-secret": "s3cret", "expires": "2026-12-31"},
+			{"type": "userpass", "password": "secret"},
+			{"password": "secret"},
+		} {
+			c.Check(validate(doc), jc.ErrorIsNil, gc.Commentf("%v", doc))
+		}
+
+		err := validate(map[string]interface{}{"type": "oauth", "client-id": "juju"})
+		c.Check(err, gc.ErrorMatches, `client-secret: property is required`)
+		c.Check(errors.Is(err, ErrRequired), jc.IsTrue)
+
+		err = validate(map[string]interface{}{"type": "userpass"})
+		c.Check(err, gc.ErrorMatches, `password: property is required`)
+
+		// The keywords implemented by this package apply within the
+		// chosen schema too.
+		err = validate(map[string]interface{}{"type": "oauth", "client-id": "juju", "client-secret": "s3cret", "expires": "soon"})
+		c.Check(err, gc.ErrorMatches, `expires: .*`)
+
+		// As are those implemented by jsschema.
+		err = validate(map[string]interface{}{"type": "oauth", "client-id": "juju", "client-secret": 3})
+		c.Check(err, gc.ErrorMatches, `client-secret: .*`)
+
+		c.Check(validate(map[string]interface{}{"type": "oauth", "client-id": 3}), gc.NotNil)
+		c.Check(validate(map[string]interface{}{"type": "userpass", "password": "p", "token": "t"}), gc.NotNil)
+	}
+}
+
+func (ConditionalSuite) TestIfThenElseRoundTrip(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(conditionalSchema))
+	c.Assert(err, jc.ErrorIsNil)
+	data, err := json.Marshal(s)
+	c.Assert(err, jc.ErrorIsNil)
+	var out Schema
+	c.Assert(json.Unmarshal(data, &out), jc.ErrorIsNil)
+	c.Assert(out.If, gc.NotNil)
+	c.Check(out.If.Required, jc.DeepEquals, []string{"type"})
+	c.Check(out.Then.Required, jc.DeepEquals, []string{"client-id", "client-secret"})
+	c.Check(out.Else.Properties["password"].Type, jc.DeepEquals, []Type{StringType})
+	c.Check(out.Unknown, gc.HasLen, 0)
+}
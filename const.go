@@ -0,0 +1,63 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/lestrrat/go-jsschema"
+)
+
+// constErrors returns an error for each of the composed schemas whose const
+// x, found at path, isn't equal to.
+func constErrors(composed []*Schema, x interface{}, path string) ValidationErrors {
+	var errs ValidationErrors
+	for _, cs := range composed {
+		if cs.Const == nil || valuesEqual(x, cs.Const) {
+			continue
+		}
+		want, err := json.Marshal(cs.Const)
+		if err != nil {
+			want = []byte(fmt.Sprint(cs.Const))
+		}
+		errs = append(errs, &ValidationError{
+			Path:    path,
+			Keyword: "const",
+			Err:     fmt.Errorf("must be %s", want),
+		})
+	}
+	return errs
+}
+
+// constInternal adjusts in, the internal form of s, if s has a const which
+// jsschema can check, so that it is taken into account where a schema is
+// only checked by jsschema, as when deciding whether an if schema matches.
+// A string is given as the only value of enum, and a number as the minimum
+// and maximum, unless in already has them. Other constants are only checked
+// by this package, and an object or array constant is allowed any
+// properties or items that s doesn't give schemas for. The internal form is
+// only adjusted for validation, so that s is still marshaled with its own
+// keywords.
+func constInternal(s *Schema, in *schema.Schema) {
+	switch c := normalizeValue(s.Const).(type) {
+	case string:
+		if len(in.Enum) == 0 {
+			in.Enum = []interface{}{c}
+		}
+	case float64:
+		if !in.Minimum.Initialized && !in.Maximum.Initialized {
+			in.Minimum = schema.Number{Val: c, Initialized: true}
+			in.Maximum = schema.Number{Val: c, Initialized: true}
+		}
+	case map[string]interface{}:
+		if s.AdditionalProperties == nil {
+			in.AdditionalProperties = &schema.AdditionalProperties{}
+		}
+	case []interface{}:
+		if s.AdditionalItems == nil {
+			in.AdditionalItems = &schema.AdditionalItems{}
+		}
+	}
+}
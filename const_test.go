@@ -0,0 +1,138 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"encoding/json"
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type ConstSuite struct{}
+
+var _ = gc.Suite(ConstSuite{})
+
+var constSchema = `
+type: object
+properties:
+  api-version:
+    type: string
+    const: v1
+  replicas:
+    type: integer
+    const: 3
+  enabled:
+    type: boolean
+    const: true
+  owner:
+    type: object
+    const: {name: admin, roles: [read, write]}
+  ports:
+    type: array
+    const: [80, 443]
+  anything:
+    const: {a: [1, {b: null}]}
+`
+
+func (ConstSuite) TestConst(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(constSchema))
+	c.Assert(err, jc.ErrorIsNil)
+	for i, test := range []struct {
+		doc map[string]interface{}
+		err string
+	}{{
+		doc: map[string]interface{}{
+			"api-version": "v1",
+			"replicas":    3,
+			"enabled":     true,
+			"owner":       map[string]interface{}{"name": "admin", "roles": []interface{}{"read", "write"}},
+			"ports":       []interface{}{80.0, 443},
+			"anything":    map[string]interface{}{"a": []interface{}{1, map[string]interface{}{"b": nil}}},
+		},
+	}, {
+		doc: map[string]interface{}{"api-version": "v2"},
+		err: `api-version: must be "v1"`,
+	}, {
+		doc: map[string]interface{}{"replicas": 3.0},
+	}, {
+		doc: map[string]interface{}{"replicas": 4},
+		err: `replicas: must be 3`,
+	}, {
+		doc: map[string]interface{}{"enabled": false},
+		err: `enabled: must be true`,
+	}, {
+		doc: map[string]interface{}{"owner": map[string]interface{}{"name": "admin", "roles": []interface{}{"write", "read"}}},
+		err: `owner: must be {"name":"admin","roles":\["read","write"\]}`,
+	}, {
+		doc: map[string]interface{}{"owner": map[string]interface{}{"name": "admin"}},
+		err: `owner: must be .*`,
+	}, {
+		doc: map[string]interface{}{"ports": []interface{}{80}},
+		err: `ports: must be \[80,443\]`,
+	}, {
+		doc: map[string]interface{}{"anything": "a"},
+		err: `anything: must be {"a":\[1,{"b":null}\]}`,
+	}} {
+		c.Logf("test %d: %v", i, test.doc)
+		err := s.Validate(test.doc)
+		if test.err == "" {
+			c.Check(err, jc.ErrorIsNil)
+			continue
+		}
+		c.Check(err, gc.ErrorMatches, test.err)
+		c.Check(err.(*ValidationError).Keyword, gc.Equals, "const")
+	}
+}
+
+func (ConstSuite) TestConstConditional(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(`
+type: object
+properties:
+  kind: {type: string}
+  size: {type: integer}
+  port: {type: integer}
+if:
+  properties:
+    kind: {const: disk}
+then:
+  required: [size]
+else:
+  required: [port]
+`))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.Validate(map[string]interface{}{"kind": "disk", "size": 1}), jc.ErrorIsNil)
+	c.Check(s.Validate(map[string]interface{}{"kind": "disk"}), gc.ErrorMatches, `size: .*`)
+	c.Check(s.Validate(map[string]interface{}{"kind": "nic", "port": 1}), jc.ErrorIsNil)
+	c.Check(s.Validate(map[string]interface{}{"kind": "nic", "size": 1}), gc.ErrorMatches, `port: .*`)
+}
+
+func (ConstSuite) TestConstAlternatives(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(`
+oneOf:
+- {type: integer, const: 1}
+- {type: integer, const: 2}
+`))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.Validate(1), jc.ErrorIsNil)
+	c.Check(s.Validate(2), jc.ErrorIsNil)
+	c.Check(s.Validate(3), gc.NotNil)
+}
+
+func (ConstSuite) TestConstRoundTrip(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(constSchema))
+	c.Assert(err, jc.ErrorIsNil)
+	data, err := json.Marshal(s)
+	c.Assert(err, jc.ErrorIsNil)
+	var out Schema
+	c.Assert(json.Unmarshal(data, &out), jc.ErrorIsNil)
+	c.Check(out.Properties["api-version"].Const, gc.Equals, "v1")
+	c.Check(out.Properties["api-version"].Enum, gc.HasLen, 0)
+	c.Check(out.Properties["owner"].Const, jc.DeepEquals, map[string]interface{}{
+		"name":  "admin",
+		"roles": []interface{}{"read", "write"},
+	})
+	c.Check(out.Unknown, gc.IsNil)
+}
@@ -0,0 +1,184 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// constraintsSchema describes the document produced by parsing a constraints
+// string. Sizes are given in MiB.
+const constraintsSchema = `
+type: object
+properties:
+  arch:
+    type: string
+    enum: [amd64, arm64, ppc64el, riscv64, s390x]
+  container:
+    type: string
+    enum: [lxd, kvm]
+  cores:
+    type: integer
+    minimum: 0
+  cpu-power:
+    type: integer
+    minimum: 0
+  mem:
+    type: integer
+    minimum: 0
+  root-disk:
+    type: integer
+    minimum: 0
+  root-disk-source:
+    type: string
+  tags:
+    type: array
+    items:
+      type: string
+  instance-role:
+    type: string
+  instance-type:
+    type: string
+  spaces:
+    type: array
+    items:
+      type: string
+      pattern: ^\^?[a-z0-9]+(-[a-z0-9]+)*$
+  virt-type:
+    type: string
+  zones:
+    type: array
+    items:
+      type: string
+  allocate-public-ip:
+    type: boolean
+  image-id:
+    type: string
+`
+
+// ConstraintsSchema is the schema that constraints strings are checked
+// against once parsed by the constraints format.
+var ConstraintsSchema = func() *Schema {
+	s, err := FromYAML(strings.NewReader(constraintsSchema))
+	if err != nil {
+		panic(err)
+	}
+	return s
+}()
+
+func init() {
+	RegisterFormat(FormatConstraints, func(value string) error {
+		_, err := ParseConstraints(value)
+		return err
+	})
+}
+
+// ParseConstraints parses a juju constraints string, such as
+// "mem=8G cores=4 tags=a,b", into a document with a property for each
+// constraint, and checks it against ConstraintsSchema. Sizes are converted
+// into MiB, rounding fractions up, lists are split at commas, and
+// constraints with empty values are omitted.
+func ParseConstraints(value string) (map[string]interface{}, error) {
+	doc := make(map[string]interface{})
+	seen := make(map[string]bool)
+	for _, field := range strings.Fields(value) {
+		eq := strings.Index(field, "=")
+		if eq <= 0 {
+			return nil, fmt.Errorf("malformed constraint %q", field)
+		}
+		name, raw := field[:eq], field[eq+1:]
+		ps, ok := ConstraintsSchema.Properties[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown constraint %q", name)
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("constraint %q specified more than once", name)
+		}
+		seen[name] = true
+		if raw == "" {
+			continue
+		}
+		v, err := parseConstraintValue(name, ps, raw)
+		if err != nil {
+			return nil, err
+		}
+		doc[name] = v
+	}
+	if err := ConstraintsSchema.Validate(doc); err != nil {
+		return nil, fmt.Errorf("invalid constraints %q: %v", value, err)
+	}
+	return doc, nil
+}
+
+func parseConstraintValue(name string, s *Schema, raw string) (interface{}, error) {
+	switch {
+	case hasType(s, ArrayType):
+		var l []interface{}
+		for _, item := range strings.Split(raw, ",") {
+			if item != "" {
+				l = append(l, item)
+			}
+		}
+		return l, nil
+	case hasType(s, BooleanType):
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, fmt.Errorf("constraint %q: expected boolean, got %q", name, raw)
+		}
+		return b, nil
+	case hasType(s, IntegerType):
+		if name == "mem" || name == "root-disk" {
+			return parseSize(name, raw)
+		}
+		n, err := strconv.ParseUint(raw, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("constraint %q: expected non-negative integer, got %q", name, raw)
+		}
+		return int(n), nil
+	}
+	return raw, nil
+}
+
+// parseSize parses a size such as "512M" or "1.5G" into MiB. Sizes without a
+// suffix are already in MiB. Fractions of a MiB are rounded up, as juju
+// does, so that the size is never less than the one asked for.
+func parseSize(name, raw string) (interface{}, error) {
+	mib, ok := parseMiB(raw)
+	if !ok {
+		return nil, fmt.Errorf("constraint %q: expected a size such as 512M or 8G, got %q", name, raw)
+	}
+	return int(math.Ceil(mib)), nil
+}
+
+// sizeSuffixes holds the multipliers from MiB for the suffixes recognised
+// by parseMiB, largest first.
+var sizeSuffixes = []struct {
+	suffix     string
+	multiplier float64
+}{
+	{"P", 1024 * 1024 * 1024},
+	{"T", 1024 * 1024},
+	{"G", 1024},
+	{"M", 1},
+}
+
+// parseMiB parses a size with an optional M, G, T or P suffix into MiB.
+func parseMiB(raw string) (float64, bool) {
+	multiplier := 1.0
+	num := raw
+	for _, s := range sizeSuffixes {
+		if strings.HasSuffix(strings.ToUpper(raw), s.suffix) {
+			multiplier, num = s.multiplier, raw[:len(raw)-1]
+			break
+		}
+	}
+	f, err := strconv.ParseFloat(num, 64)
+	if err != nil || f < 0 {
+		return 0, false
+	}
+	return f * multiplier, true
+}
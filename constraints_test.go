@@ -0,0 +1,91 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"errors"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type ConstraintsSuite struct{}
+
+var _ = gc.Suite(ConstraintsSuite{})
+
+func (ConstraintsSuite) TestParseConstraints(c *gc.C) {
+	doc, err := ParseConstraints("mem=8G cores=4 root-disk=512 arch=arm64 tags=a,b spaces=^db,public allocate-public-ip=true zones=")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(doc, jc.DeepEquals, map[string]interface{}{
+		"mem":                8192,
+		"cores":              4,
+		"root-disk":          512,
+		"arch":               "arm64",
+		"tags":               []interface{}{"a", "b"},
+		"spaces":             []interface{}{"^db", "public"},
+		"allocate-public-ip": true,
+	})
+
+	// Fractional sizes are rounded up to a whole MiB.
+	doc, err = ParseConstraints("mem=1.5G root-disk=0.5M")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(doc, jc.DeepEquals, map[string]interface{}{"mem": 1536, "root-disk": 1})
+
+	doc, err = ParseConstraints("")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(doc, gc.HasLen, 0)
+}
+
+func (ConstraintsSuite) TestParseConstraintsErrors(c *gc.C) {
+	for _, test := range []struct {
+		value string
+		err   string
+	}{{
+		value: "mem",
+		err:   `malformed constraint "mem"`,
+	}, {
+		value: "memory=8G",
+		err:   `unknown constraint "memory"`,
+	}, {
+		value: "mem=8G mem=4G",
+		err:   `constraint "mem" specified more than once`,
+	}, {
+		value: "mem= mem=4G",
+		err:   `constraint "mem" specified more than once`,
+	}, {
+		value: "mem=lots",
+		err:   `constraint "mem": expected a size such as 512M or 8G, got "lots"`,
+	}, {
+		value: "cores=-1",
+		err:   `constraint "cores": expected non-negative integer, got "-1"`,
+	}, {
+		value: "allocate-public-ip=maybe",
+		err:   `constraint "allocate-public-ip": expected boolean, got "maybe"`,
+	}, {
+		value: "arch=z80",
+		err:   `invalid constraints "arch=z80": .*`,
+	}} {
+		c.Logf("test %q", test.value)
+		_, err := ParseConstraints(test.value)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (ConstraintsSuite) TestConstraintsFormat(c *gc.C) {
+	s := &Schema{
+		Type: []Type{ObjectType},
+		Properties: map[string]*Schema{
+			"constraints": {Type: []Type{StringType}, Format: FormatConstraints},
+		},
+	}
+	c.Check(s.Validate(map[string]interface{}{"constraints": "mem=4G"}), jc.ErrorIsNil)
+	err := s.Validate(map[string]interface{}{"constraints": "mem=4G disks=2"})
+	c.Check(err, gc.ErrorMatches, `constraints: unknown constraint "disks"`)
+}
+
+func (ConstraintsSuite) TestRegisterFormat(c *gc.C) {
+	RegisterFormat("test-never", func(string) error { return errors.New("never valid") })
+	s := &Schema{Type: []Type{StringType}, Format: "test-never"}
+	c.Check(s.Validate("x"), gc.ErrorMatches, "never valid")
+}
@@ -0,0 +1,130 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ConstraintSet holds the constraints on a leaf property that are simple
+// enough for a client, such as a web dashboard, to check locally before a
+// document is sent to the controller. Its json form is stable, and is
+// described for TypeScript clients by WriteConstraintsTypeScript.
+type ConstraintSet struct {
+	// Type holds the names of the types the value may have, such as
+	// "string" or "integer".
+	Type []string `json:"type,omitempty"`
+
+	// Required reports whether the object holding the property requires
+	// it.
+	Required bool `json:"required,omitempty"`
+
+	// Minimum and Maximum hold the bounds of numeric values, which the
+	// value may equal unless ExclusiveMinimum or ExclusiveMaximum is set.
+	Minimum          *float64 `json:"minimum,omitempty"`
+	ExclusiveMinimum bool     `json:"exclusiveMinimum,omitempty"`
+	Maximum          *float64 `json:"maximum,omitempty"`
+	ExclusiveMaximum bool     `json:"exclusiveMaximum,omitempty"`
+
+	// MinLength and MaxLength hold the bounds of the length of string
+	// values.
+	MinLength *int `json:"minLength,omitempty"`
+	MaxLength *int `json:"maxLength,omitempty"`
+
+	// Patterns holds regular expressions that string values must all
+	// match somewhere within them, in the syntax of Go's regexp package,
+	// which for the patterns commonly used is also valid in JavaScript.
+	Patterns []string `json:"patterns,omitempty"`
+
+	// Enum holds the values that the value must be one of, if it is
+	// restricted to a fixed set.
+	Enum []interface{} `json:"enum,omitempty"`
+}
+
+// ExportConstraints returns the constraints on each leaf property of s,
+// keyed by its path as given by Keys. Constraints given by allOf schemas
+// are combined with those of the property itself, but those which can only
+// be checked against the whole document, or by custom validators, are left
+// for the controller to check.
+func ExportConstraints(s *Schema) map[string]ConstraintSet {
+	constraints := make(map[string]ConstraintSet)
+	for _, leaf := range s.leaves() {
+		cs := ConstraintSet{Required: leaf.required}
+		for _, ps := range composedSchemas(s, leaf.schema, false) {
+			cs.add(ps)
+		}
+		constraints[dottedKey(leaf.path)] = cs
+	}
+	return constraints
+}
+
+// add adds the constraints given by s to cs, keeping the tighter of any
+// bounds given by both.
+func (cs *ConstraintSet) add(s *Schema) {
+	if cs.Type == nil {
+		for _, t := range s.Type {
+			cs.Type = append(cs.Type, t.String())
+		}
+	}
+	if lo, exclusive, ok := s.lowerBound(); ok && (cs.Minimum == nil || lo > *cs.Minimum || (lo == *cs.Minimum && exclusive)) {
+		cs.Minimum, cs.ExclusiveMinimum = &lo, exclusive
+	}
+	if hi, exclusive, ok := s.upperBound(); ok && (cs.Maximum == nil || hi < *cs.Maximum || (hi == *cs.Maximum && exclusive)) {
+		cs.Maximum, cs.ExclusiveMaximum = &hi, exclusive
+	}
+	if s.MinLength != nil && (cs.MinLength == nil || *s.MinLength > *cs.MinLength) {
+		cs.MinLength = s.MinLength
+	}
+	if s.MaxLength != nil && (cs.MaxLength == nil || *s.MaxLength < *cs.MaxLength) {
+		cs.MaxLength = s.MaxLength
+	}
+	if s.Pattern != nil {
+		cs.Patterns = append(cs.Patterns, s.Pattern.String())
+	}
+	if cs.Enum == nil {
+		cs.Enum = s.Enum
+	}
+}
+
+// WriteConstraintsTypeScript writes a TypeScript declaration file (.d.ts)
+// to w describing the json form of constraints, as returned by
+// ExportConstraints: the ConstraintSet interface, and a constraints object
+// holding one for each of the paths in constraints.
+func WriteConstraintsTypeScript(w io.Writer, constraints map[string]ConstraintSet) error {
+	var b strings.Builder
+	b.WriteString("// Code generated by jsonschema.WriteConstraintsTypeScript; DO NOT EDIT.\n\n")
+	b.WriteString("export interface ConstraintSet {\n")
+	b.WriteString("  type?: string[];\n")
+	b.WriteString("  required?: boolean;\n")
+	b.WriteString("  minimum?: number;\n")
+	b.WriteString("  exclusiveMinimum?: boolean;\n")
+	b.WriteString("  maximum?: number;\n")
+	b.WriteString("  exclusiveMaximum?: boolean;\n")
+	b.WriteString("  minLength?: number;\n")
+	b.WriteString("  maxLength?: number;\n")
+	b.WriteString("  patterns?: string[];\n")
+	b.WriteString("  enum?: unknown[];\n")
+	b.WriteString("}\n\n")
+	b.WriteString("export declare const constraints: {\n")
+	for _, path := range sortedConstraintPaths(constraints) {
+		fmt.Fprintf(&b, "  readonly %s: ConstraintSet;\n", strconv.Quote(path))
+	}
+	b.WriteString("};\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// sortedConstraintPaths returns the paths in constraints in sorted order.
+func sortedConstraintPaths(constraints map[string]ConstraintSet) []string {
+	paths := make([]string, 0, len(constraints))
+	for path := range constraints {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
@@ -0,0 +1,96 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type ConstraintSetSuite struct{}
+
+var _ = gc.Suite(ConstraintSetSuite{})
+
+const constraintSetSchema = `
+type: object
+required: [name]
+properties:
+  name:
+    type: string
+    minLength: 1
+    maxLength: 63
+    pattern: "^[a-z]"
+    allOf:
+    - pattern: "[a-z0-9]$"
+    - maxLength: 40
+  port:
+    type: integer
+    minimum: 1
+    maximum: 65536
+    exclusiveMaximum: true
+  mode:
+    type: string
+    enum: [ha, single]
+  nodes:
+    type: array
+    items:
+      type: object
+      properties:
+        address:
+          type: string
+`
+
+func (ConstraintSetSuite) TestExportConstraints(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(constraintSetSchema))
+	c.Assert(err, jc.ErrorIsNil)
+	constraints := ExportConstraints(s)
+	c.Check(constraints, jc.DeepEquals, map[string]ConstraintSet{
+		"name": {
+			Type:      []string{"string"},
+			Required:  true,
+			MinLength: Int(1),
+			MaxLength: Int(40),
+			Patterns:  []string{"^[a-z]", "[a-z0-9]$"},
+		},
+		"port": {
+			Type:             []string{"integer"},
+			Minimum:          Float(1),
+			Maximum:          Float(65536),
+			ExclusiveMaximum: true,
+		},
+		"mode": {
+			Type: []string{"string"},
+			Enum: []interface{}{"ha", "single"},
+		},
+		"nodes[*].address": {
+			Type: []string{"string"},
+		},
+	})
+
+	data, err := json.Marshal(constraints["port"])
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(data), gc.Equals, `{"type":["integer"],"minimum":1,"maximum":65536,"exclusiveMaximum":true}`)
+}
+
+func (ConstraintSetSuite) TestWriteConstraintsTypeScript(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(constraintSetSchema))
+	c.Assert(err, jc.ErrorIsNil)
+	var buf bytes.Buffer
+	err = WriteConstraintsTypeScript(&buf, ExportConstraints(s))
+	c.Assert(err, jc.ErrorIsNil)
+	out := buf.String()
+	c.Check(out, jc.HasPrefix, "// Code generated by jsonschema.WriteConstraintsTypeScript; DO NOT EDIT.\n")
+	c.Check(out, jc.Contains, "export interface ConstraintSet {\n  type?: string[];\n")
+	c.Check(out, jc.Contains, `export declare const constraints: {
+  readonly "mode": ConstraintSet;
+  readonly "name": ConstraintSet;
+  readonly "nodes[*].address": ConstraintSet;
+  readonly "port": ConstraintSet;
+};
+`)
+}
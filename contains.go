@@ -0,0 +1,65 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import "fmt"
+
+// containsErrors returns an error if the array arr, found at path and
+// described by s, holds fewer items matching s.Contains than its
+// minContains requires, or more than its maxContains allows. References are
+// resolved within root.
+func containsErrors(root, s *Schema, arr []interface{}, path string) ValidationErrors {
+	if s.Contains == nil {
+		return nil
+	}
+	n := len(containedItems(root, s, arr))
+	min := 1
+	if s.MinContains != nil {
+		min = *s.MinContains
+	}
+	if n < min {
+		keyword := "contains"
+		if s.MinContains != nil {
+			keyword = "minContains"
+		}
+		return ValidationErrors{{
+			Path:    path,
+			Keyword: keyword,
+			Err:     fmt.Errorf("contains schema matches %s, need at least %d", matchingItems(n), min),
+		}}
+	}
+	if s.MaxContains != nil && n > *s.MaxContains {
+		return ValidationErrors{{
+			Path:    path,
+			Keyword: "maxContains",
+			Err:     fmt.Errorf("contains schema matches %s, want at most %d", matchingItems(n), *s.MaxContains),
+		}}
+	}
+	return nil
+}
+
+// containedItems returns the indexes of the items of arr that match
+// s.Contains, found within root, in order. They are matched in the same way
+// as an if schema, so the properties that s.Contains doesn't declare are
+// allowed.
+func containedItems(root, s *Schema, arr []interface{}) []int {
+	var indexes []int
+	for i, item := range arr {
+		if matchesConstraint(root, s.Contains, item) {
+			indexes = append(indexes, i)
+		}
+	}
+	return indexes
+}
+
+// matchingItems describes n items, as "no items", "1 item" or "3 items".
+func matchingItems(n int) string {
+	switch n {
+	case 0:
+		return "no items"
+	case 1:
+		return "1 item"
+	}
+	return fmt.Sprintf("%d items", n)
+}
@@ -0,0 +1,140 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"encoding/json"
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type ContainsSuite struct{}
+
+var _ = gc.Suite(ContainsSuite{})
+
+var containsSchema = `
+type: object
+properties:
+  disks:
+    type: array
+    items:
+      type: object
+      properties:
+        name: {type: string}
+        role: {type: string}
+    contains:
+      properties:
+        role: {enum: [boot]}
+      required: [role]
+  zones:
+    type: array
+    items: {type: string}
+    contains: {pattern: "^us-"}
+    minContains: 2
+    maxContains: 3
+  tags:
+    type: array
+    contains: {type: string, minLength: 4}
+    minContains: 0
+    maxContains: 1
+`
+
+func (ContainsSuite) TestContains(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(containsSchema))
+	c.Assert(err, jc.ErrorIsNil)
+	for i, test := range []struct {
+		doc map[string]interface{}
+		err string
+	}{{
+		doc: map[string]interface{}{"disks": []interface{}{
+			map[string]interface{}{"name": "a"},
+			map[string]interface{}{"name": "b", "role": "boot"},
+		}},
+	}, {
+		doc: map[string]interface{}{"disks": []interface{}{
+			map[string]interface{}{"name": "a", "role": "data"},
+		}},
+		err: `disks: contains schema matches no items, need at least 1`,
+	}, {
+		doc: map[string]interface{}{"disks": []interface{}{}},
+		err: `disks: contains schema matches no items, need at least 1`,
+	}, {
+		doc: map[string]interface{}{"zones": []interface{}{"us-east", "eu-west", "us-west"}},
+	}, {
+		doc: map[string]interface{}{"zones": []interface{}{"us-east", "eu-west"}},
+		err: `zones: contains schema matches 1 item, need at least 2`,
+	}, {
+		doc: map[string]interface{}{"zones": []interface{}{"us-a", "us-b", "us-c", "us-d"}},
+		err: `zones: contains schema matches 4 items, want at most 3`,
+	}, {
+		doc: map[string]interface{}{"tags": []interface{}{}},
+	}, {
+		doc: map[string]interface{}{"tags": []interface{}{"abcd", 1, "ab"}},
+	}, {
+		doc: map[string]interface{}{"tags": []interface{}{"abcd", "efgh"}},
+		err: `tags: contains schema matches 2 items, want at most 1`,
+	}, {
+		// The error from contains is found alongside those reported by
+		// jsschema.
+		doc: map[string]interface{}{"zones": []interface{}{"eu-west", 1}},
+		err: `(.|\n)*zones: contains schema matches no items, need at least 2(.|\n)*`,
+	}} {
+		c.Logf("test %d: %v", i, test.doc)
+		err := s.Validate(test.doc)
+		if test.err == "" {
+			c.Check(err, jc.ErrorIsNil)
+			continue
+		}
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (ContainsSuite) TestContainsKeywords(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(containsSchema))
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.Validate(map[string]interface{}{"zones": []interface{}{"us-east"}})
+	c.Assert(err, gc.FitsTypeOf, &ValidationError{})
+	c.Check(err.(*ValidationError).Keyword, gc.Equals, "minContains")
+	err = s.Validate(map[string]interface{}{"disks": []interface{}{}})
+	c.Assert(err, gc.FitsTypeOf, &ValidationError{})
+	c.Check(err.(*ValidationError).Keyword, gc.Equals, "contains")
+}
+
+func (ContainsSuite) TestContainsBoolean(c *gc.C) {
+	s, err := FromJSON(strings.NewReader(`{"type": "array", "contains": false}`))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.Validate([]interface{}{1}), gc.ErrorMatches, `contains schema matches no items, need at least 1`)
+	s, err = FromJSON(strings.NewReader(`{"type": "array", "contains": true}`))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.Validate([]interface{}{1}), jc.ErrorIsNil)
+	c.Check(s.Validate([]interface{}{}), gc.NotNil)
+}
+
+func (ContainsSuite) TestContainsUnevaluatedItems(c *gc.C) {
+	s, err := FromJSON(strings.NewReader(`{
+		"type": "array",
+		"prefixItems": [{"type": "string"}],
+		"contains": {"type": "integer"},
+		"unevaluatedItems": false
+	}`))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.Validate([]interface{}{"a", 1, 2}), jc.ErrorIsNil)
+	c.Check(s.Validate([]interface{}{"a", 1, true}), gc.ErrorMatches, `\[2\]: unevaluated items are not allowed`)
+}
+
+func (ContainsSuite) TestContainsRoundTrip(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(containsSchema))
+	c.Assert(err, jc.ErrorIsNil)
+	data, err := json.Marshal(s)
+	c.Assert(err, jc.ErrorIsNil)
+	var out Schema
+	c.Assert(json.Unmarshal(data, &out), jc.ErrorIsNil)
+	zones := out.Properties["zones"]
+	c.Check(zones.Contains, gc.NotNil)
+	c.Check(zones.MinContains, jc.DeepEquals, Int(2))
+	c.Check(zones.MaxContains, jc.DeepEquals, Int(3))
+	c.Check(out.Validate(map[string]interface{}{"zones": []interface{}{"us-east"}}), gc.NotNil)
+}
@@ -0,0 +1,357 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+)
+
+// CorpusReport describes a corpus of example documents checked by
+// VerifyCorpus.
+type CorpusReport struct {
+	// Valid and Invalid hold the names of the documents found in the
+	// valid and invalid directories of the corpus, in sorted order.
+	Valid   []string
+	Invalid []string
+
+	// Coverage describes the parts of the schema exercised by the
+	// documents in the corpus.
+	Coverage CoverageReport
+
+	// Suggestions holds an invalid document for each constraint which no
+	// document in the corpus exercises, where one can be made.
+	Suggestions []CorpusSuggestion
+}
+
+// CorpusSuggestion holds a document which could be added to a corpus as an
+// invalid example, to exercise a constraint that the corpus doesn't.
+type CorpusSuggestion struct {
+	// Name holds a name for the document within the corpus, such as
+	// "invalid/properties-port-maximum.json".
+	Name string
+
+	// Constraint holds the json pointer of the constraint the document
+	// exercises, as given by CoverageReport.
+	Constraint string
+
+	// Base holds the name of the valid document in the corpus that
+	// Document was made from, by changing a single value in it.
+	Base string
+
+	// Document holds the suggested document, which fails validation.
+	Document interface{}
+}
+
+// VerifyCorpus checks the corpus of example documents described by s held
+// in fsys, so that it can be run as a test of the schema. The corpus holds
+// a valid and an invalid directory, each holding documents in json or
+// yaml, with the .json, .yaml or .yml extension. An error is returned
+// describing each valid document which fails validation and each invalid
+// one which passes it, or if the documents can't be read.
+//
+// The report returned, even with an error, describes the coverage of the
+// schema by the corpus, and suggests an invalid document for each
+// constraint left uncovered, made by changing a value in a valid document
+// so that the constraint rejects it. Suggestions are only made for valid
+// documents which pass validation, and only if the document made fails it.
+func VerifyCorpus(fsys fs.FS, s *Schema) (CorpusReport, error) {
+	var report CorpusReport
+	valid, err := readCorpusDir(fsys, "valid")
+	if err != nil {
+		return report, err
+	}
+	invalid, err := readCorpusDir(fsys, "invalid")
+	if err != nil {
+		return report, err
+	}
+	var problems []string
+	var docs, bases []interface{}
+	var baseNames []string
+	for _, doc := range valid {
+		report.Valid = append(report.Valid, doc.name)
+		docs = append(docs, doc.value)
+		if err := s.Validate(copyValue(doc.value)); err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %v", doc.name, err))
+			continue
+		}
+		bases = append(bases, doc.value)
+		baseNames = append(baseNames, doc.name)
+	}
+	for _, doc := range invalid {
+		report.Invalid = append(report.Invalid, doc.name)
+		docs = append(docs, doc.value)
+		if err := s.Validate(copyValue(doc.value)); err == nil {
+			problems = append(problems, fmt.Sprintf("%s: unexpectedly valid", doc.name))
+		}
+	}
+	report.Coverage = Coverage(s, docs)
+	report.Suggestions = suggestInvalid(s, report.Coverage, bases, baseNames)
+	if len(problems) > 0 {
+		return report, fmt.Errorf("corpus documents not validated as expected: %s", strings.Join(problems, "; "))
+	}
+	return report, nil
+}
+
+// corpusDoc holds a document read from a corpus.
+type corpusDoc struct {
+	name  string
+	value interface{}
+}
+
+// readCorpusDir returns the documents held in the named directory of fsys,
+// in order of their names. A missing directory holds no documents.
+func readCorpusDir(fsys fs.FS, dir string) ([]corpusDoc, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot read corpus: %v", err)
+	}
+	var docs []corpusDoc
+	for _, entry := range entries {
+		switch path.Ext(entry.Name()) {
+		case ".json", ".yaml", ".yml":
+		default:
+			continue
+		}
+		if entry.IsDir() {
+			continue
+		}
+		name := path.Join(dir, entry.Name())
+		f, err := fsys.Open(name)
+		if err != nil {
+			return nil, fmt.Errorf("cannot read corpus: %v", err)
+		}
+		// YAML is a superset of json, so this handles both.
+		v, err := readYAML(f)
+		f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse corpus document %s: %v", name, err)
+		}
+		docs = append(docs, corpusDoc{name: name, value: v})
+	}
+	return docs, nil
+}
+
+// suggestInvalid returns a suggested invalid document for each constraint
+// of s left uncovered by the given coverage, made from the first of the
+// bases, named by names, holding a value that the constraint applies to.
+func suggestInvalid(s *Schema, coverage CoverageReport, bases []interface{}, names []string) []CorpusSuggestion {
+	uncovered := make(map[string]bool)
+	for _, item := range coverage.Constraints {
+		if item.Documents == 0 {
+			uncovered[item.Pointer] = true
+		}
+	}
+	if len(uncovered) == 0 {
+		return nil
+	}
+	cv := newCoverage(s)
+	var suggestions []CorpusSuggestion
+	suggested := make(map[string]bool)
+	for i, base := range bases {
+		cv.exercised = make(map[string]bool)
+		cv.applied = func(cs *Schema, x interface{}, path []interface{}) {
+			for _, c := range schemaConstraints(cs) {
+				pointer := cv.pointers[cs] + "/" + c.keyword
+				if !uncovered[pointer] || suggested[pointer] {
+					continue
+				}
+				v, ok := violatingValue(cs, c.keyword, x)
+				if _, isObj := asObject(v); !ok || (len(path) == 0 && !isObj) {
+					// Documents in a corpus are objects.
+					continue
+				}
+				doc := replaceValue(base, path, v)
+				if s.Validate(copyValue(doc)) == nil {
+					continue
+				}
+				suggested[pointer] = true
+				suggestions = append(suggestions, CorpusSuggestion{
+					Name:       "invalid/" + suggestionName(pointer) + ".json",
+					Constraint: pointer,
+					Base:       names[i],
+					Document:   doc,
+				})
+			}
+		}
+		cv.walk(s, base, nil)
+	}
+	order := make(map[string]int)
+	for i, item := range coverage.Constraints {
+		order[item.Pointer] = i
+	}
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		return order[suggestions[i].Constraint] < order[suggestions[j].Constraint]
+	})
+	return suggestions
+}
+
+// suggestionName returns the name of a document suggested to exercise the
+// constraint at the given pointer, such as "properties-port-maximum".
+func suggestionName(pointer string) string {
+	name := strings.Trim(strings.TrimPrefix(pointer, "#"), "/")
+	return strings.NewReplacer("/", "-", "~1", "-", "~0", "-").Replace(name)
+}
+
+// replaceValue returns a copy of x with the value found at path replaced by
+// v. The path holds the names of properties and the indexes of items on the
+// way to the value, which must exist. Only the values on the path are
+// copied.
+func replaceValue(x interface{}, path []interface{}, v interface{}) interface{} {
+	if len(path) == 0 {
+		return v
+	}
+	switch elem := path[0].(type) {
+	case string:
+		obj, _ := asObject(x)
+		out := make(map[string]interface{}, len(obj))
+		for k, pv := range obj {
+			out[k] = pv
+		}
+		out[elem] = replaceValue(obj[elem], path[1:], v)
+		return out
+	case int:
+		arr, _ := asArray(x)
+		out := make([]interface{}, len(arr))
+		copy(out, arr)
+		out[elem] = replaceValue(arr[elem], path[1:], v)
+		return out
+	}
+	return x
+}
+
+// violatingValue returns a value, made from x where it can be, that is
+// rejected by the constraint of s given by the named keyword, and reports
+// whether it could make one.
+func violatingValue(s *Schema, keyword string, x interface{}) (interface{}, bool) {
+	obj, isObj := asObject(x)
+	arr, isArr := asArray(x)
+	switch keyword {
+	case "type":
+		for _, v := range []interface{}{"invalid", 0.5, 1.0, true, nil, []interface{}{}, map[string]interface{}{}} {
+			if !matchesType(s.Type, v) {
+				return v, true
+			}
+		}
+	case "enum", "const":
+		values := s.Enum
+		if keyword == "const" {
+			values = []interface{}{s.Const}
+		}
+		return valueNotIn(values)
+	case "minimum":
+		return *s.Minimum - 1, true
+	case "exclusiveMinimum":
+		return s.exclusiveLower()
+	case "maximum":
+		return *s.Maximum + 1, true
+	case "exclusiveMaximum":
+		return s.exclusiveUpper()
+	case "multipleOf":
+		return *s.MultipleOf / 2, true
+	case "minLength":
+		if *s.MinLength > 0 {
+			return strings.Repeat("x", *s.MinLength-1), true
+		}
+	case "maxLength":
+		return strings.Repeat("x", *s.MaxLength+1), true
+	case "pattern":
+		for _, v := range []string{"", "!", "invalid value", "0"} {
+			if !s.Pattern.MatchString(v) {
+				return v, true
+			}
+		}
+	case "minItems":
+		if isArr && *s.MinItems > 0 && len(arr) >= *s.MinItems {
+			return append([]interface{}{}, arr[:*s.MinItems-1]...), true
+		}
+	case "maxItems", "uniqueItems":
+		if isArr && len(arr) > 0 {
+			n := 1
+			if keyword == "maxItems" && len(arr) <= *s.MaxItems {
+				n = *s.MaxItems + 1 - len(arr)
+			}
+			out := append([]interface{}{}, arr...)
+			for i := 0; i < n; i++ {
+				out = append(out, copyValue(arr[0]))
+			}
+			return out, true
+		}
+	case "minProperties":
+		if isObj && *s.MinProperties > 0 && len(obj) >= *s.MinProperties {
+			out := make(map[string]interface{})
+			for _, k := range sortedObjectKeys(obj)[:*s.MinProperties-1] {
+				out[k] = obj[k]
+			}
+			return out, true
+		}
+	case "maxProperties":
+		if isObj {
+			out := make(map[string]interface{})
+			for k, v := range obj {
+				out[k] = v
+			}
+			for i := 1; len(out) <= *s.MaxProperties; i++ {
+				out[fmt.Sprintf("extra-%d", i)] = nil
+			}
+			return out, true
+		}
+	case "required":
+		for _, name := range s.Required {
+			if _, ok := obj[name]; ok {
+				out := make(map[string]interface{})
+				for k, v := range obj {
+					if k != name {
+						out[k] = v
+					}
+				}
+				return out, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// valueNotIn returns a value of the same type as the first of values that
+// is none of them, and reports whether it could make one.
+func valueNotIn(values []interface{}) (interface{}, bool) {
+	in := func(v interface{}) bool {
+		for _, value := range values {
+			if valuesEqual(v, value) {
+				return true
+			}
+		}
+		return false
+	}
+	var candidates []interface{}
+	switch first := normalizeValue(values[0]).(type) {
+	case string:
+		candidates = []interface{}{"invalid", first + "-invalid"}
+	case float64:
+		max := first
+		for _, v := range values {
+			if f, ok := normalizeValue(v).(float64); ok && f > max {
+				max = f
+			}
+		}
+		candidates = []interface{}{max + 1}
+	case bool:
+		candidates = []interface{}{!first}
+	default:
+		candidates = []interface{}{"invalid"}
+	}
+	for _, v := range candidates {
+		if !in(v) {
+			return v, true
+		}
+	}
+	return nil, false
+}
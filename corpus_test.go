@@ -0,0 +1,169 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"strings"
+	"testing/fstest"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type CorpusSuite struct{}
+
+var _ = gc.Suite(CorpusSuite{})
+
+var corpusSchema = `
+type: object
+required: [name]
+properties:
+  name:
+    type: string
+    maxLength: 8
+  port:
+    type: integer
+    minimum: 1
+    maximum: 65535
+  tags:
+    type: array
+    items: {type: string}
+    uniqueItems: true
+`
+
+func (CorpusSuite) TestVerifyCorpus(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(corpusSchema))
+	c.Assert(err, jc.ErrorIsNil)
+	report, err := VerifyCorpus(fstest.MapFS{
+		"valid/basic.yaml":         {Data: []byte("name: db\nport: 5432\ntags: [a, b]\n")},
+		"valid/minimal.json":       {Data: []byte(`{"name": "db"}`)},
+		"invalid/no-name.yaml":     {Data: []byte("port: 80\n")},
+		"invalid/port-zero.yaml":   {Data: []byte("name: db\nport: 0\n")},
+		"invalid/README.md":        {Data: []byte("not a document")},
+		"schema.yaml":              {Data: []byte("not in the corpus")},
+		"invalid/long-name.yml":    {Data: []byte("name: much-too-long\n")},
+		"invalid/port-string.json": {Data: []byte(`{"name": "db", "port": "80"}`)},
+		"invalid/notes.txt":        {Data: []byte("ignored")},
+	}, s)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(report.Valid, jc.DeepEquals, []string{"valid/basic.yaml", "valid/minimal.json"})
+	c.Check(report.Invalid, jc.DeepEquals, []string{
+		"invalid/long-name.yml",
+		"invalid/no-name.yaml",
+		"invalid/port-string.json",
+		"invalid/port-zero.yaml",
+	})
+	c.Check(report.Coverage.Uncovered(), jc.DeepEquals, []string{
+		"#/type",
+		"#/properties/name/type",
+		"#/properties/port/maximum",
+		"#/properties/tags/type",
+		"#/properties/tags/uniqueItems",
+		"#/properties/tags/items/type",
+	})
+	c.Check(report.Suggestions, jc.DeepEquals, []CorpusSuggestion{{
+		Name:       "invalid/properties-name-type.json",
+		Constraint: "#/properties/name/type",
+		Base:       "valid/basic.yaml",
+		Document:   map[string]interface{}{"name": 0.5, "port": 5432, "tags": []interface{}{"a", "b"}},
+	}, {
+		Name:       "invalid/properties-port-maximum.json",
+		Constraint: "#/properties/port/maximum",
+		Base:       "valid/basic.yaml",
+		Document:   map[string]interface{}{"name": "db", "port": 65536.0, "tags": []interface{}{"a", "b"}},
+	}, {
+		Name:       "invalid/properties-tags-type.json",
+		Constraint: "#/properties/tags/type",
+		Base:       "valid/basic.yaml",
+		Document:   map[string]interface{}{"name": "db", "port": 5432, "tags": "invalid"},
+	}, {
+		Name:       "invalid/properties-tags-uniqueItems.json",
+		Constraint: "#/properties/tags/uniqueItems",
+		Base:       "valid/basic.yaml",
+		Document:   map[string]interface{}{"name": "db", "port": 5432, "tags": []interface{}{"a", "b", "a"}},
+	}, {
+		Name:       "invalid/properties-tags-items-type.json",
+		Constraint: "#/properties/tags/items/type",
+		Base:       "valid/basic.yaml",
+		Document:   map[string]interface{}{"name": "db", "port": 5432, "tags": []interface{}{0.5, "b"}},
+	}})
+}
+
+func (CorpusSuite) TestVerifyCorpusFailures(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(corpusSchema))
+	c.Assert(err, jc.ErrorIsNil)
+	report, err := VerifyCorpus(fstest.MapFS{
+		"valid/bad-port.yaml":  {Data: []byte("name: db\nport: 0\n")},
+		"invalid/minimal.yaml": {Data: []byte("name: db\n")},
+	}, s)
+	c.Check(err, gc.ErrorMatches, `corpus documents not validated as expected: `+
+		`valid/bad-port.yaml: port: .*minimum.*; invalid/minimal.yaml: unexpectedly valid`)
+	c.Check(report.Valid, jc.DeepEquals, []string{"valid/bad-port.yaml"})
+	c.Check(report.Invalid, jc.DeepEquals, []string{"invalid/minimal.yaml"})
+	// Invalid documents aren't used to make suggestions.
+	c.Check(report.Suggestions, gc.HasLen, 0)
+}
+
+func (CorpusSuite) TestVerifyCorpusParseError(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(corpusSchema))
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = VerifyCorpus(fstest.MapFS{
+		"valid/broken.yaml": {Data: []byte("name: [\n")},
+	}, s)
+	c.Check(err, gc.ErrorMatches, `cannot parse corpus document valid/broken.yaml: .*`)
+}
+
+func (CorpusSuite) TestVerifyEmptyCorpus(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(corpusSchema))
+	c.Assert(err, jc.ErrorIsNil)
+	report, err := VerifyCorpus(fstest.MapFS{}, s)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(report.Valid, gc.HasLen, 0)
+	c.Check(report.Coverage.Uncovered(), gc.HasLen, len(report.Coverage.Properties)+len(report.Coverage.Constraints))
+}
+
+func (CorpusSuite) TestViolatingValue(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(`
+type: object
+minProperties: 2
+maxProperties: 3
+properties:
+  size: {type: integer, multipleOf: 4, exclusiveMinimum: 0}
+  mode: {type: string, enum: [ro, rw], minLength: 2, pattern: "^[a-z]+$"}
+  nodes: {type: array, minItems: 1, maxItems: 2}
+`))
+	c.Assert(err, jc.ErrorIsNil)
+	size, mode, nodes := s.Properties["size"], s.Properties["mode"], s.Properties["nodes"]
+	for i, test := range []struct {
+		schema  *Schema
+		keyword string
+		x       interface{}
+		want    interface{}
+	}{
+		{schema: size, keyword: "multipleOf", x: 8, want: 2.0},
+		{schema: size, keyword: "exclusiveMinimum", x: 8, want: 0.0},
+		{schema: mode, keyword: "enum", x: "ro", want: "invalid"},
+		{schema: mode, keyword: "minLength", x: "ro", want: "x"},
+		{schema: mode, keyword: "pattern", x: "ro", want: ""},
+		{schema: nodes, keyword: "minItems", x: []interface{}{1}, want: []interface{}{}},
+		{schema: nodes, keyword: "maxItems", x: []interface{}{1}, want: []interface{}{1, 1, 1}},
+		{
+			schema:  s,
+			keyword: "minProperties",
+			x:       map[string]interface{}{"mode": "ro", "size": 4},
+			want:    map[string]interface{}{"mode": "ro"},
+		},
+		{
+			schema:  s,
+			keyword: "maxProperties",
+			x:       map[string]interface{}{"mode": "ro", "size": 4},
+			want:    map[string]interface{}{"mode": "ro", "size": 4, "extra-1": nil, "extra-2": nil},
+		},
+	} {
+		c.Logf("test %d: %s of %v", i, test.keyword, test.x)
+		v, ok := violatingValue(test.schema, test.keyword, test.x)
+		c.Assert(ok, jc.IsTrue)
+		c.Check(v, jc.DeepEquals, test.want)
+	}
+}
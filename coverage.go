@@ -0,0 +1,326 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"unicode/utf8"
+)
+
+// CoverageReport describes how much of a schema is exercised by a corpus of
+// documents, such as the fixtures used to test it. See Coverage.
+type CoverageReport struct {
+	// Properties holds an item for each property declared by the
+	// schema, which is exercised by the documents that set it.
+	Properties []CoverageItem
+
+	// Branches holds an item for each anyOf and oneOf alternative, each
+	// then and else schema of a conditional, and each schema depending on
+	// a property, which are exercised by the documents holding a value
+	// they apply to.
+	Branches []CoverageItem
+
+	// Constraints holds an item for each constraint given by the schema,
+	// such as a minimum or a pattern, which is exercised by the documents
+	// holding a value that it rejects, as an invalid fixture should.
+	Constraints []CoverageItem
+}
+
+// CoverageItem describes how often a part of a schema is exercised.
+type CoverageItem struct {
+	// Pointer holds the json pointer of the part of the schema, such as
+	// "#/properties/port" for a property, "#/anyOf/1" for a branch or
+	// "#/properties/port/maximum" for a constraint.
+	Pointer string
+
+	// Documents holds the number of documents which exercise it.
+	Documents int
+}
+
+// Uncovered returns the pointers of the properties, branches and
+// constraints in r which no document exercises, in that order.
+func (r CoverageReport) Uncovered() []string {
+	var pointers []string
+	for _, items := range [][]CoverageItem{r.Properties, r.Branches, r.Constraints} {
+		for _, item := range items {
+			if item.Documents == 0 {
+				pointers = append(pointers, item.Pointer)
+			}
+		}
+	}
+	return pointers
+}
+
+// Coverage returns a report of the properties, branches and constraints of
+// s that are exercised by docs, so that a corpus of test fixtures can be
+// checked to cover the schema. The documents needn't be valid: a
+// constraint is only exercised by a value that it rejects. Values are
+// followed through the properties and items of the schemas that describe
+// them, and through the composed schemas that apply to them, as for
+// unevaluatedProperties, so a branch is only exercised by a value that it
+// matches. The schemas given by if and not are conditions rather than
+// constraints, so their properties and constraints aren't reported.
+func Coverage(s *Schema, docs []interface{}) CoverageReport {
+	cv := newCoverage(s)
+	var report CoverageReport
+	listed := make(map[string]bool)
+	add := func(items *[]CoverageItem, pointer string) {
+		if !listed[pointer] {
+			listed[pointer] = true
+			*items = append(*items, CoverageItem{Pointer: pointer})
+		}
+	}
+	for _, sub := range cv.schemas {
+		for _, name := range sortedKeys(sub.Properties) {
+			add(&report.Properties, cv.pointers[sub.Properties[name]])
+		}
+		for _, branch := range coverageBranches(sub) {
+			add(&report.Branches, cv.pointers[branch])
+		}
+		for _, c := range schemaConstraints(sub) {
+			add(&report.Constraints, cv.pointers[sub]+"/"+c.keyword)
+		}
+	}
+	counts := make(map[string]int)
+	for _, doc := range docs {
+		cv.exercised = make(map[string]bool)
+		cv.walk(s, doc, nil)
+		for pointer := range cv.exercised {
+			counts[pointer]++
+		}
+	}
+	for _, items := range [][]CoverageItem{report.Properties, report.Branches, report.Constraints} {
+		for i := range items {
+			items[i].Documents = counts[items[i].Pointer]
+		}
+	}
+	return report
+}
+
+type coverage struct {
+	root *Schema
+
+	// pointers holds the json pointer of each schema within root.
+	pointers map[*Schema]string
+
+	// schemas holds the schemas within root whose properties, branches
+	// and constraints are reported, in the order they are found.
+	schemas []*Schema
+
+	// exercised holds the pointers of the parts of root exercised by the
+	// document being walked.
+	exercised map[string]bool
+
+	// applied, if set, is called with each schema that applies to a value
+	// in the document being walked, along with the value and its path, as
+	// described by replaceValue.
+	applied func(cs *Schema, x interface{}, path []interface{})
+}
+
+// newCoverage returns a coverage for documents described by s. The schemas
+// given by if and not are conditions rather than constraints, so they are
+// left out of its schemas.
+func newCoverage(s *Schema) *coverage {
+	cv := &coverage{root: s, pointers: make(map[*Schema]string)}
+	conditions := make(map[*Schema]bool)
+	walkSchemaPointers(s, func(sub *Schema, pointer string) {
+		cv.pointers[sub] = pointer
+		cv.schemas = append(cv.schemas, sub)
+		for _, cond := range []*Schema{sub.If, sub.Not} {
+			if cond != nil {
+				walkSchemaPointers(cond, func(cs *Schema, _ string) {
+					conditions[cs] = true
+				})
+			}
+		}
+	})
+	schemas := cv.schemas[:0]
+	for _, sub := range cv.schemas {
+		if !conditions[sub] {
+			schemas = append(schemas, sub)
+		}
+	}
+	cv.schemas = schemas
+	return cv
+}
+
+// walk records the parts of s, and of the schemas nested within it, that
+// are exercised by x, found at path.
+func (cv *coverage) walk(s *Schema, x interface{}, path []interface{}) {
+	cv.exercised[cv.pointers[s]] = true
+	applicableSchemas(cv.root, s, x, func(cs *Schema) {
+		pointer := cv.pointers[cs]
+		cv.exercised[pointer] = true
+		if cv.applied != nil {
+			cv.applied(cs, x, path)
+		}
+		for _, c := range schemaConstraints(cs) {
+			if c.rejects(x) {
+				cv.exercised[pointer+"/"+c.keyword] = true
+			}
+		}
+		path := path[:len(path):len(path)]
+		if obj, ok := asObject(x); ok {
+			for _, name := range sortedObjectKeys(obj) {
+				for _, ps := range propertySchemas(cs, name) {
+					cv.walk(ps, obj[name], append(path, name))
+				}
+			}
+		}
+		if arr, ok := asArray(x); ok {
+			for i, item := range arr {
+				cv.walk(itemSchema(cs, i), item, append(path, i))
+			}
+		}
+	})
+}
+
+// coverageBranches returns the schemas composed into s which only apply to
+// some values: its anyOf and oneOf alternatives, its then and else
+// schemas, and the schemas depending on its properties.
+func coverageBranches(s *Schema) []*Schema {
+	var branches []*Schema
+	branches = append(branches, s.AnyOf...)
+	branches = append(branches, s.OneOf...)
+	for _, branch := range []*Schema{s.Then, s.Else} {
+		if branch != nil {
+			branches = append(branches, branch)
+		}
+	}
+	for _, name := range sortedDependents(s) {
+		branches = append(branches, s.dependentSchemas()[name]...)
+	}
+	return branches
+}
+
+// constraint holds a constraint given by a schema, named by its keyword.
+type constraint struct {
+	keyword string
+
+	// rejects reports whether the constraint rejects x. Values of a kind
+	// that the constraint doesn't apply to aren't rejected.
+	rejects func(x interface{}) bool
+}
+
+// schemaConstraints returns the constraints given by s on the values it
+// describes, leaving aside those on the properties and items that they
+// hold, which are given by the schemas nested in s.
+func schemaConstraints(s *Schema) []constraint {
+	var cs []constraint
+	add := func(keyword string, rejects func(x interface{}) bool) {
+		cs = append(cs, constraint{keyword: keyword, rejects: rejects})
+	}
+	number := func(keyword string, bound *float64, rejects func(f, bound float64) bool) {
+		if bound == nil {
+			return
+		}
+		add(keyword, func(x interface{}) bool {
+			f, ok := normalizeValue(x).(float64)
+			return ok && rejects(f, *bound)
+		})
+	}
+	length := func(keyword string, bound *int, size func(x interface{}) (int, bool), rejects func(n, bound int) bool) {
+		if bound == nil {
+			return
+		}
+		add(keyword, func(x interface{}) bool {
+			n, ok := size(x)
+			return ok && rejects(n, *bound)
+		})
+	}
+	less := func(n, bound int) bool { return n < bound }
+	greater := func(n, bound int) bool { return n > bound }
+	runes := func(x interface{}) (int, bool) {
+		str, ok := x.(string)
+		return utf8.RuneCountInString(str), ok
+	}
+	items := func(x interface{}) (int, bool) {
+		arr, ok := asArray(x)
+		return len(arr), ok
+	}
+	properties := func(x interface{}) (int, bool) {
+		obj, ok := asObject(x)
+		return len(obj), ok
+	}
+
+	if len(s.Type) > 0 {
+		add("type", func(x interface{}) bool {
+			return !matchesType(s.Type, x)
+		})
+	}
+	if len(s.Enum) > 0 {
+		add("enum", func(x interface{}) bool {
+			for _, v := range s.Enum {
+				if valuesEqual(x, v) {
+					return false
+				}
+			}
+			return true
+		})
+	}
+	if s.Const != nil {
+		add("const", func(x interface{}) bool {
+			return !valuesEqual(x, s.Const)
+		})
+	}
+	number("minimum", s.Minimum, func(f, bound float64) bool { return f < bound })
+	if lo, ok := s.exclusiveLower(); ok {
+		number("exclusiveMinimum", &lo, func(f, bound float64) bool { return f <= bound })
+	}
+	number("maximum", s.Maximum, func(f, bound float64) bool { return f > bound })
+	if hi, ok := s.exclusiveUpper(); ok {
+		number("exclusiveMaximum", &hi, func(f, bound float64) bool { return f >= bound })
+	}
+	number("multipleOf", s.MultipleOf, func(f, m float64) bool { return !isMultipleOf(f, m) })
+	length("minLength", s.MinLength, runes, less)
+	length("maxLength", s.MaxLength, runes, greater)
+	if s.Pattern != nil {
+		add("pattern", func(x interface{}) bool {
+			str, ok := x.(string)
+			return ok && !s.Pattern.MatchString(str)
+		})
+	}
+	length("minItems", s.MinItems, items, less)
+	length("maxItems", s.MaxItems, items, greater)
+	if s.UniqueItems != nil && *s.UniqueItems {
+		add("uniqueItems", func(x interface{}) bool {
+			arr, _ := asArray(x)
+			for i := range arr {
+				for j := i + 1; j < len(arr); j++ {
+					if valuesEqual(arr[i], arr[j]) {
+						return true
+					}
+				}
+			}
+			return false
+		})
+	}
+	length("minProperties", s.MinProperties, properties, less)
+	length("maxProperties", s.MaxProperties, properties, greater)
+	if len(s.Required) > 0 {
+		add("required", func(x interface{}) bool {
+			obj, ok := asObject(x)
+			if !ok {
+				return false
+			}
+			for _, name := range s.Required {
+				if _, ok := obj[name]; !ok {
+					return true
+				}
+			}
+			return false
+		})
+	}
+	return cs
+}
+
+// matchesType reports whether x is of one of the given types.
+func matchesType(types []Type, x interface{}) bool {
+	t := valueType(nil, x)
+	for _, want := range types {
+		if want == t || (want == NumberType && t == IntegerType) {
+			return true
+		}
+	}
+	return false
+}
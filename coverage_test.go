@@ -0,0 +1,142 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type CoverageSuite struct{}
+
+var _ = gc.Suite(CoverageSuite{})
+
+var coverageSchema = `
+type: object
+required: [name]
+properties:
+  name:
+    type: string
+    maxLength: 8
+  port:
+    type: integer
+    minimum: 1
+    maximum: 65535
+  storage:
+    anyOf:
+    - type: string
+      pattern: ^[0-9]+G$
+    - type: integer
+  nodes:
+    type: array
+    items:
+      type: object
+      properties:
+        address: {type: string}
+if:
+  required: [port]
+  properties:
+    port: {const: 443}
+then:
+  required: [certificate]
+`
+
+func (CoverageSuite) TestCoverage(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(coverageSchema))
+	c.Assert(err, jc.ErrorIsNil)
+	report := Coverage(s, []interface{}{
+		map[string]interface{}{"name": "db", "port": 5432, "storage": "10G"},
+		map[string]interface{}{"name": "much-too-long", "storage": "10G"},
+		map[string]interface{}{"port": 0, "nodes": []interface{}{map[string]interface{}{}}},
+	})
+	c.Check(report.Properties, jc.DeepEquals, []CoverageItem{
+		{Pointer: "#/properties/name", Documents: 2},
+		{Pointer: "#/properties/nodes", Documents: 1},
+		{Pointer: "#/properties/port", Documents: 2},
+		{Pointer: "#/properties/storage", Documents: 2},
+		{Pointer: "#/properties/nodes/items/properties/address", Documents: 0},
+	})
+	c.Check(report.Branches, jc.DeepEquals, []CoverageItem{
+		{Pointer: "#/then", Documents: 0},
+		{Pointer: "#/properties/storage/anyOf/0", Documents: 2},
+		{Pointer: "#/properties/storage/anyOf/1", Documents: 0},
+	})
+	c.Check(report.Constraints, jc.DeepEquals, []CoverageItem{
+		{Pointer: "#/type", Documents: 0},
+		{Pointer: "#/required", Documents: 1},
+		{Pointer: "#/properties/name/type", Documents: 0},
+		{Pointer: "#/properties/name/maxLength", Documents: 1},
+		{Pointer: "#/properties/nodes/type", Documents: 0},
+		{Pointer: "#/properties/nodes/items/type", Documents: 0},
+		{Pointer: "#/properties/nodes/items/properties/address/type", Documents: 0},
+		{Pointer: "#/properties/port/type", Documents: 0},
+		{Pointer: "#/properties/port/minimum", Documents: 1},
+		{Pointer: "#/properties/port/maximum", Documents: 0},
+		{Pointer: "#/properties/storage/anyOf/0/type", Documents: 0},
+		{Pointer: "#/properties/storage/anyOf/0/pattern", Documents: 0},
+		{Pointer: "#/properties/storage/anyOf/1/type", Documents: 0},
+		{Pointer: "#/then/required", Documents: 0},
+	})
+}
+
+func (CoverageSuite) TestCoverageBranches(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(coverageSchema))
+	c.Assert(err, jc.ErrorIsNil)
+	report := Coverage(s, []interface{}{
+		map[string]interface{}{"name": "web", "port": 443, "storage": 10},
+		map[string]interface{}{"name": "web", "storage": "ten"},
+	})
+	c.Check(report.Branches, jc.DeepEquals, []CoverageItem{
+		{Pointer: "#/then", Documents: 1},
+		{Pointer: "#/properties/storage/anyOf/0", Documents: 0},
+		{Pointer: "#/properties/storage/anyOf/1", Documents: 1},
+	})
+}
+
+func (CoverageSuite) TestUncovered(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(`
+type: object
+properties:
+  size: {type: integer, minimum: 1}
+  kind: {type: string, enum: [disk, nic]}
+`))
+	c.Assert(err, jc.ErrorIsNil)
+	report := Coverage(s, []interface{}{
+		map[string]interface{}{"size": 0},
+		map[string]interface{}{"size": "big"},
+	})
+	c.Check(report.Uncovered(), jc.DeepEquals, []string{
+		"#/properties/kind",
+		"#/type",
+		"#/properties/kind/type",
+		"#/properties/kind/enum",
+	})
+}
+
+func (CoverageSuite) TestCoverageReferences(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(`
+type: object
+definitions:
+  port: {type: integer, maximum: 65535}
+properties:
+  http: {$ref: "#/definitions/port"}
+  https: {$ref: "#/definitions/port"}
+`))
+	c.Assert(err, jc.ErrorIsNil)
+	report := Coverage(s, []interface{}{
+		map[string]interface{}{"http": 80},
+		map[string]interface{}{"https": 100000},
+	})
+	c.Check(report.Properties, jc.DeepEquals, []CoverageItem{
+		{Pointer: "#/properties/http", Documents: 1},
+		{Pointer: "#/properties/https", Documents: 1},
+	})
+	c.Check(report.Constraints, jc.DeepEquals, []CoverageItem{
+		{Pointer: "#/type", Documents: 0},
+		{Pointer: "#/definitions/port/type", Documents: 0},
+		{Pointer: "#/definitions/port/maximum", Documents: 1},
+	})
+}
@@ -0,0 +1,259 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ToCRDValidation returns s converted into the structural schema subset which
+// Kubernetes accepts as the openAPIV3Schema of a custom resource definition.
+// Keywords Kubernetes doesn't support are pruned, juju extensions are either
+// dropped or converted into their x-kubernetes equivalent (immutable becomes
+// a transition rule), and any x-kubernetes-* keywords set on s are kept.
+// The branches of allOf, anyOf, oneOf and not are left without a type, as
+// Kubernetes requires.
+// An error is returned if s can't be expressed as a structural schema, for
+// example because it uses references or tuple items.
+func ToCRDValidation(s *Schema) (map[string]interface{}, error) {
+	b, err := json.Marshal(s)
+	if err != nil {
+		return nil, err
+	}
+	var m map[string]interface{}
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, err
+	}
+	if err := toCRDSchema(m, "", nil); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// toCRDSchema converts m, found at path, into a structural schema. If m is
+// within an allOf, anyOf, oneOf or not, structural holds the schema at the
+// same position outside them, which gives the value's type, and otherwise it
+// is nil.
+func toCRDSchema(m map[string]interface{}, path string, structural map[string]interface{}) error {
+	fail := func(format string, args ...interface{}) error {
+		where := "schema"
+		if path != "" {
+			where = fmt.Sprintf("property %q", path)
+		}
+		return fmt.Errorf("cannot convert %s to CRD validation: %s", where, fmt.Sprintf(format, args...))
+	}
+	if _, ok := m["$ref"]; ok {
+		return fail("references are not supported")
+	}
+
+	immutable := m["immutable"] == true
+	for k := range m {
+		if isCRDKeyword(k) {
+			continue
+		}
+		delete(m, k)
+	}
+	if immutable {
+		m["x-kubernetes-validations"] = []interface{}{
+			map[string]interface{}{
+				"rule":    "self == oldSelf",
+				"message": "value is immutable",
+			},
+		}
+	}
+	// OpenAPI v3.0 gives exclusive limits in the boolean form of draft 4,
+	// modifying minimum and maximum.
+	for _, b := range exclusiveBounds() {
+		limit, ok := m[b.keyword].(float64)
+		if !ok {
+			continue
+		}
+		if bound, ok := m[b.bound].(float64); ok && (b.bound == "minimum" && bound > limit || b.bound == "maximum" && bound < limit) {
+			// The inclusive bound is the tighter.
+			delete(m, b.keyword)
+			continue
+		}
+		m[b.bound], m[b.keyword] = limit, true
+	}
+	// Additional properties may not be disallowed alongside properties.
+	if m["additionalProperties"] == false {
+		delete(m, "additionalProperties")
+	}
+	if m["uniqueItems"] == false {
+		delete(m, "uniqueItems")
+	} else if m["uniqueItems"] == true {
+		return fail("uniqueItems is not supported")
+	}
+
+	if structural == nil {
+		if err := toCRDType(m); err != nil {
+			return fail("%v", err)
+		}
+	} else if err := toCRDJunctorType(m, structural); err != nil {
+		return fail("%v", err)
+	}
+
+	if props, ok := m["properties"].(map[string]interface{}); ok {
+		for _, name := range sortedObjectKeys(props) {
+			ps, _ := props[name].(map[string]interface{})
+			if err := toCRDSchema(ps, propertyPath(path, name), crdChild(structural, "properties", name)); err != nil {
+				return err
+			}
+		}
+	}
+	switch items := m["items"].(type) {
+	case []interface{}:
+		return fail("tuple items are not supported")
+	case map[string]interface{}:
+		if err := toCRDSchema(items, path+"[*]", crdChild(structural, "items", "")); err != nil {
+			return err
+		}
+	}
+	if ap, ok := m["additionalProperties"].(map[string]interface{}); ok {
+		if structural != nil {
+			return fail("additionalProperties is not supported within allOf, anyOf, oneOf or not")
+		}
+		if err := toCRDSchema(ap, propertyPath(path, "*"), nil); err != nil {
+			return err
+		}
+	}
+	// The branches of junctors may only constrain values whose type is
+	// given by the schema outside them.
+	if structural == nil {
+		structural = m
+	}
+	for _, k := range []string{"allOf", "anyOf", "oneOf"} {
+		l, _ := m[k].([]interface{})
+		for _, sub := range l {
+			sm, _ := sub.(map[string]interface{})
+			if err := toCRDSchema(sm, path, structural); err != nil {
+				return err
+			}
+		}
+	}
+	if not, ok := m["not"].(map[string]interface{}); ok {
+		if err := toCRDSchema(not, path, structural); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// crdChild returns the schema found in the given keyword of structural, by
+// name if given, or nil if there is none.
+func crdChild(structural map[string]interface{}, keyword, name string) map[string]interface{} {
+	if structural == nil {
+		return nil
+	}
+	child := structural[keyword]
+	if name != "" {
+		props, _ := child.(map[string]interface{})
+		child = props[name]
+	}
+	m, _ := child.(map[string]interface{})
+	if m == nil {
+		// Give the value no type rather than none at all.
+		m = map[string]interface{}{}
+	}
+	return m
+}
+
+// toCRDJunctorType removes the type keyword, and the other keywords which
+// structural schemas don't allow, from m, the branch of a junctor. Its type
+// must agree with that of structural, the schema outside the junctor.
+func toCRDJunctorType(m, structural map[string]interface{}) error {
+	for _, k := range []string{"title", "description", "default"} {
+		delete(m, k)
+	}
+	if _, ok := m["type"]; !ok {
+		delete(m, "nullable")
+		return nil
+	}
+	t := map[string]interface{}{"type": m["type"]}
+	if err := toCRDType(t); err != nil {
+		return err
+	}
+	if t["type"] != structural["type"] || t["x-kubernetes-int-or-string"] != structural["x-kubernetes-int-or-string"] {
+		return fmt.Errorf("type within allOf, anyOf, oneOf or not must match the type outside them")
+	}
+	delete(m, "type")
+	delete(m, "nullable")
+	return nil
+}
+
+// toCRDType converts the type keyword of m into the single type required by
+// structural schemas.
+func toCRDType(m map[string]interface{}) error {
+	var types []string
+	switch t := m["type"].(type) {
+	case string:
+		types = []string{t}
+	case []interface{}:
+		for _, v := range t {
+			types = append(types, fmt.Sprint(v))
+		}
+	}
+	var nonNull []string
+	for _, t := range types {
+		if t == "null" {
+			m["nullable"] = true
+		} else {
+			nonNull = append(nonNull, t)
+		}
+	}
+	sort.Strings(nonNull)
+	switch {
+	case len(nonNull) == 1:
+		m["type"] = nonNull[0]
+	case len(nonNull) == 2 && nonNull[0] == "integer" && nonNull[1] == "string":
+		delete(m, "type")
+		m["x-kubernetes-int-or-string"] = true
+	case len(nonNull) == 0:
+		if _, ok := m["properties"]; ok {
+			m["type"] = "object"
+			break
+		}
+		if _, ok := m["items"]; ok {
+			m["type"] = "array"
+			break
+		}
+		delete(m, "type")
+		if len(m) == 0 || (len(m) == 1 && m["nullable"] == true) {
+			// An unconstrained value must be explicitly preserved.
+			m["x-kubernetes-preserve-unknown-fields"] = true
+			break
+		}
+		if m["x-kubernetes-preserve-unknown-fields"] != true && m["x-kubernetes-int-or-string"] != true {
+			return fmt.Errorf("type must be specified")
+		}
+	default:
+		return fmt.Errorf("multiple types %s are not supported", strings.Join(nonNull, ", "))
+	}
+	return nil
+}
+
+// isCRDKeyword reports whether k may be used in a structural schema. Juju
+// extensions, and standard keywords such as $ref and definitions which
+// Kubernetes doesn't support, are excluded.
+func isCRDKeyword(k string) bool {
+	return strings.HasPrefix(k, "x-kubernetes-") || isCRDSchemaKeyword(k)
+}
+
+// isCRDSchemaKeyword reports whether k is one of the standard jsonschema
+// keywords supported by Kubernetes.
+func isCRDSchemaKeyword(k string) bool {
+	switch k {
+	case "title", "description", "default", "type", "format",
+		"multipleOf", "minimum", "maximum", "exclusiveMinimum", "exclusiveMaximum",
+		"maxLength", "minLength", "pattern",
+		"items", "minItems", "maxItems", "uniqueItems",
+		"maxProperties", "minProperties", "required", "properties", "additionalProperties",
+		"enum", "allOf", "anyOf", "oneOf", "not", "nullable":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,177 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type CRDSuite struct{}
+
+var _ = gc.Suite(CRDSuite{})
+
+const crdExample = `
+$schema: http://json-schema.org/draft-04/schema#
+type: object
+title: Database
+required: [engine]
+properties:
+  engine:
+    type: string
+    enum: [postgresql, mysql]
+    immutable: true
+    singular: engine
+  port:
+    type: [integer, string]
+  password:
+    type: string
+    secret: true
+  labels:
+    type: object
+    additionalProperties:
+      type: string
+  replicas:
+    type: [integer, "null"]
+    minimum: 1
+  ratio:
+    type: number
+    minimum: 0
+    exclusiveMaximum: 1
+  extra:
+    x-kubernetes-preserve-unknown-fields: true
+  anything: {}
+`
+
+func (CRDSuite) TestToCRDValidation(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(crdExample))
+	c.Assert(err, jc.ErrorIsNil)
+
+	v, err := ToCRDValidation(s)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(v, jc.DeepEquals, map[string]interface{}{
+		"type":     "object",
+		"title":    "Database",
+		"required": []interface{}{"engine"},
+		"properties": map[string]interface{}{
+			"engine": map[string]interface{}{
+				"type": "string",
+				"enum": []interface{}{"postgresql", "mysql"},
+				"x-kubernetes-validations": []interface{}{
+					map[string]interface{}{
+						"rule":    "self == oldSelf",
+						"message": "value is immutable",
+					},
+				},
+			},
+			"port": map[string]interface{}{
+				"x-kubernetes-int-or-string": true,
+			},
+			"password": map[string]interface{}{
+				"type": "string",
+			},
+			"labels": map[string]interface{}{
+				"type": "object",
+				"additionalProperties": map[string]interface{}{
+					"type": "string",
+				},
+			},
+			"replicas": map[string]interface{}{
+				"type":     "integer",
+				"nullable": true,
+				"minimum":  float64(1),
+			},
+			"ratio": map[string]interface{}{
+				"type":             "number",
+				"minimum":          float64(0),
+				"maximum":          float64(1),
+				"exclusiveMaximum": true,
+			},
+			"extra": map[string]interface{}{
+				"x-kubernetes-preserve-unknown-fields": true,
+			},
+			"anything": map[string]interface{}{
+				"x-kubernetes-preserve-unknown-fields": true,
+			},
+		},
+	})
+}
+
+func (CRDSuite) TestToCRDValidationErrors(c *gc.C) {
+	for i, test := range []struct {
+		schema string
+		err    string
+	}{{
+		schema: `{type: object, properties: {a: {$ref: "#/definitions/a"}}}`,
+		err:    `cannot convert property "a" to CRD validation: references are not supported`,
+	}, {
+		schema: `{type: array, items: [{type: string}, {type: integer}]}`,
+		err:    `cannot convert schema to CRD validation: tuple items are not supported`,
+	}, {
+		schema: `{type: object, properties: {a: {type: [string, boolean]}}}`,
+		err:    `cannot convert property "a" to CRD validation: multiple types boolean, string are not supported`,
+	}, {
+		schema: `{type: object, properties: {a: {minLength: 2}}}`,
+		err:    `cannot convert property "a" to CRD validation: type must be specified`,
+	}} {
+		c.Logf("test %d: %s", i, test.schema)
+		s, err := FromYAML(strings.NewReader(test.schema))
+		c.Assert(err, jc.ErrorIsNil)
+		_, err = ToCRDValidation(s)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (CRDSuite) TestToCRDValidationJunctors(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(`
+type: object
+properties:
+  a: {type: string}
+  b: {type: integer}
+anyOf:
+- required: [a]
+- required: [b]
+allOf:
+- properties:
+    a: {minLength: 2}
+- properties:
+    b: {type: integer, minimum: 1}
+not:
+  properties:
+    a: {type: string, enum: [x]}
+`))
+	c.Assert(err, jc.ErrorIsNil)
+
+	v, err := ToCRDValidation(s)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(v, jc.DeepEquals, map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"a": map[string]interface{}{"type": "string"},
+			"b": map[string]interface{}{"type": "integer"},
+		},
+		"anyOf": []interface{}{
+			map[string]interface{}{"required": []interface{}{"a"}},
+			map[string]interface{}{"required": []interface{}{"b"}},
+		},
+		"allOf": []interface{}{
+			map[string]interface{}{"properties": map[string]interface{}{
+				"a": map[string]interface{}{"minLength": float64(2)},
+			}},
+			map[string]interface{}{"properties": map[string]interface{}{
+				"b": map[string]interface{}{"minimum": float64(1)},
+			}},
+		},
+		"not": map[string]interface{}{"properties": map[string]interface{}{
+			"a": map[string]interface{}{"enum": []interface{}{"x"}},
+		}},
+	})
+
+	s, err = FromYAML(strings.NewReader(`{type: object, properties: {a: {type: string}}, anyOf: [{properties: {a: {type: integer}}}]}`))
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = ToCRDValidation(s)
+	c.Check(err, gc.ErrorMatches, `cannot convert property "a" to CRD validation: type within allOf, anyOf, oneOf or not must match the type outside them`)
+}
@@ -0,0 +1,87 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"fmt"
+	"time"
+)
+
+// Layouts accepted for the date and date-time formats. The first layout of
+// each is the strict RFC 3339 form; the others are only accepted when
+// ValidationOptions.LenientDates is set.
+var (
+	dateLayouts = []string{
+		"2006-01-02",
+		"2006-1-2",
+	}
+	dateTimeLayouts = []string{
+		time.RFC3339Nano,
+		"2006-01-02 15:04:05.999999999Z07:00",
+		"2006-01-02T15:04Z07:00",
+		"2006-01-02 15:04Z07:00",
+	}
+)
+
+func init() {
+	RegisterFormat(FormatDate, func(value string) error {
+		_, err := parseDate(FormatDate, value, false)
+		return err
+	})
+	RegisterFormat(FormatDateTime, func(value string) error {
+		_, err := parseDate(FormatDateTime, value, false)
+		return err
+	})
+}
+
+// lookupFormat returns the checker for the given format, taking
+// opts.LenientDates into account.
+func (opts ValidationOptions) lookupFormat(format Format) (registeredFormat, bool) {
+	if opts.LenientDates && isDateFormat(format) {
+		return registeredFormat{f: func(value string) error {
+			_, err := parseDate(format, value, true)
+			return err
+		}}, true
+	}
+	return lookupFormat(format)
+}
+
+// isDateFormat reports whether format is date or date-time.
+func isDateFormat(format Format) bool {
+	return format == FormatDate || format == FormatDateTime
+}
+
+// parseDate parses value, which has the date or date-time format. Only the
+// strict RFC 3339 layout is accepted unless lenient is set.
+func parseDate(format Format, value string, lenient bool) (time.Time, error) {
+	layouts := dateTimeLayouts
+	if format == FormatDate {
+		layouts = dateLayouts
+	}
+	if !lenient {
+		layouts = layouts[:1]
+	}
+	for _, layout := range layouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	if format == FormatDate {
+		return time.Time{}, fmt.Errorf("expected a date such as 2006-01-02, got %q", value)
+	}
+	return time.Time{}, fmt.Errorf("expected a time such as 2006-01-02T15:04:05Z, got %q", value)
+}
+
+// normalizeDate returns value, which has the date or date-time format and
+// may be in any of the lenient forms, in strict RFC 3339 form.
+func normalizeDate(format Format, value string) (string, error) {
+	t, err := parseDate(format, value, true)
+	if err != nil {
+		return "", err
+	}
+	if format == FormatDate {
+		return t.Format(dateLayouts[0]), nil
+	}
+	return t.Format(time.RFC3339Nano), nil
+}
@@ -0,0 +1,94 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type DatesSuite struct{}
+
+var _ = gc.Suite(DatesSuite{})
+
+var datesSchema = &Schema{
+	Type: []Type{ObjectType},
+	Properties: map[string]*Schema{
+		"day": {
+			Type:   []Type{StringType},
+			Format: FormatDate,
+		},
+		"at": {
+			Type:   []Type{StringType},
+			Format: FormatDateTime,
+		},
+	},
+}
+
+var dateTests = []struct {
+	doc     map[string]interface{}
+	strict  string
+	lenient string
+	coerced map[string]interface{}
+}{{
+	doc: map[string]interface{}{"day": "2026-10-14", "at": "2026-10-14T09:30:00Z"},
+}, {
+	doc: map[string]interface{}{"at": "2026-10-14T09:30:00.5+02:00"},
+}, {
+	doc:     map[string]interface{}{"at": "2026-10-14 09:30:15Z"},
+	strict:  `at: expected a time such as 2006-01-02T15:04:05Z, got "2026-10-14 09:30:15Z"`,
+	coerced: map[string]interface{}{"at": "2026-10-14T09:30:15Z"},
+}, {
+	doc:     map[string]interface{}{"at": "2026-10-14 09:30+01:00"},
+	strict:  `at: expected a time such as 2006-01-02T15:04:05Z, got "2026-10-14 09:30\+01:00"`,
+	coerced: map[string]interface{}{"at": "2026-10-14T09:30:00+01:00"},
+}, {
+	doc:     map[string]interface{}{"day": "2026-1-4"},
+	strict:  `day: expected a date such as 2006-01-02, got "2026-1-4"`,
+	coerced: map[string]interface{}{"day": "2026-01-04"},
+}, {
+	doc:     map[string]interface{}{"at": "yesterday"},
+	strict:  `at: expected a time such as 2006-01-02T15:04:05Z, got "yesterday"`,
+	lenient: `at: expected a time such as 2006-01-02T15:04:05Z, got "yesterday"`,
+}}
+
+func (DatesSuite) TestValidateDates(c *gc.C) {
+	for i, test := range dateTests {
+		c.Logf("test %d: %v", i, test.doc)
+		err := datesSchema.Validate(test.doc)
+		if test.strict == "" {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, gc.ErrorMatches, test.strict)
+		}
+		err = datesSchema.ValidateWith(ValidationOptions{LenientDates: true}, test.doc)
+		if test.lenient == "" {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, gc.ErrorMatches, test.lenient)
+		}
+	}
+}
+
+func (DatesSuite) TestCoerceDates(c *gc.C) {
+	for i, test := range dateTests {
+		c.Logf("test %d: %v", i, test.doc)
+		doc := make(map[string]interface{})
+		for k, v := range test.doc {
+			doc[k] = v
+		}
+		err := datesSchema.Coerce(doc)
+		if test.lenient != "" {
+			c.Check(err, gc.ErrorMatches, test.lenient)
+			continue
+		}
+		c.Assert(err, jc.ErrorIsNil)
+		expect := test.coerced
+		if expect == nil {
+			expect = test.doc
+		}
+		c.Check(doc, jc.DeepEquals, expect)
+		c.Check(datesSchema.Validate(doc), jc.ErrorIsNil)
+	}
+}
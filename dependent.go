@@ -0,0 +1,130 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"fmt"
+	"sort"
+)
+
+// dependentSchemas returns the schemas an object described by s must also
+// satisfy when it holds each property, as given by DependentSchemas and by
+// the schema form of Dependencies, keyed by the name of the property.
+func (s *Schema) dependentSchemas() map[string][]*Schema {
+	if len(s.DependentSchemas) == 0 && len(s.Dependencies.Schemas) == 0 {
+		return nil
+	}
+	deps := make(map[string][]*Schema)
+	for _, m := range []map[string]*Schema{s.Dependencies.Schemas, s.DependentSchemas} {
+		for name, dep := range m {
+			deps[name] = append(deps[name], dep)
+		}
+	}
+	return deps
+}
+
+// dependentRequired returns the names of the properties that an object
+// described by s must hold when it holds each property, as given by
+// DependentRequired and by the name form of Dependencies, keyed by the name
+// of the property.
+func (s *Schema) dependentRequired() map[string][]string {
+	if len(s.DependentRequired) == 0 && len(s.Dependencies.Names) == 0 {
+		return nil
+	}
+	deps := make(map[string][]string)
+	seen := make(map[[2]string]bool)
+	for _, m := range []map[string][]string{s.Dependencies.Names, s.DependentRequired} {
+		for name, required := range m {
+			for _, r := range required {
+				if !seen[[2]string{name, r}] {
+					seen[[2]string{name, r}] = true
+					deps[name] = append(deps[name], r)
+				}
+			}
+		}
+	}
+	return deps
+}
+
+// missingDependents returns an error for each property that obj, found at
+// path, must hold because of the properties it holds, as described by s,
+// but doesn't. Properties found in skip aren't reported, and those reported
+// are added to it.
+func missingDependents(s *Schema, obj map[string]interface{}, path string, skip map[string]bool) ValidationErrors {
+	deps := s.dependentRequired()
+	names := make([]string, 0, len(deps))
+	for name := range deps {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var errs ValidationErrors
+	for _, name := range names {
+		if _, ok := obj[name]; !ok {
+			continue
+		}
+		for _, r := range deps[name] {
+			if _, ok := obj[r]; ok || skip[r] {
+				continue
+			}
+			skip[r] = true
+			e := &ValidationError{
+				Path:    propertyPath(path, r),
+				Keyword: "dependentRequired",
+				Err:     fmt.Errorf("property is required when %q is set", name),
+			}
+			if ps, ok := s.Properties[r]; ok {
+				e = provenanceError(ps, e)
+			}
+			errs = append(errs, e)
+		}
+	}
+	return errs
+}
+
+// sortedDependents returns the names of the properties that schemas in s
+// depend on, in sorted order.
+func sortedDependents(s *Schema) []string {
+	deps := s.dependentSchemas()
+	names := make([]string, 0, len(deps))
+	for name := range deps {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// explainDependents returns the errors found in x, found at path, by the
+// schemas that depend on the properties it holds, in the schemas composed
+// into s.
+func (e *explanation) explainDependents(s *Schema, composed []*Schema, x interface{}, path string) ValidationErrors {
+	root := e.root
+	obj, ok := asObject(x)
+	if !ok {
+		return nil
+	}
+	var errs ValidationErrors
+	for _, cs := range composed {
+		for _, name := range sortedDependents(cs) {
+			if _, ok := obj[name]; !ok {
+				continue
+			}
+			for _, dep := range cs.dependentSchemas()[name] {
+				dep = derefLocal(root, dep)
+				if validateInternal(root, withAlternative(root, s, dep), x) == nil {
+					continue
+				}
+				depErrs := e.explainSchemas(composedSchemas(root, dep, false), x, path)
+				if len(depErrs) == 0 {
+					depErrs = ValidationErrors{{
+						Path:    path,
+						Keyword: "dependentSchemas",
+						Err:     fmt.Errorf("does not match the schema required by %q", name),
+					}}
+				}
+				errs = append(errs, depErrs...)
+			}
+		}
+	}
+	return errs
+}
@@ -0,0 +1,147 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type DependentSuite struct{}
+
+var _ = gc.Suite(DependentSuite{})
+
+// dependentSchema requires a billing address and a valid expiry date when a
+// credit card is given, using both dependentSchemas and the schema form of
+// dependencies.
+const dependentSchema = `
+type: object
+properties:
+  name:
+    type: string
+  credit-card:
+    type: integer
+dependentSchemas:
+  credit-card:
+    required: [billing-address]
+    properties:
+      billing-address:
+        type: string
+dependencies:
+  credit-card:
+    properties:
+      expires:
+        type: string
+        format: date
+`
+
+func (DependentSuite) TestDependentSchemas(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(dependentSchema))
+	c.Assert(err, jc.ErrorIsNil)
+	v, err := NewValidator(s)
+	c.Assert(err, jc.ErrorIsNil)
+	for _, validate := range []func(interface{}) error{s.Validate, v.Validate} {
+		for _, doc := range []map[string]interface{}{
+			{"name": "juju"},
+			{"name": "juju", "credit-card": 5555, "billing-address": "1 Road"},
+			{"credit-card": 5555, "billing-address": "1 Road", "expires": "2030-01-01"},
+		} {
+			c.Check(validate(doc), jc.ErrorIsNil, gc.Commentf("%v", doc))
+		}
+
+		err := validate(map[string]interface{}{"credit-card": 5555})
+		c.Check(err, gc.ErrorMatches, `billing-address: property is required`)
+		c.Check(errors.Is(err, ErrRequired), jc.IsTrue)
+
+		// The keywords implemented by this package apply within the
+		// dependent schemas too.
+		err = validate(map[string]interface{}{"credit-card": 5555, "billing-address": "1 Road", "expires": "soon"})
+		c.Check(err, gc.ErrorMatches, `expires: .*`)
+
+		c.Check(validate(map[string]interface{}{"name": "juju", "token": "t"}), gc.NotNil)
+		c.Check(validate(map[string]interface{}{"credit-card": 5555, "billing-address": 3}), gc.NotNil)
+	}
+}
+
+func (DependentSuite) TestDependentSchemasRoundTrip(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(dependentSchema))
+	c.Assert(err, jc.ErrorIsNil)
+	data, err := json.Marshal(s)
+	c.Assert(err, jc.ErrorIsNil)
+	var out Schema
+	c.Assert(json.Unmarshal(data, &out), jc.ErrorIsNil)
+	c.Assert(out.DependentSchemas["credit-card"], gc.NotNil)
+	c.Check(out.DependentSchemas["credit-card"].Required, jc.DeepEquals, []string{"billing-address"})
+	c.Assert(out.Dependencies.Schemas["credit-card"], gc.NotNil)
+	c.Check(out.Dependencies.Schemas["credit-card"].Properties["expires"].Format, gc.Equals, FormatDate)
+	c.Check(out.Unknown, gc.HasLen, 0)
+}
+
+// dependentRequiredSchema requires a proxy port with a proxy host, using
+// dependentRequired, and a proxy host with proxy credentials, using the name
+// form of dependencies.
+const dependentRequiredSchema = `
+type: object
+properties:
+  proxy-host:
+    type: string
+  proxy-port:
+    type: integer
+  proxy-user:
+    type: string
+  proxy-password:
+    type: string
+dependentRequired:
+  proxy-host: [proxy-port]
+dependencies:
+  proxy-user: [proxy-host, proxy-password]
+`
+
+func (DependentSuite) TestDependentRequired(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(dependentRequiredSchema))
+	c.Assert(err, jc.ErrorIsNil)
+	v, err := NewValidator(s)
+	c.Assert(err, jc.ErrorIsNil)
+	for _, validate := range []func(interface{}) error{s.Validate, v.Validate} {
+		for _, doc := range []map[string]interface{}{
+			{},
+			{"proxy-port": 3128},
+			{"proxy-host": "squid", "proxy-port": 3128},
+			{"proxy-host": "squid", "proxy-port": 3128, "proxy-user": "juju", "proxy-password": "s3cret"},
+		} {
+			c.Check(validate(doc), jc.ErrorIsNil, gc.Commentf("%v", doc))
+		}
+
+		err := validate(map[string]interface{}{"proxy-host": "squid"})
+		c.Check(err, gc.ErrorMatches, `proxy-port: property is required when "proxy-host" is set`)
+		c.Check(errors.Is(err, ErrRequired), jc.IsTrue)
+		var verr *ValidationError
+		c.Assert(errors.As(err, &verr), jc.IsTrue)
+		c.Check(verr.Keyword, gc.Equals, "dependentRequired")
+
+		err = validate(map[string]interface{}{"proxy-user": "juju"})
+		c.Check(err, gc.ErrorMatches, `proxy-host: property is required when "proxy-user" is set; `+
+			`proxy-password: property is required when "proxy-user" is set`)
+
+		// Other problems are reported alongside.
+		err = validate(map[string]interface{}{"proxy-host": "squid", "proxy-user": 3, "proxy-password": "p"})
+		c.Check(err, gc.ErrorMatches, `proxy-port: property is required when "proxy-host" is set; proxy-user: .*`)
+	}
+}
+
+func (DependentSuite) TestDependentRequiredRoundTrip(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(dependentRequiredSchema))
+	c.Assert(err, jc.ErrorIsNil)
+	data, err := json.Marshal(s)
+	c.Assert(err, jc.ErrorIsNil)
+	var out Schema
+	c.Assert(json.Unmarshal(data, &out), jc.ErrorIsNil)
+	c.Check(out.DependentRequired, jc.DeepEquals, map[string][]string{"proxy-host": {"proxy-port"}})
+	c.Check(out.Dependencies.Names, jc.DeepEquals, map[string][]string{"proxy-user": {"proxy-host", "proxy-password"}})
+	c.Check(out.Unknown, gc.HasLen, 0)
+}
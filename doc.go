@@ -0,0 +1,199 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+)
+
+// Doc holds a document bound to the schema that describes it, for callers
+// which would rather not handle the map[string]interface{} form of the
+// document directly. Values are read and written by dotted paths, such as
+// "network.mtu" or "nodes[0].port", as for ResetToDefault.
+//
+// A Doc is copy on write: the map it is created from, and any it shares
+// with its copies, are never modified; the first change made to a Doc
+// makes it a private copy of the document instead. A Doc also records the
+// paths that have been set since it was created or last marked clean, so
+// that callers can tell whether it needs to be saved.
+//
+// A Doc is not safe for concurrent use.
+type Doc struct {
+	schema *Schema
+	data   map[string]interface{}
+
+	// owned records whether data is private to this Doc, and so may be
+	// modified.
+	owned bool
+
+	// dirty holds the paths which have been set.
+	dirty map[string]bool
+}
+
+// NewDoc returns a Doc holding data, described by s. Data is not copied
+// unless the Doc is changed, and is never modified.
+func NewDoc(s *Schema, data map[string]interface{}) *Doc {
+	if data == nil {
+		data = make(map[string]interface{})
+	}
+	return &Doc{schema: s, data: data, dirty: make(map[string]bool)}
+}
+
+// Schema returns the schema that describes d.
+func (d *Doc) Schema() *Schema {
+	return d.schema
+}
+
+// Map returns the document held by d. It must not be modified; use Set, or
+// a copy made by Copy, instead.
+func (d *Doc) Map() map[string]interface{} {
+	return d.data
+}
+
+// Copy returns a copy of d, which shares its document with d until either
+// of them is changed. The copy is clean.
+func (d *Doc) Copy() *Doc {
+	d.owned = false
+	return &Doc{schema: d.schema, data: d.data, dirty: make(map[string]bool)}
+}
+
+// Get returns the value at path in d, or the default that the schema gives
+// it if it isn't set. It reports whether a value was found.
+func (d *Doc) Get(path string) (interface{}, bool) {
+	v, _, ok, err := d.schema.lookupPath(d.data, path)
+	return v, ok && err == nil
+}
+
+// String returns the string at path in d, as for Schema.GetString.
+func (d *Doc) String(path string) (string, error) {
+	return d.schema.GetString(d.data, path)
+}
+
+// Int returns the integer at path in d, as for Schema.GetInt.
+func (d *Doc) Int(path string) (int, error) {
+	return d.schema.GetInt(d.data, path)
+}
+
+// Float returns the number at path in d, as for Schema.GetFloat.
+func (d *Doc) Float(path string) (float64, error) {
+	return d.schema.GetFloat(d.data, path)
+}
+
+// Bool returns the boolean at path in d, as for Schema.GetBool.
+func (d *Doc) Bool(path string) (bool, error) {
+	return d.schema.GetBool(d.data, path)
+}
+
+// Set sets the value at path in d, creating any objects on the way to it
+// that aren't set, and records path as dirty. The value is not checked
+// against the schema; see Validate. An error is returned if path is
+// invalid, or if it passes through a value that isn't an object or an
+// array item that doesn't exist.
+func (d *Doc) Set(path string, v interface{}) (err error) {
+	defer recoverPanic(&err)
+	elems, ok := parseFlatKey(path)
+	if !ok {
+		return fmt.Errorf("cannot set %q: invalid path", path)
+	}
+	d.own()
+	var container interface{} = d.data
+	for i, m := range elems {
+		last := i == len(elems)-1
+		if m[1] != "" {
+			arr, ok := container.([]interface{})
+			index, _ := strconv.Atoi(m[1])
+			if !ok || index >= len(arr) {
+				return fmt.Errorf("cannot set %q: no value at %s", path, flatPrefix(elems[:i+1]))
+			}
+			if last {
+				arr[index] = v
+				break
+			}
+			container = arr[index]
+			continue
+		}
+		obj, ok := container.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("cannot set %q: %s is not an object", path, flatPrefix(elems[:i]))
+		}
+		if last {
+			obj[m[2]] = v
+			break
+		}
+		next, ok := obj[m[2]]
+		if !ok || next == nil {
+			next = make(map[string]interface{})
+			obj[m[2]] = next
+		}
+		container = next
+	}
+	d.dirty[path] = true
+	return nil
+}
+
+// Unset removes the value at path in d, as for Schema.Unset, and records
+// path as dirty. It returns the default that applies in its place, if any.
+func (d *Doc) Unset(path string) (interface{}, error) {
+	d.own()
+	v, err := d.schema.Unset(d.data, path)
+	if err != nil {
+		return nil, err
+	}
+	d.dirty[path] = true
+	return v, nil
+}
+
+// Dirty returns the paths that have been set in d since it was created or
+// last marked clean, in sorted order.
+func (d *Doc) Dirty() []string {
+	paths := make([]string, 0, len(d.dirty))
+	for path := range d.dirty {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// IsDirty reports whether any path has been set in d since it was created
+// or last marked clean.
+func (d *Doc) IsDirty() bool {
+	return len(d.dirty) > 0
+}
+
+// MarkClean forgets the paths that have been set in d, for example once it
+// has been saved.
+func (d *Doc) MarkClean() {
+	d.dirty = make(map[string]bool)
+}
+
+// Validate validates d against its schema, as for Schema.Validate. The
+// defaults that validation inserts are not kept; see Defaults.
+func (d *Doc) Validate() error {
+	return d.ValidateWith(ValidationOptions{})
+}
+
+// ValidateWith validates d against its schema, as for
+// Schema.ValidateWith.
+func (d *Doc) ValidateWith(opts ValidationOptions) error {
+	return d.schema.ValidateWith(opts, copyValue(d.data))
+}
+
+// Defaults inserts the defaults given by the schema into d, as for
+// Schema.InsertDefaults. Inserting defaults doesn't change the meaning of
+// the document, so it doesn't make d dirty.
+func (d *Doc) Defaults() {
+	d.own()
+	d.schema.InsertDefaults(d.data)
+}
+
+// own makes the document held by d private to it, so that it may be
+// modified.
+func (d *Doc) own() {
+	if !d.owned {
+		d.data = copyValue(d.data).(map[string]interface{})
+		d.owned = true
+	}
+}
@@ -0,0 +1,149 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type DocSuite struct{}
+
+var _ = gc.Suite(DocSuite{})
+
+const docSchema = `
+type: object
+properties:
+  payload:
+    type: string
+  enabled:
+    type: boolean
+    default: true
+  network:
+    type: object
+    properties:
+      mtu:
+        type: integer
+        default: 1500
+      name:
+        type: string
+  nodes:
+    type: array
+    items:
+      type: object
+      properties:
+        port:
+          type: integer
+`
+
+func newDocSchema(c *gc.C) *Schema {
+	s, err := FromYAML(strings.NewReader(docSchema))
+	c.Assert(err, jc.ErrorIsNil)
+	return s
+}
+
+func (DocSuite) TestGetters(c *gc.C) {
+	doc := NewDoc(newDocSchema(c), map[string]interface{}{
+		"payload": "hello",
+		"network": map[string]interface{}{"name": "lan"},
+		"nodes":   []interface{}{map[string]interface{}{"port": float64(22)}},
+	})
+	str, err := doc.String("payload")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(str, gc.Equals, "hello")
+
+	str, err = doc.String("network.name")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(str, gc.Equals, "lan")
+
+	port, err := doc.Int("nodes[0].port")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(port, gc.Equals, 22)
+
+	// Values that aren't set are given their defaults.
+	mtu, err := doc.Int("network.mtu")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(mtu, gc.Equals, 1500)
+	enabled, err := doc.Bool("enabled")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(enabled, jc.IsTrue)
+
+	_, err = doc.String("enabled")
+	c.Check(err, gc.ErrorMatches, `enabled: got boolean, want string`)
+	_, err = doc.Int("payload")
+	c.Check(err, gc.ErrorMatches, `payload: got string, want number`)
+	_, err = doc.String("nodes[1].port")
+	c.Check(err, gc.ErrorMatches, `nodes\[1\].port: no value`)
+	_, err = doc.String("nodes..port")
+	c.Check(err, gc.ErrorMatches, `nodes..port: invalid path`)
+	_, ok := doc.Get("missing")
+	c.Check(ok, jc.IsFalse)
+}
+
+func (DocSuite) TestSetCopyOnWrite(c *gc.C) {
+	original := map[string]interface{}{
+		"payload": "hello",
+		"nodes":   []interface{}{map[string]interface{}{"port": float64(22)}},
+	}
+	doc := NewDoc(newDocSchema(c), original)
+	c.Check(doc.IsDirty(), jc.IsFalse)
+	copied := doc.Copy()
+
+	c.Assert(doc.Set("payload", "goodbye"), jc.ErrorIsNil)
+	c.Assert(doc.Set("network.mtu", 9000), jc.ErrorIsNil)
+	c.Assert(doc.Set("nodes[0].port", 2222), jc.ErrorIsNil)
+	c.Check(doc.Map(), jc.DeepEquals, map[string]interface{}{
+		"payload": "goodbye",
+		"network": map[string]interface{}{"mtu": 9000},
+		"nodes":   []interface{}{map[string]interface{}{"port": 2222}},
+	})
+	c.Check(doc.Dirty(), jc.DeepEquals, []string{"network.mtu", "nodes[0].port", "payload"})
+
+	// Neither the original map nor the copy is changed.
+	c.Check(original, jc.DeepEquals, map[string]interface{}{
+		"payload": "hello",
+		"nodes":   []interface{}{map[string]interface{}{"port": float64(22)}},
+	})
+	c.Check(copied.Map(), jc.DeepEquals, original)
+	c.Check(copied.IsDirty(), jc.IsFalse)
+
+	doc.MarkClean()
+	c.Check(doc.IsDirty(), jc.IsFalse)
+
+	def, err := doc.Unset("network.mtu")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(def, gc.Equals, float64(1500))
+	c.Check(doc.Map()["network"], jc.DeepEquals, map[string]interface{}{})
+	c.Check(doc.Dirty(), jc.DeepEquals, []string{"network.mtu"})
+	c.Check(copied.Map(), jc.DeepEquals, original)
+	doc.MarkClean()
+
+	err = doc.Set("payload.length", 3)
+	c.Check(err, gc.ErrorMatches, `cannot set "payload.length": payload is not an object`)
+	err = doc.Set("nodes[3].port", 3)
+	c.Check(err, gc.ErrorMatches, `cannot set "nodes\[3\].port": no value at nodes\[3\]`)
+	c.Check(doc.IsDirty(), jc.IsFalse)
+}
+
+func (DocSuite) TestValidateAndDefaults(c *gc.C) {
+	original := map[string]interface{}{"network": map[string]interface{}{}}
+	doc := NewDoc(newDocSchema(c), original)
+	c.Assert(doc.Validate(), jc.ErrorIsNil)
+	// Validation doesn't insert defaults.
+	c.Check(doc.Map(), jc.DeepEquals, original)
+
+	c.Assert(doc.Set("network.mtu", "big"), jc.ErrorIsNil)
+	c.Check(doc.Validate(), gc.ErrorMatches, `network.mtu: .*`)
+
+	doc = NewDoc(newDocSchema(c), original)
+	doc.Defaults()
+	c.Check(doc.Map(), jc.DeepEquals, map[string]interface{}{
+		"enabled": true,
+		"network": map[string]interface{}{"mtu": float64(1500)},
+	})
+	c.Check(doc.IsDirty(), jc.IsFalse)
+	c.Check(original, jc.DeepEquals, map[string]interface{}{"network": map[string]interface{}{}})
+}
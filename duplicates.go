@@ -0,0 +1,127 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+)
+
+// DecodeOptions holds options for decoding json schemas and documents.
+type DecodeOptions struct {
+	// DisallowDuplicateKeys causes an error to be returned if an object
+	// holds the same key more than once. Otherwise the last value for the
+	// key is used, as by encoding/json, and the others are silently lost.
+	DisallowDuplicateKeys bool
+
+	// Loader, if set, is used to load the schemas referred to by any
+	// external references, such as "common.json#/definitions/port", in a
+	// decoded schema. When validating a document, it is used as described
+	// by ValidationOptions.Loader.
+	Loader Loader
+}
+
+// FromJSONWithOptions returns a schema created from the json value in r, in
+// the same way as FromJSON, decoding it as specified by opts.
+func FromJSONWithOptions(r io.Reader, opts DecodeOptions) (*Schema, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	if opts.DisallowDuplicateKeys {
+		if err := checkDuplicateKeys(data); err != nil {
+			return nil, err
+		}
+	}
+	s, err := FromJSON(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	return s, resolveExternalRefs(s, opts.Loader)
+}
+
+// FromYAMLWithOptions returns a schema created from the yaml value in r, in
+// the same way as FromYAML, decoding it as specified by opts.
+func FromYAMLWithOptions(r io.Reader, opts DecodeOptions) (*Schema, error) {
+	s, err := FromYAML(r)
+	if err != nil {
+		return nil, err
+	}
+	return s, resolveExternalRefs(s, opts.Loader)
+}
+
+// ValidateBytes decodes the json document in data as specified by opts,
+// validates it against s, and returns it.
+func (s *Schema) ValidateBytes(data []byte, opts DecodeOptions) (interface{}, error) {
+	if opts.DisallowDuplicateKeys {
+		if err := checkDuplicateKeys(data); err != nil {
+			return nil, err
+		}
+	}
+	var x interface{}
+	if err := json.Unmarshal(data, &x); err != nil {
+		return nil, err
+	}
+	if err := s.ValidateWith(ValidationOptions{Loader: opts.Loader}, x); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// checkDuplicateKeys returns an error if any object in the json value in
+// data holds the same key more than once.
+func checkDuplicateKeys(data []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if err := checkDuplicateValue(dec, ""); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return err
+	}
+	return nil
+}
+
+// checkDuplicateValue checks the next value read from dec, which is found
+// at path, for duplicate keys.
+func checkDuplicateValue(dec *json.Decoder, path string) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	switch tok {
+	case json.Delim('{'):
+		seen := make(map[string]bool)
+		for dec.More() {
+			tok, err := dec.Token()
+			if err != nil {
+				return err
+			}
+			key := tok.(string)
+			if seen[key] {
+				if path == "" {
+					return fmt.Errorf("duplicate key %q", key)
+				}
+				return fmt.Errorf("%s: duplicate key %q", path, key)
+			}
+			seen[key] = true
+			if err := checkDuplicateValue(dec, propertyPath(path, key)); err != nil {
+				return err
+			}
+		}
+		_, err := dec.Token()
+		return err
+	case json.Delim('['):
+		for i := 0; dec.More(); i++ {
+			if err := checkDuplicateValue(dec, itemPath(path, i)); err != nil {
+				return err
+			}
+		}
+		_, err := dec.Token()
+		return err
+	}
+	return nil
+}
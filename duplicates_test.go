@@ -0,0 +1,74 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type DuplicatesSuite struct{}
+
+var _ = gc.Suite(DuplicatesSuite{})
+
+var strictDecoding = DecodeOptions{DisallowDuplicateKeys: true}
+
+func (DuplicatesSuite) TestFromJSONDuplicateKeys(c *gc.C) {
+	const schema = `{"type": "object", "properties": {"name": {"type": "string", "type": "integer"}}}`
+	s, err := FromJSONWithOptions(strings.NewReader(schema), DecodeOptions{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.Properties["name"].Type, jc.DeepEquals, []Type{IntegerType})
+
+	_, err = FromJSONWithOptions(strings.NewReader(schema), strictDecoding)
+	c.Check(err, gc.ErrorMatches, `properties.name: duplicate key "type"`)
+
+	_, err = FromJSONWithOptions(strings.NewReader(`{"type": "object", "type": "string"}`), strictDecoding)
+	c.Check(err, gc.ErrorMatches, `duplicate key "type"`)
+
+	s, err = FromJSONWithOptions(strings.NewReader(`{"type": "string"}`), strictDecoding)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.Type, jc.DeepEquals, []Type{StringType})
+}
+
+func (DuplicatesSuite) TestFromYAMLDuplicateKeys(c *gc.C) {
+	_, err := FromYAML(strings.NewReader("type: object\ntype: string\n"))
+	c.Check(err, gc.ErrorMatches, `(?s).*mapping key "type" already defined.*`)
+}
+
+func (DuplicatesSuite) TestValidateBytes(c *gc.C) {
+	s := &Schema{
+		Type: []Type{ObjectType},
+		Properties: map[string]*Schema{
+			"nodes": {
+				Type: []Type{ArrayType},
+				Items: &ItemSpec{Schemas: []*Schema{{
+					Type: []Type{ObjectType},
+					Properties: map[string]*Schema{
+						"port": {Type: []Type{NumberType}},
+					},
+				}}},
+			},
+		},
+	}
+	doc := []byte(`{"nodes": [{"port": 22}, {"port": 80, "port": 8080}]}`)
+	x, err := s.ValidateBytes(doc, DecodeOptions{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(x, jc.DeepEquals, map[string]interface{}{
+		"nodes": []interface{}{
+			map[string]interface{}{"port": float64(22)},
+			map[string]interface{}{"port": float64(8080)},
+		},
+	})
+
+	_, err = s.ValidateBytes(doc, strictDecoding)
+	c.Check(err, gc.ErrorMatches, `nodes\[1\]: duplicate key "port"`)
+
+	_, err = s.ValidateBytes([]byte(`{"nodes": [{"port": "x"}]}`), strictDecoding)
+	c.Check(err, gc.ErrorMatches, `nodes\[0\].port: .*`)
+
+	_, err = s.ValidateBytes([]byte(`{"nodes": [`), strictDecoding)
+	c.Check(err, gc.ErrorMatches, `unexpected end of JSON input`)
+}
@@ -0,0 +1,34 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+// dynamicTarget returns the schema that the dynamic reference s refers to
+// when validating against root, given the schema target that it refers to
+// statically.
+//
+// The dynamic scope of a reference is the chain of resources that
+// validation passed through to reach it. The outermost resource with a
+// matching anchor wins, and that is always root's when it has one, so only
+// root is consulted: a reference from a schema that root refers to is
+// redirected to root, and is otherwise followed statically.
+func dynamicTarget(root, s, target *Schema) *Schema {
+	switch {
+	case s.DynamicRef != "":
+		name := s.DynamicRef[len(stripFragment(s.DynamicRef)):]
+		if len(name) < 2 || name[1] == '/' || target.DynamicAnchor != name[1:] {
+			return target
+		}
+		outer := findInResource(root, func(sub *Schema) bool {
+			return sub.DynamicAnchor == name[1:]
+		})
+		if outer != nil {
+			return outer
+		}
+	case s.RecursiveRef != "":
+		if target.RecursiveAnchor && root.RecursiveAnchor {
+			return root
+		}
+	}
+	return target
+}
@@ -0,0 +1,119 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type DynamicSuite struct{}
+
+var _ = gc.Suite(DynamicSuite{})
+
+// treeSchema describes a generic tree, which planSchema extends by
+// requiring the data held by every node to be a string.
+const treeSchema = `{
+	"$id": "https://schemas.example.com/tree.json",
+	"$dynamicAnchor": "node",
+	"type": "object",
+	"properties": {
+		"data": {"type": ["string", "integer"]},
+		"children": {
+			"type": "array",
+			"items": {"$dynamicRef": "#node"}
+		}
+	}
+}`
+
+const planSchema = `{
+	"$id": "https://schemas.example.com/plan.json",
+	"$dynamicAnchor": "node",
+	"allOf": [{"$ref": "tree.json"}],
+	"properties": {
+		"data": {"type": "string"},
+		"children": {"type": "array"}
+	}
+}`
+
+// planDoc holds a tree with a node nested two levels deep which holds a
+// number rather than a string.
+var planDoc = map[string]interface{}{
+	"data": "deploy",
+	"children": []interface{}{
+		map[string]interface{}{
+			"data": "database",
+			"children": []interface{}{
+				map[string]interface{}{"data": 3},
+			},
+		},
+	},
+}
+
+func treeLoader() Loader {
+	return LoaderFunc(func(uri string) (*Schema, error) {
+		return FromJSON(strings.NewReader(treeSchema))
+	})
+}
+
+func (DynamicSuite) TestDynamicRef(c *gc.C) {
+	tree, err := FromJSON(strings.NewReader(treeSchema))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(tree.Validate(planDoc), jc.ErrorIsNil)
+
+	plan, err := FromJSONWithOptions(strings.NewReader(planSchema), DecodeOptions{Loader: treeLoader()})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(plan.Validate(planDoc), gc.ErrorMatches, `children\[0\]\.children\[0\]\.data: .*`)
+	v, err := NewValidator(plan)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(v.Validate(planDoc), gc.ErrorMatches, `children\[0\]\.children\[0\]\.data: .*`)
+	c.Check(plan.Validate(map[string]interface{}{
+		"data":     "deploy",
+		"children": []interface{}{map[string]interface{}{"data": "database"}},
+	}), jc.ErrorIsNil)
+}
+
+func (DynamicSuite) TestDynamicRefWithoutAnchor(c *gc.C) {
+	// Without a dynamic anchor of its own, a schema extending the tree
+	// only applies to its root node.
+	plan, err := FromJSONWithOptions(strings.NewReader(strings.Replace(planSchema, `"$dynamicAnchor"`, `"$anchor"`, 1)), DecodeOptions{Loader: treeLoader()})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(plan.Validate(planDoc), jc.ErrorIsNil)
+	c.Check(plan.Validate(map[string]interface{}{"data": 3}), gc.ErrorMatches, `data: .*`)
+}
+
+func (DynamicSuite) TestRecursiveRef(c *gc.C) {
+	s, err := FromJSON(strings.NewReader(`{
+		"$recursiveAnchor": true,
+		"allOf": [{"$ref": "#/$defs/tree"}],
+		"properties": {
+			"data": {"type": "string"},
+			"children": {"type": "array"}
+		},
+		"$defs": {
+			"tree": {
+				"$id": "https://schemas.example.com/tree.json",
+				"$recursiveAnchor": true,
+				"type": "object",
+				"properties": {
+					"data": {"type": ["string", "integer"]},
+					"children": {
+						"type": "array",
+						"items": {"$recursiveRef": "#"}
+					}
+				}
+			}
+		}
+	}`))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.Defs["tree"].Properties["children"].Items.Schemas[0].RecursiveRef, gc.Equals, "#")
+	c.Check(s.Validate(planDoc), gc.ErrorMatches, `children\[0\]\.children\[0\]\.data: .*`)
+	c.Check(s.Defs["tree"].Validate(planDoc), jc.ErrorIsNil)
+
+	data, err := s.MarshalJSON()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(data), jc.Contains, `"$recursiveRef":"#"`)
+}
@@ -0,0 +1,73 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import "reflect"
+
+// EnumOption describes one of the values allowed by the enum of a schema,
+// for presenting as a choice to users.
+type EnumOption struct {
+	// Value holds the value, as it is stored in the document.
+	Value interface{}
+
+	// Label holds the name to show for the value: its entry in
+	// EnumLabels if it has one, and otherwise the value as it would be
+	// typed.
+	Label string
+
+	// Description holds the value's entry in EnumDescriptions, if any.
+	Description string
+}
+
+// EnumOptions returns the values allowed by the enum of s, in order, with
+// their labels and descriptions. It returns nil if s has no enum.
+func (s *Schema) EnumOptions() []EnumOption {
+	if len(s.Enum) == 0 {
+		return nil
+	}
+	options := make([]EnumOption, len(s.Enum))
+	for i, v := range s.Enum {
+		options[i] = EnumOption{Value: v, Label: exampleString(v)}
+		if i < len(s.EnumLabels) && s.EnumLabels[i] != "" {
+			options[i].Label = s.EnumLabels[i]
+		}
+		if i < len(s.EnumDescriptions) {
+			options[i].Description = s.EnumDescriptions[i]
+		}
+	}
+	return options
+}
+
+// EnumLabel returns the label for v, one of the values allowed by the enum
+// of s, as given by EnumOptions. A value not in the enum is labelled with
+// the value itself, as it would be typed.
+func (s *Schema) EnumLabel(v interface{}) string {
+	if option, ok := s.enumOption(v); ok {
+		return option.Label
+	}
+	return exampleString(v)
+}
+
+// EnumValue returns the value in the enum of s with the given label, as
+// given by EnumOptions, so that a choice made by the user can be stored. It
+// reports whether one was found.
+func (s *Schema) EnumValue(label string) (interface{}, bool) {
+	for _, option := range s.EnumOptions() {
+		if option.Label == label {
+			return option.Value, true
+		}
+	}
+	return nil, false
+}
+
+// enumOption returns the option in the enum of s for v.
+func (s *Schema) enumOption(v interface{}) (EnumOption, bool) {
+	v = normalizeValue(v)
+	for _, option := range s.EnumOptions() {
+		if reflect.DeepEqual(normalizeValue(option.Value), v) {
+			return option, true
+		}
+	}
+	return EnumOption{}, false
+}
@@ -0,0 +1,78 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type EnumSuite struct{}
+
+var _ = gc.Suite(EnumSuite{})
+
+const enumSchema = `
+type: object
+properties:
+  provider:
+    type: string
+    description: The cloud provider.
+    enum: [aws, gce, lxd]
+    enum-labels: [Amazon Web Services, Google Compute Engine]
+    enum-descriptions: [Amazon's public cloud]
+  replicas:
+    type: integer
+    enum: [1, 3]
+`
+
+func (EnumSuite) TestEnumOptions(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(enumSchema))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.Properties["provider"].EnumOptions(), jc.DeepEquals, []EnumOption{
+		{Value: "aws", Label: "Amazon Web Services", Description: "Amazon's public cloud"},
+		{Value: "gce", Label: "Google Compute Engine"},
+		{Value: "lxd", Label: "lxd"},
+	})
+	c.Check(s.Properties["replicas"].EnumOptions(), jc.DeepEquals, []EnumOption{
+		{Value: float64(1), Label: "1"},
+		{Value: float64(3), Label: "3"},
+	})
+	c.Check(s.EnumOptions(), gc.IsNil)
+}
+
+func (EnumSuite) TestEnumLabel(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(enumSchema))
+	c.Assert(err, jc.ErrorIsNil)
+	provider := s.Properties["provider"]
+	c.Check(provider.EnumLabel("aws"), gc.Equals, "Amazon Web Services")
+	c.Check(provider.EnumLabel("lxd"), gc.Equals, "lxd")
+	c.Check(provider.EnumLabel("azure"), gc.Equals, "azure")
+	c.Check(s.Properties["replicas"].EnumLabel(3), gc.Equals, "3")
+
+	v, ok := provider.EnumValue("Google Compute Engine")
+	c.Check(ok, jc.IsTrue)
+	c.Check(v, gc.Equals, "gce")
+	_, ok = provider.EnumValue("gce")
+	c.Check(ok, jc.IsFalse)
+}
+
+func (EnumSuite) TestEnumLabelsInMarkdown(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(enumSchema))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(ToMarkdown(s), gc.Equals, "| Property | Type | Required | Default | Description | Examples |\n"+
+		"|---|---|---|---|---|---|\n"+
+		"| `provider` | string | no |  | The cloud provider. One of `aws` (Amazon Web Services), `gce` (Google Compute Engine), `lxd`. |  |\n"+
+		"| `replicas` | integer | no |  | One of `1`, `3`. |  |\n")
+}
+
+func (EnumSuite) TestStripEnumLabels(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(enumSchema))
+	c.Assert(err, jc.ErrorIsNil)
+	stripped := StripAnnotations(s).Properties["provider"]
+	c.Check(stripped.EnumLabels, gc.IsNil)
+	c.Check(stripped.EnumDescriptions, gc.IsNil)
+	c.Check(stripped.Enum, gc.HasLen, 3)
+}
@@ -0,0 +1,437 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Errors matched by errors.Is for validation errors in the corresponding
+// category, whether on their own or within ValidationErrors.
+var (
+	// ErrRequired is matched by errors for missing required properties.
+	ErrRequired = errors.New("property is required")
+
+	// ErrFormat is matched by errors for strings which don't conform to
+	// their format.
+	ErrFormat = errors.New("invalid format")
+
+	// ErrValidator is matched by errors returned by custom validators. See
+	// RegisterValidator.
+	ErrValidator = errors.New("rejected by validator")
+
+	// ErrForbidden is matched by errors for properties that may not be
+	// set, because of the feature-flag or access keywords.
+	ErrForbidden = errors.New("property may not be set")
+)
+
+// keywordErrors maps keywords to the category of the errors they produce.
+var keywordErrors = map[string]error{
+	"required":          ErrRequired,
+	"dependentRequired": ErrRequired,
+	"format":            ErrFormat,
+	"validators":        ErrValidator,
+	"feature-flag":      ErrForbidden,
+	"access":            ErrForbidden,
+}
+
+// ValidationError describes a value in a document which failed validation.
+type ValidationError struct {
+	// Path holds the path of the value within the document, such as
+	// "nodes[0].address", or is empty for the document itself.
+	Path string
+
+	// Keyword holds the name of the schema keyword which rejected the
+	// value, where known.
+	Keyword string
+
+	// Source holds the provenance of the property which rejected the
+	// value, where known. See Schema.Provenance.
+	Source string
+
+	// Err describes the problem with the value.
+	Err error
+}
+
+// Error implements the error interface.
+func (e *ValidationError) Error() string {
+	msg := e.Err.Error()
+	if e.Path != "" {
+		msg = e.Path + ": " + msg
+	}
+	if e.Source != "" {
+		msg = "rejected by " + e.Source + ": " + msg
+	}
+	return msg
+}
+
+// Unwrap returns the underlying error.
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is the category of e, such as ErrRequired.
+func (e *ValidationError) Is(target error) bool {
+	category, ok := keywordErrors[e.Keyword]
+	return ok && category == target
+}
+
+// ValidationErrors holds the errors found when a document fails validation
+// in more than one place. They are ordered by the position of the values in
+// the schema, as described by Sort.
+type ValidationErrors []*ValidationError
+
+// Error implements the error interface.
+func (errs ValidationErrors) Error() string {
+	msgs := make([]string, len(errs))
+	for i, err := range errs {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Unwrap returns the individual errors, so that errors.Is and errors.As
+// consider each of them.
+func (errs ValidationErrors) Unwrap() []error {
+	unwrapped := make([]error, len(errs))
+	for i, err := range errs {
+		unwrapped[i] = err
+	}
+	return unwrapped
+}
+
+// Sort sorts errs into a stable order: by the order in which s declares the
+// properties on the path to each value (see Schema.Order), then by array
+// index, then by path, so that the same document always produces the same
+// errors in the same order.
+func (errs ValidationErrors) Sort(s *Schema) {
+	keys := make(map[*ValidationError][]pathRank, len(errs))
+	for _, err := range errs {
+		keys[err] = rankPath(s, err.Path)
+	}
+	sort.SliceStable(errs, func(i, j int) bool {
+		ki, kj := keys[errs[i]], keys[errs[j]]
+		for n := 0; n < len(ki) && n < len(kj); n++ {
+			if ki[n] != kj[n] {
+				return ki[n].less(kj[n])
+			}
+		}
+		if len(ki) != len(kj) {
+			return len(ki) < len(kj)
+		}
+		return errs[i].Path < errs[j].Path
+	})
+}
+
+// err returns errs as an error: nil if there are none, the only error if
+// there is one, and errs itself otherwise.
+func (errs ValidationErrors) err() error {
+	switch len(errs) {
+	case 0:
+		return nil
+	case 1:
+		return errs[0]
+	}
+	return errs
+}
+
+// validationError returns a ValidationError for err, which was caused by the
+// value found at path being rejected by the given keyword.
+func validationError(path, keyword string, err error) error {
+	return &ValidationError{Path: path, Keyword: keyword, Err: err}
+}
+
+// pathRank holds the position of one element of a path: the position of a
+// property in its schema's order, followed by its name, or an array index.
+type pathRank struct {
+	pos  int
+	name string
+}
+
+func (r pathRank) less(other pathRank) bool {
+	if r.pos != other.pos {
+		return r.pos < other.pos
+	}
+	return r.name < other.name
+}
+
+var pathElement = regexp.MustCompile(`\[(\d+)\]|([^.\[]+)`)
+
+// rankPath returns the rank of each element of path, which is a path into
+// a document described by s.
+func rankPath(s *Schema, path string) []pathRank {
+	var ranks []pathRank
+	for _, m := range pathElement.FindAllStringSubmatch(path, -1) {
+		if m[1] != "" {
+			i, _ := strconv.Atoi(m[1])
+			ranks = append(ranks, pathRank{pos: i})
+			if s != nil {
+				s = itemSchema(s, i)
+			}
+			continue
+		}
+		name := m[2]
+		pos := -1
+		var next *Schema
+		if s != nil {
+			props := orderedProperties(s)
+			pos = len(props)
+			for i, p := range props {
+				if p == name {
+					pos = i
+					break
+				}
+			}
+			if ps := propertySchemas(s, name); len(ps) > 0 {
+				next = ps[0]
+			}
+		}
+		ranks = append(ranks, pathRank{pos: pos, name: name})
+		s = next
+	}
+	return ranks
+}
+
+// objectKeysInOrder returns the keys of obj, which is described by s, with
+// the properties of s first, ordered as described by s.Order, and any others
+// after them in sorted order.
+func objectKeysInOrder(s *Schema, obj map[string]interface{}) []string {
+	keys := make([]string, 0, len(obj))
+	seen := make(map[string]bool)
+	for _, name := range orderedProperties(s) {
+		if _, ok := obj[name]; ok {
+			keys = append(keys, name)
+			seen[name] = true
+		}
+	}
+	for _, name := range sortedObjectKeys(obj) {
+		if !seen[name] {
+			keys = append(keys, name)
+		}
+	}
+	return keys
+}
+
+// internalPrefix matches the start of the errors returned by jsschema, which
+// identify the validator by its address.
+var internalPrefix = regexp.MustCompile(`^validator 0x[0-9a-f]+ failed: `)
+
+// explainError returns the errors which caused jsschema to reject x, found
+// at path, when validating it against s, found within root, with the result
+// err. See explanation.explain.
+func explainError(root, s *Schema, x interface{}, path string, err error) ValidationErrors {
+	return newExplanation(root).explain(s, x, path, err)
+}
+
+// explanation holds the state for explaining why jsschema rejected a value.
+type explanation struct {
+	// root holds the schema being validated against, which references are
+	// resolved within.
+	root *Schema
+
+	// compiled holds the schemas compiled so far, so that each is compiled
+	// only once however many values it is used to explain.
+	compiled map[*Schema]compiledSchema
+}
+
+// compiledSchema holds the result of compiling a schema with
+// compileInternal.
+type compiledSchema struct {
+	v   interface{ Validate(interface{}) error }
+	err error
+}
+
+func newExplanation(root *Schema) *explanation {
+	return &explanation{root: root, compiled: make(map[*Schema]compiledSchema)}
+}
+
+// validate validates x against the keywords in s that are implemented by
+// jsschema, compiling s only the first time it is used.
+func (e *explanation) validate(s *Schema, x interface{}) error {
+	c, ok := e.compiled[s]
+	if !ok {
+		c.v, c.err = compileInternal(e.root, s)
+		e.compiled[s] = c
+	}
+	if c.err != nil {
+		return c.err
+	}
+	return c.v.Validate(x)
+}
+
+// explain returns the errors which caused jsschema to reject x, found at
+// path, when validating it against s with the result err, in a stable order.
+// The properties and items of x are checked individually, so that each
+// problem is reported with its own path; if none is at fault, the
+// alternatives, dependents, conditionals, consts and described patterns of s
+// are checked instead, and failing that err itself is returned.
+func (e *explanation) explain(s *Schema, x interface{}, path string, err error) ValidationErrors {
+	root := e.root
+	s = derefLocal(root, s)
+	composed := composedSchemas(root, s, false)
+	errs := e.explainSchemas(composed, x, path)
+	if len(errs) == 0 {
+		errs = e.explainAlternatives(s, composed, x, path)
+		errs = append(errs, e.explainDependents(s, composed, x, path)...)
+		errs = append(errs, e.explainConditionals(composed, x, path)...)
+	}
+	if len(errs) == 0 {
+		errs = append(constErrors(composed, x, path), patternErrors(composed, x, path)...)
+	}
+	if len(errs) == 0 {
+		errs = append(errs, &ValidationError{
+			Path: path,
+			Err:  errors.New(internalPrefix.ReplaceAllString(err.Error(), "")),
+		})
+	}
+	errs = append(errs, multipleOfErrors(composed, x, path)...)
+	errs.Sort(s)
+	return errs
+}
+
+// explainSchemas returns the errors found in the properties and items of
+// x, found at path, as described by the composed schemas.
+func (e *explanation) explainSchemas(composed []*Schema, x interface{}, path string) ValidationErrors {
+	root := e.root
+	var errs ValidationErrors
+	explain := func(ps *Schema, v interface{}, path string) {
+		if perr := e.validate(ps, v); perr != nil {
+			for _, pe := range e.explain(ps, v, path, perr) {
+				errs = append(errs, provenanceError(ps, pe))
+			}
+		}
+	}
+	if obj, ok := asObject(x); ok {
+		missing := make(map[string]bool)
+		for _, cs := range composed {
+			for _, name := range cs.Required {
+				if _, ok := obj[name]; ok || missing[name] {
+					continue
+				}
+				missing[name] = true
+				e := &ValidationError{Path: propertyPath(path, name), Keyword: "required", Err: ErrRequired}
+				if ps, ok := cs.Properties[name]; ok {
+					e = provenanceError(ps, e)
+				}
+				errs = append(errs, e)
+			}
+		}
+		for _, cs := range composed {
+			errs = append(errs, missingDependents(cs, obj, path, missing)...)
+			errs = append(errs, propertyNameErrors(root, cs, obj, path)...)
+			errs = append(errs, unevaluatedErrors(root, cs, obj, path)...)
+		}
+		for _, name := range objectKeysInOrder(composed[0], obj) {
+			for _, cs := range composed {
+				for _, ps := range propertySchemas(cs, name) {
+					explain(ps, obj[name], propertyPath(path, name))
+				}
+			}
+		}
+	}
+	if arr, ok := asArray(x); ok {
+		for _, cs := range composed {
+			errs = append(errs, containsErrors(root, cs, arr, path)...)
+			errs = append(errs, unevaluatedItemErrors(root, cs, arr, path)...)
+		}
+		for _, cs := range composed {
+			if len(cs.PrefixItems) == 0 && (cs.Items == nil || (len(cs.Items.Schemas) == 0 && cs.AdditionalItems == nil)) {
+				continue
+			}
+			for i, item := range arr {
+				explain(itemSchema(cs, i), item, itemPath(path, i))
+			}
+		}
+	}
+	return errs
+}
+
+// explainAlternatives returns an error for each set of anyOf or oneOf
+// alternatives in the schemas composed into s that x, found at path, doesn't
+// match as it should: none of them, or more than one of a set of oneOf
+// alternatives. When x matches none and only one of them might be meant to
+// describe x, as told by admitsValue, the errors found in x by that
+// alternative are returned instead. An error is also returned for each
+// schema given by not that x matches.
+func (e *explanation) explainAlternatives(s *Schema, composed []*Schema, x interface{}, path string) ValidationErrors {
+	root := e.root
+	var errs ValidationErrors
+	explain := func(keyword string, alts []*Schema) {
+		var candidates []*Schema
+		var matched []string
+		for i, alt := range alts {
+			alt = derefLocal(root, alt)
+			if validateInternal(root, withAlternative(root, s, alt), x) == nil {
+				matched = append(matched, alternativeName(alt, i))
+				if keyword == "anyOf" {
+					return
+				}
+			} else if admitsValue(alt, x) {
+				candidates = append(candidates, alt)
+			}
+		}
+		switch {
+		case len(matched) == 1:
+			return
+		case len(matched) > 1:
+			errs = append(errs, &ValidationError{
+				Path:    path,
+				Keyword: keyword,
+				Err:     fmt.Errorf("matches more than one of the alternatives (%s)", joinNames(matched)),
+			})
+			return
+		}
+		var altErrs ValidationErrors
+		if len(candidates) == 1 {
+			altErrs = e.explainSchemas(composedSchemas(root, candidates[0], false), x, path)
+		}
+		if len(altErrs) == 0 {
+			altErrs = ValidationErrors{{
+				Path:    path,
+				Keyword: keyword,
+				Err:     errors.New("does not match any of the alternatives"),
+			}}
+		}
+		errs = append(errs, altErrs...)
+	}
+	for _, cs := range composed {
+		if len(cs.AnyOf) > 0 {
+			explain("anyOf", cs.AnyOf)
+		}
+		if len(cs.OneOf) > 0 {
+			explain("oneOf", cs.OneOf)
+		}
+		if cs.Not != nil && validateInternal(root, &Schema{Not: cs.Not}, x) != nil {
+			msg := "must not match the schema"
+			if not := derefLocal(root, cs.Not); not.Title != "" {
+				msg = fmt.Sprintf("must not match %q", not.Title)
+			}
+			errs = append(errs, &ValidationError{Path: path, Keyword: "not", Err: errors.New(msg)})
+		}
+	}
+	return errs
+}
+
+// alternativeName returns the name of the i'th alternative alt for use in
+// errors: its title if it has one, and otherwise its position, counting
+// from one.
+func alternativeName(alt *Schema, i int) string {
+	if alt.Title != "" {
+		return strconv.Quote(alt.Title)
+	}
+	return strconv.Itoa(i + 1)
+}
+
+// joinNames returns names joined into a list, as in "a, b and c".
+func joinNames(names []string) string {
+	if len(names) == 1 {
+		return names[0]
+	}
+	return strings.Join(names[:len(names)-1], ", ") + " and " + names[len(names)-1]
+}
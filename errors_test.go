@@ -0,0 +1,186 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"errors"
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type ErrorsSuite struct{}
+
+var _ = gc.Suite(ErrorsSuite{})
+
+const orderedErrorsSchema = `
+type: object
+order: [zone, name, nodes]
+required: [zone, region]
+properties:
+  name:
+    type: string
+  zone:
+    type: string
+  region:
+    type: string
+  nodes:
+    type: array
+    items:
+      type: object
+      properties:
+        port:
+          type: integer
+        address:
+          type: string
+`
+
+func (ErrorsSuite) TestErrorOrder(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(orderedErrorsSchema))
+	c.Assert(err, jc.ErrorIsNil)
+	doc := map[string]interface{}{
+		"name": 1,
+		"nodes": []interface{}{
+			map[string]interface{}{"address": 1, "port": "x"},
+			map[string]interface{}{"address": 2},
+		},
+	}
+	// The errors are the same every time, in schema order.
+	for i := 0; i < 20; i++ {
+		err := s.Validate(doc)
+		errs, ok := err.(ValidationErrors)
+		c.Assert(ok, jc.IsTrue, gc.Commentf("%#v", err))
+		var paths []string
+		for _, e := range errs {
+			paths = append(paths, e.Path)
+			c.Check(e.Err, gc.Not(gc.ErrorMatches), "validator 0x.*")
+		}
+		c.Assert(paths, jc.DeepEquals, []string{
+			"zone",
+			"name",
+			"nodes[0].address",
+			"nodes[0].port",
+			"nodes[1].address",
+			"region",
+		})
+		c.Check(errs[0].Keyword, gc.Equals, "required")
+	}
+}
+
+func (ErrorsSuite) TestPatternPropertiesOrder(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(`
+type: object
+patternProperties:
+  "^a": {const: 1}
+  "^ab": {const: 2}
+  "b$": {const: 3}
+  "^c": {const: 4}
+`))
+	c.Assert(err, jc.ErrorIsNil)
+	for i := 0; i < 20; i++ {
+		err := s.Validate(map[string]interface{}{"cab": 0, "ab": 0})
+		errs, ok := err.(ValidationErrors)
+		c.Assert(ok, jc.IsTrue, gc.Commentf("%#v", err))
+		var msgs []string
+		for _, e := range errs {
+			msgs = append(msgs, e.Error())
+		}
+		c.Assert(msgs, jc.DeepEquals, []string{
+			"ab: must be 1",
+			"ab: must be 2",
+			"ab: must be 3",
+			"cab: must be 4",
+			"cab: must be 3",
+		})
+	}
+}
+
+func (ErrorsSuite) TestExplanationCompilesOnce(c *gc.C) {
+	item := &Schema{
+		Type:       []Type{ObjectType},
+		Properties: map[string]*Schema{"name": {Type: []Type{StringType}}},
+		Required:   []string{"name"},
+	}
+	s := &Schema{Type: []Type{ArrayType}, Items: &ItemSpec{Schemas: []*Schema{item}}}
+	doc := []interface{}{
+		map[string]interface{}{},
+		map[string]interface{}{"name": "a"},
+		map[string]interface{}{},
+		map[string]interface{}{},
+	}
+	e := newExplanation(s)
+	err := e.validate(s, doc)
+	c.Assert(err, gc.NotNil)
+	errs := e.explain(s, doc, "", err)
+	c.Check(errs, gc.HasLen, 3)
+	// The item schema is compiled once for all the items.
+	c.Check(e.compiled, gc.HasLen, 2)
+}
+
+func (ErrorsSuite) TestSort(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(orderedErrorsSchema))
+	c.Assert(err, jc.ErrorIsNil)
+	fail := errors.New("fail")
+	errs := ValidationErrors{
+		{Path: "other", Err: fail},
+		{Path: "nodes[10].port", Err: fail},
+		{Path: "nodes[2].address", Err: fail},
+		{Path: "nodes", Err: fail},
+		{Path: "name", Err: fail},
+		{Path: "", Err: fail},
+		{Path: "zone", Err: fail},
+	}
+	errs.Sort(s)
+	var paths []string
+	for _, e := range errs {
+		paths = append(paths, e.Path)
+	}
+	c.Check(paths, jc.DeepEquals, []string{
+		"",
+		"zone",
+		"name",
+		"nodes",
+		"nodes[2].address",
+		"nodes[10].port",
+		"other",
+	})
+	c.Check(errs.Error(), gc.Equals, "fail; zone: fail; name: fail; nodes: fail; nodes[2].address: fail; nodes[10].port: fail; other: fail")
+}
+
+func (ErrorsSuite) TestSingleError(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(orderedErrorsSchema))
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.Validate(map[string]interface{}{"zone": "a", "region": 1})
+	verr, ok := err.(*ValidationError)
+	c.Assert(ok, jc.IsTrue, gc.Commentf("%#v", err))
+	c.Check(verr.Path, gc.Equals, "region")
+}
+
+func (ErrorsSuite) TestErrorsIs(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(orderedErrorsSchema))
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.Validate(map[string]interface{}{"zone": "a"})
+	c.Check(errors.Is(err, ErrRequired), jc.IsTrue)
+	c.Check(errors.Is(err, ErrFormat), jc.IsFalse)
+
+	// Every error in an aggregate is considered.
+	err = s.Validate(map[string]interface{}{"name": 1})
+	_, ok := err.(ValidationErrors)
+	c.Assert(ok, jc.IsTrue, gc.Commentf("%#v", err))
+	c.Check(errors.Is(err, ErrRequired), jc.IsTrue)
+	var verr *ValidationError
+	c.Assert(errors.As(err, &verr), jc.IsTrue)
+	c.Check(verr.Path, gc.Equals, "zone")
+
+	// Errors returned by custom validators are unwrapped too.
+	sentinel := errors.New("sentinel")
+	errs := ValidationErrors{
+		{Path: "name", Keyword: "validators", Err: sentinel},
+		{Path: "zone", Keyword: "access", Err: errors.New("no access")},
+	}
+	c.Check(errors.Is(errs, sentinel), jc.IsTrue)
+	c.Check(errors.Is(errs, ErrValidator), jc.IsTrue)
+	c.Check(errors.Is(errs, ErrForbidden), jc.IsTrue)
+}
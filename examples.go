@@ -0,0 +1,56 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// examples returns the sample values given for s: its Examples, or its
+// Example if it has none.
+func (s *Schema) examples() []interface{} {
+	if len(s.Examples) > 0 {
+		return s.Examples
+	}
+	if s.Example != nil {
+		return []interface{}{s.Example}
+	}
+	return nil
+}
+
+// ExampleHint returns a hint showing the format expected of values of s,
+// such as "(e.g. us-east-1)", for use when prompting the user. Several
+// examples are separated by commas. The empty string is returned if s has
+// no examples.
+func (s *Schema) ExampleHint() string {
+	examples := s.examples()
+	if len(examples) == 0 {
+		return ""
+	}
+	return "(e.g. " + formatExamplesList(examples) + ")"
+}
+
+// formatExamplesList returns the given examples as a comma-separated list.
+func formatExamplesList(examples []interface{}) string {
+	strs := make([]string, len(examples))
+	for i, example := range examples {
+		strs[i] = exampleString(example)
+	}
+	return strings.Join(strs, ", ")
+}
+
+// exampleString returns example as it would be typed by the user: strings
+// as they are, and other values in json form.
+func exampleString(example interface{}) string {
+	if str, ok := example.(string); ok {
+		return str
+	}
+	b, err := json.Marshal(example)
+	if err != nil {
+		return fmt.Sprint(example)
+	}
+	return string(b)
+}
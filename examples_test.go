@@ -0,0 +1,66 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"encoding/json"
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type ExamplesSuite struct{}
+
+var _ = gc.Suite(ExamplesSuite{})
+
+func (ExamplesSuite) TestExampleHint(c *gc.C) {
+	for i, test := range []struct {
+		schema *Schema
+		hint   string
+	}{{
+		schema: &Schema{},
+		hint:   "",
+	}, {
+		schema: &Schema{Examples: []interface{}{"us-east-1"}},
+		hint:   "(e.g. us-east-1)",
+	}, {
+		schema: &Schema{Examples: []interface{}{"us-east-1", "eu-west-2"}},
+		hint:   "(e.g. us-east-1, eu-west-2)",
+	}, {
+		schema: &Schema{Examples: []interface{}{8080, true}},
+		hint:   "(e.g. 8080, true)",
+	}, {
+		schema: &Schema{Example: "admin"},
+		hint:   "(e.g. admin)",
+	}, {
+		schema: &Schema{Example: "admin", Examples: []interface{}{"root"}},
+		hint:   "(e.g. root)",
+	}} {
+		c.Logf("test %d", i)
+		c.Check(test.schema.ExampleHint(), gc.Equals, test.hint)
+	}
+}
+
+func (ExamplesSuite) TestExamplesRoundTrip(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(`
+type: object
+properties:
+  region: {type: string, examples: [us-east-1, eu-west-2]}
+`))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.Properties["region"].Examples, jc.DeepEquals, []interface{}{"us-east-1", "eu-west-2"})
+	c.Check(s.Validate(map[string]interface{}{"region": "ap-south-1"}), jc.ErrorIsNil)
+
+	data, err := json.Marshal(s)
+	c.Assert(err, jc.ErrorIsNil)
+	var s1 Schema
+	c.Assert(json.Unmarshal(data, &s1), jc.ErrorIsNil)
+	c.Check(s1.Properties["region"].Examples, jc.DeepEquals, []interface{}{"us-east-1", "eu-west-2"})
+}
+
+func (ExamplesSuite) TestStripExamples(c *gc.C) {
+	s := &Schema{Examples: []interface{}{"x"}}
+	c.Check(StripAnnotations(s).Examples, gc.IsNil)
+}
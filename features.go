@@ -0,0 +1,62 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+// WithFeatures returns a copy of s without the properties which are behind
+// feature flags that are not in the given list of enabled flags, so that
+// documentation and interactive prompts don't mention them. The original
+// schema is not modified. The removed properties are no longer required, and
+// dependencies on or of them are dropped, including the schemas depending
+// on them.
+func (s *Schema) WithFeatures(enabled ...string) *Schema {
+	opts := ValidationOptions{FeatureFlags: enabled}
+	filtered := cloneSchema(s)
+	walkSchema(filtered, func(s *Schema) {
+		removed := make(map[string]bool)
+		for name, ps := range s.Properties {
+			if ps.FeatureFlag != "" && !opts.FeatureEnabled(ps.FeatureFlag) {
+				delete(s.Properties, name)
+				removed[name] = true
+			}
+		}
+		if len(removed) == 0 {
+			return
+		}
+		s.Required = withoutNames(s.Required, removed)
+		s.DependentRequired = withoutDependencies(s.DependentRequired, removed)
+		s.Dependencies.Names = withoutDependencies(s.Dependencies.Names, removed)
+		for name := range removed {
+			delete(s.Dependencies.Schemas, name)
+			delete(s.DependentSchemas, name)
+		}
+	})
+	return filtered
+}
+
+// withoutNames returns a copy of names without those in removed.
+func withoutNames(names []string, removed map[string]bool) []string {
+	var kept []string
+	for _, name := range names {
+		if !removed[name] {
+			kept = append(kept, name)
+		}
+	}
+	return kept
+}
+
+// withoutDependencies returns a copy of deps without the dependencies of the
+// properties in removed, nor on them.
+func withoutDependencies(deps map[string][]string, removed map[string]bool) map[string][]string {
+	var kept map[string][]string
+	for name, names := range deps {
+		if names = withoutNames(names, removed); removed[name] || len(names) == 0 {
+			continue
+		}
+		if kept == nil {
+			kept = make(map[string][]string)
+		}
+		kept[name] = names
+	}
+	return kept
+}
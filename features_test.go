@@ -0,0 +1,106 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type FeaturesSuite struct{}
+
+var _ = gc.Suite(FeaturesSuite{})
+
+const featuresExample = `
+type: object
+properties:
+  name:
+    type: string
+  fan-config:
+    type: string
+    feature-flag: developer-mode
+  network:
+    type: object
+    properties:
+      ipv6-only:
+        type: boolean
+        feature-flag: ipv6
+`
+
+func (FeaturesSuite) TestValidateFeatureFlags(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(featuresExample))
+	c.Assert(err, jc.ErrorIsNil)
+
+	doc := map[string]interface{}{
+		"name":       "x",
+		"fan-config": "10.0.0.0/8=252.0.0.0/8",
+	}
+	err = s.Validate(doc)
+	c.Check(err, gc.ErrorMatches, `fan-config: cannot be set unless feature flag "developer-mode" is enabled`)
+	err = s.ValidateWith(ValidationOptions{FeatureFlags: []string{"developer-mode"}}, doc)
+	c.Check(err, jc.ErrorIsNil)
+
+	doc = map[string]interface{}{
+		"network": map[string]interface{}{"ipv6-only": true},
+	}
+	err = s.ValidateWith(ValidationOptions{FeatureFlags: []string{"developer-mode"}}, doc)
+	c.Check(err, gc.ErrorMatches, `network.ipv6-only: cannot be set unless feature flag "ipv6" is enabled`)
+
+	// Properties behind flags may always be left unset.
+	err = s.Validate(map[string]interface{}{"name": "x"})
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (FeaturesSuite) TestWithFeatures(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(featuresExample))
+	c.Assert(err, jc.ErrorIsNil)
+
+	filtered := s.WithFeatures()
+	c.Check(sortedKeys(filtered.Properties), jc.DeepEquals, []string{"name", "network"})
+	c.Check(filtered.Properties["network"].Properties, gc.HasLen, 0)
+
+	filtered = s.WithFeatures("ipv6")
+	c.Check(sortedKeys(filtered.Properties), jc.DeepEquals, []string{"name", "network"})
+	c.Check(sortedKeys(filtered.Properties["network"].Properties), jc.DeepEquals, []string{"ipv6-only"})
+
+	// The original is untouched.
+	c.Check(s.Properties, gc.HasLen, 3)
+}
+
+func (FeaturesSuite) TestWithFeaturesRequired(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(`
+type: object
+properties:
+  name:
+    type: string
+  x:
+    type: string
+    feature-flag: developer-mode
+required: [name, x]
+dependentRequired:
+  name: [x]
+  x: [name]
+dependentSchemas:
+  x: {required: [name]}
+dependencies:
+  x: {required: [name]}
+`))
+	c.Assert(err, jc.ErrorIsNil)
+
+	filtered := s.WithFeatures()
+	c.Check(filtered.Required, jc.DeepEquals, []string{"name"})
+	c.Check(filtered.DependentRequired, gc.HasLen, 0)
+	c.Check(filtered.DependentSchemas, gc.HasLen, 0)
+	c.Check(filtered.Dependencies.Schemas, gc.HasLen, 0)
+	c.Check(filtered.Check(), jc.ErrorIsNil)
+	c.Check(filtered.Validate(map[string]interface{}{"name": "a"}), jc.ErrorIsNil)
+
+	// The original is untouched.
+	c.Check(s.Required, jc.DeepEquals, []string{"name", "x"})
+	c.Check(s.DependentRequired["name"], jc.DeepEquals, []string{"x"})
+	c.Check(s.DependentSchemas["x"], gc.NotNil)
+	c.Check(s.Dependencies.Schemas["x"], gc.NotNil)
+}
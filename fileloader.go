@@ -0,0 +1,69 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"fmt"
+	"io/fs"
+	"net/url"
+	"strings"
+)
+
+// FileLoader is a Loader which loads schemas, in json or yaml, from the
+// files in FS, so that a schema split across several files can be loaded
+// with a single call to LoadFile. Use os.DirFS to load schemas from a
+// directory on disk, or an embed.FS to load schemas built into the binary.
+//
+// Files are identified by "file" uris holding their slash-separated paths
+// within FS, such as "file:///schemas/common.json", so that references
+// such as "common.json#/definitions/port" are resolved relative to the file
+// that holds them.
+type FileLoader struct {
+	FS fs.FS
+}
+
+// LoadFile returns the schema in the named file, with any references to
+// other files resolved relative to it.
+func (l FileLoader) LoadFile(name string) (*Schema, error) {
+	s, err := l.Load(name)
+	if err != nil {
+		return nil, err
+	}
+	if err := newRefResolver(l).resolve(s, fileURI(name), true); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Load implements Loader. The uri may also be given as a path within l.FS.
+func (l FileLoader) Load(uri string) (*Schema, error) {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return nil, err
+	}
+	if (u.Scheme != "" && u.Scheme != "file") || u.Host != "" {
+		return nil, fmt.Errorf("cannot load %s: not a file uri", uri)
+	}
+	name := strings.TrimPrefix(u.Path, "/")
+	if !fs.ValidPath(name) {
+		return nil, fmt.Errorf("cannot load %s: invalid path %q", uri, name)
+	}
+	f, err := l.FS.Open(name)
+	if err != nil {
+		return nil, fmt.Errorf("cannot load %s: %w", uri, err)
+	}
+	defer f.Close()
+	// YAML is a superset of json, so this handles both.
+	s, err := FromYAML(f)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse schema from %s: %v", uri, err)
+	}
+	return s, nil
+}
+
+// fileURI returns the uri identifying the named file to a FileLoader.
+func fileURI(name string) string {
+	u := url.URL{Scheme: "file", Path: "/" + name}
+	return u.String()
+}
@@ -0,0 +1,97 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing/fstest"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type FileLoaderSuite struct{}
+
+var _ = gc.Suite(FileLoaderSuite{})
+
+var schemaFiles = fstest.MapFS{
+	"service.yaml": {Data: []byte(`
+type: object
+properties:
+  endpoint:
+    $ref: common/endpoint.yaml
+  timeout:
+    $ref: "common/units.json#/definitions/seconds"
+`)},
+	"common/endpoint.yaml": {Data: []byte(`
+type: object
+properties:
+  port:
+    $ref: "#/definitions/port"
+  timeout:
+    $ref: "units.json#/definitions/seconds"
+definitions:
+  port:
+    type: integer
+    maximum: 65535
+    default: 443
+`)},
+	"common/units.json": {Data: []byte(`{
+		"definitions": {"seconds": {"type": "integer", "minimum": 0}}
+	}`)},
+	"broken.yaml": {Data: []byte(`
+properties:
+  a:
+    $ref: ../outside.yaml
+`)},
+}
+
+func (FileLoaderSuite) TestLoadFile(c *gc.C) {
+	s, err := FileLoader{FS: schemaFiles}.LoadFile("service.yaml")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(s.Validate(map[string]interface{}{
+		"endpoint": map[string]interface{}{"port": 8443, "timeout": 5},
+		"timeout":  10,
+	}), jc.ErrorIsNil)
+	c.Check(s.Validate(map[string]interface{}{
+		"endpoint": map[string]interface{}{"port": 70000},
+	}), gc.ErrorMatches, `endpoint.port: numeric value is greater than maximum`)
+	c.Check(s.Validate(map[string]interface{}{
+		"endpoint": map[string]interface{}{"timeout": -1},
+	}), gc.ErrorMatches, `endpoint.timeout: numeric value is less than the minimum`)
+
+	doc := map[string]interface{}{}
+	s.InsertDefaults(doc)
+	c.Check(doc, jc.DeepEquals, map[string]interface{}{
+		"endpoint": map[string]interface{}{"port": float64(443)},
+	})
+}
+
+func (FileLoaderSuite) TestLoadFileFromDisk(c *gc.C) {
+	dir := c.MkDir()
+	for name, f := range schemaFiles {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		c.Assert(os.MkdirAll(filepath.Dir(path), 0755), jc.ErrorIsNil)
+		c.Assert(os.WriteFile(path, f.Data, 0644), jc.ErrorIsNil)
+	}
+	s, err := FileLoader{FS: os.DirFS(dir)}.LoadFile("service.yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.Validate(map[string]interface{}{"timeout": -1}), gc.ErrorMatches, `timeout: numeric value is less than the minimum`)
+}
+
+func (FileLoaderSuite) TestLoadFileErrors(c *gc.C) {
+	loader := FileLoader{FS: schemaFiles}
+	_, err := loader.LoadFile("missing.yaml")
+	c.Check(errors.Is(err, fs.ErrNotExist), jc.IsTrue)
+
+	_, err = loader.LoadFile("broken.yaml")
+	c.Check(err, gc.ErrorMatches, `cannot resolve \$ref "../outside.yaml": cannot load file:///outside.yaml: .*`)
+
+	_, err = loader.Load("https://example.com/schema.json")
+	c.Check(err, gc.ErrorMatches, `cannot load https://example.com/schema.json: not a file uri`)
+}
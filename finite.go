@@ -0,0 +1,41 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"errors"
+	"math"
+	"reflect"
+)
+
+// nonFiniteErrors returns an error for each NaN or infinite number found in
+// x, which is found at path. Such numbers can't be represented in json, and
+// compare unexpectedly against minimum and maximum, so they are rejected
+// unless ValidationOptions.AllowNonFinite is set.
+func nonFiniteErrors(x interface{}, path string) ValidationErrors {
+	var errs ValidationErrors
+	if obj, ok := asObject(x); ok {
+		for _, name := range sortedObjectKeys(obj) {
+			errs = append(errs, nonFiniteErrors(obj[name], propertyPath(path, name))...)
+		}
+		return errs
+	}
+	if arr, ok := asArray(x); ok {
+		for i, item := range arr {
+			errs = append(errs, nonFiniteErrors(item, itemPath(path, i))...)
+		}
+		return errs
+	}
+	rv := reflect.ValueOf(x)
+	if rv.Kind() != reflect.Float32 && rv.Kind() != reflect.Float64 {
+		return nil
+	}
+	switch f := rv.Float(); {
+	case math.IsNaN(f):
+		errs = append(errs, &ValidationError{Path: path, Keyword: "type", Err: errors.New("NaN is not a valid number")})
+	case math.IsInf(f, 0):
+		errs = append(errs, &ValidationError{Path: path, Keyword: "type", Err: errors.New("infinity is not a valid number")})
+	}
+	return errs
+}
@@ -0,0 +1,57 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"math"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type FiniteSuite struct{}
+
+var _ = gc.Suite(FiniteSuite{})
+
+var finiteSchema = &Schema{
+	Type: []Type{ObjectType},
+	Properties: map[string]*Schema{
+		"ratio": {
+			Type:    []Type{NumberType},
+			Minimum: Float(0),
+			Maximum: Float(1),
+		},
+		"weights": {
+			Type: []Type{ArrayType},
+			Items: &ItemSpec{Schemas: []*Schema{{
+				Type: []Type{NumberType},
+			}}},
+		},
+	},
+}
+
+func (FiniteSuite) TestRejectNonFinite(c *gc.C) {
+	err := finiteSchema.Validate(map[string]interface{}{"ratio": math.NaN()})
+	c.Check(err, gc.ErrorMatches, `ratio: NaN is not a valid number`)
+
+	err = finiteSchema.Validate(map[string]interface{}{
+		"ratio":   math.Inf(1),
+		"weights": []interface{}{0.5, float32(math.Inf(-1))},
+	})
+	c.Check(err, gc.ErrorMatches, `ratio: infinity is not a valid number; weights\[1\]: infinity is not a valid number`)
+
+	c.Check(finiteSchema.Validate(map[string]interface{}{"ratio": 0.5}), jc.ErrorIsNil)
+}
+
+func (FiniteSuite) TestAllowNonFinite(c *gc.C) {
+	opts := ValidationOptions{AllowNonFinite: true}
+	err := finiteSchema.ValidateWith(opts, map[string]interface{}{
+		"weights": []interface{}{math.NaN(), math.Inf(1)},
+	})
+	c.Check(err, jc.ErrorIsNil)
+
+	// Infinite values are still subject to minimum and maximum.
+	err = finiteSchema.ValidateWith(opts, map[string]interface{}{"ratio": math.Inf(1)})
+	c.Check(err, gc.ErrorMatches, `ratio: .*`)
+}
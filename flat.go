@@ -0,0 +1,322 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ExpandFlat converts flat key=value assignments, as given on the command
+// line, into a document described by s, and validates it. Keys are dotted
+// paths such as "auth.username", with array items given by index, as in
+// "nodes[0].address". Each value is converted to the type of its property:
+// numbers and booleans are parsed, arrays of simple values are split at
+// commas, and quantities with a unit are converted by Coerce.
+func (s *Schema) ExpandFlat(flat map[string]string) (map[string]interface{}, error) {
+	tree := make(flatObject)
+	keys := make([]string, 0, len(flat))
+	for key := range flat {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if err := tree.set(key, flat[key]); err != nil {
+			return nil, err
+		}
+	}
+	doc, err := s.expandFlat(s, tree, "")
+	if err != nil {
+		return nil, err
+	}
+	obj := doc.(map[string]interface{})
+	if err := s.Coerce(obj); err != nil {
+		return nil, err
+	}
+	if err := s.Validate(obj); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+// flatObject and flatArray hold flat assignments arranged into a tree, with
+// the values still in their string form.
+type (
+	flatObject map[string]interface{}
+	flatArray  map[int]interface{}
+)
+
+// set adds the assignment of raw to the given key to the tree.
+func (t flatObject) set(key, raw string) error {
+	elems, ok := parseFlatKey(key)
+	if !ok {
+		return fmt.Errorf("invalid key %q", key)
+	}
+	var node interface{} = t
+	for i, m := range elems {
+		last := i == len(elems)-1
+		var child interface{}
+		if !last {
+			if elems[i+1][1] != "" {
+				child = make(flatArray)
+			} else {
+				child = make(flatObject)
+			}
+		} else {
+			child = raw
+		}
+		var existing interface{}
+		var ok bool
+		switch n := node.(type) {
+		case flatObject:
+			if m[2] == "" {
+				return fmt.Errorf("key %q conflicts with another key", key)
+			}
+			if existing, ok = n[m[2]]; !ok {
+				n[m[2]] = child
+			}
+		case flatArray:
+			if m[1] == "" {
+				return fmt.Errorf("key %q conflicts with another key", key)
+			}
+			index, _ := strconv.Atoi(m[1])
+			if existing, ok = n[index]; !ok {
+				n[index] = child
+			}
+		default:
+			return fmt.Errorf("key %q conflicts with another key", key)
+		}
+		if ok {
+			if last {
+				return fmt.Errorf("key %q conflicts with another key", key)
+			}
+			child = existing
+		}
+		node = child
+	}
+	return nil
+}
+
+// parseFlatKey splits a dotted key into its elements, each of which is a
+// match of pathElement. It reports whether the key is well formed.
+func parseFlatKey(key string) ([][]string, bool) {
+	elems := pathElement.FindAllStringSubmatch(key, -1)
+	if len(elems) == 0 || elems[0][2] == "" || flatPrefix(elems) != key {
+		return nil, false
+	}
+	return elems, true
+}
+
+// flatPrefix returns the dotted key made up of the given key elements.
+func flatPrefix(elems [][]string) string {
+	var key string
+	for _, m := range elems {
+		if m[1] != "" {
+			key += m[0]
+		} else {
+			key = propertyPath(key, m[2])
+		}
+	}
+	return key
+}
+
+// expandFlat returns the value described by s for the given node of a flat
+// tree, found at path. References are resolved within root.
+func (s *Schema) expandFlat(root *Schema, node interface{}, path string) (interface{}, error) {
+	s = derefLocal(root, s)
+	switch node := node.(type) {
+	case flatObject:
+		obj := make(map[string]interface{})
+		// Properties introduced by variants can only be recognised once the
+		// properties selecting them have been converted.
+		var pending []string
+		for _, name := range sortedObjectKeys(node) {
+			if _, ok := s.Properties[name]; !ok {
+				pending = append(pending, name)
+				continue
+			}
+			v, err := s.Properties[name].expandFlat(root, node[name], propertyPath(path, name))
+			if err != nil {
+				return nil, err
+			}
+			obj[name] = v
+		}
+		effective := s.WithVariants(obj)
+		for _, name := range pending {
+			schemas := propertySchemas(effective, name)
+			if len(schemas) == 0 {
+				return nil, fmt.Errorf("unknown key %q", propertyPath(path, name))
+			}
+			v, err := schemas[0].expandFlat(root, node[name], propertyPath(path, name))
+			if err != nil {
+				return nil, err
+			}
+			obj[name] = v
+		}
+		return obj, nil
+	case flatArray:
+		arr := make([]interface{}, len(node))
+		for i := range arr {
+			item, ok := node[i]
+			if !ok {
+				return nil, fmt.Errorf("key %q is missing", itemPath(path, i))
+			}
+			v, err := itemSchema(s, i).expandFlat(root, item, itemPath(path, i))
+			if err != nil {
+				return nil, err
+			}
+			arr[i] = v
+		}
+		return arr, nil
+	}
+	v, err := flatValue(s, node.(string))
+	if err != nil {
+		return nil, fmt.Errorf("key %q: %v", path, err)
+	}
+	return v, nil
+}
+
+// flatValue converts raw to the type given by s.
+func flatValue(s *Schema, raw string) (interface{}, error) {
+	if s.Unit != "" {
+		// Coerce converts quantities.
+		return raw, nil
+	}
+	if item := arrayItem(s); item != nil {
+		var arr []interface{}
+		if raw != "" {
+			for _, elem := range strings.Split(raw, ",") {
+				v, err := flatValue(item, elem)
+				if err != nil {
+					return nil, err
+				}
+				arr = append(arr, v)
+			}
+		}
+		return arr, nil
+	}
+	if len(s.Type) == 0 {
+		return raw, nil
+	}
+	for _, t := range s.Type {
+		switch t {
+		case StringType:
+			return raw, nil
+		case IntegerType:
+			if i, err := strconv.Atoi(raw); err == nil {
+				return i, nil
+			}
+		case NumberType:
+			if f, err := strconv.ParseFloat(raw, 64); err == nil {
+				return f, nil
+			}
+		case BooleanType:
+			if b, err := strconv.ParseBool(raw); err == nil {
+				return b, nil
+			}
+		case NullType:
+			if raw == "null" {
+				return nil, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("expected %s, got %q", typeNames(s.Type), raw)
+}
+
+// typeNames returns the names of types, separated by "or".
+func typeNames(types []Type) string {
+	names := make([]string, len(types))
+	for i, t := range types {
+		names[i] = t.String()
+	}
+	return strings.Join(names, " or ")
+}
+
+// redacted replaces the values of secret properties in the output of
+// FlattenDoc.
+const redacted = "<redacted>"
+
+// FlattenDoc returns doc, which is described by s, as flat key=value
+// assignments in the form accepted by ExpandFlat, for display. Values of
+// secret properties are redacted, and values are formatted canonically:
+// numbers without exponents or trailing zeros, arrays of simple values
+// joined with commas, and sets (see Schema.SetOf) sorted.
+func FlattenDoc(s *Schema, doc map[string]interface{}) map[string]string {
+	flat := make(map[string]string)
+	flattenValue(s, s, doc, "", flat)
+	return flat
+}
+
+func flattenValue(root, s *Schema, x interface{}, path string, flat map[string]string) {
+	if s != nil {
+		s = derefLocal(root, s)
+		if s.Secret {
+			flat[path] = redacted
+			return
+		}
+	}
+	switch x := x.(type) {
+	case map[string]interface{}:
+		if s != nil {
+			s = s.WithVariants(x)
+		}
+		for name, v := range x {
+			var ps *Schema
+			if s != nil {
+				if schemas := propertySchemas(s, name); len(schemas) > 0 {
+					ps = schemas[0]
+				}
+			}
+			flattenValue(root, ps, v, propertyPath(path, name), flat)
+		}
+		return
+	case []interface{}:
+		x = canonicalItems(s, x)
+		item := func(i int) *Schema {
+			if s == nil {
+				return nil
+			}
+			return itemSchema(s, i)
+		}
+		simple := true
+		for i, v := range x {
+			switch v.(type) {
+			case map[string]interface{}, []interface{}:
+				simple = false
+			}
+			if ps := item(i); ps != nil && derefLocal(root, ps).Secret {
+				simple = false
+			}
+		}
+		if !simple {
+			for i, v := range x {
+				flattenValue(root, item(i), v, itemPath(path, i), flat)
+			}
+			return
+		}
+		elems := make([]string, len(x))
+		for i, v := range x {
+			elems[i] = formatFlatValue(v)
+		}
+		flat[path] = strings.Join(elems, ",")
+		return
+	}
+	flat[path] = formatFlatValue(x)
+}
+
+// formatFlatValue returns the canonical string form of the simple value x.
+func formatFlatValue(x interface{}) string {
+	switch x := normalizeValue(x).(type) {
+	case nil:
+		return "null"
+	case string:
+		return x
+	case float64:
+		return strconv.FormatFloat(x, 'f', -1, 64)
+	default:
+		return fmt.Sprint(x)
+	}
+}
@@ -0,0 +1,162 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type FlatSuite struct{}
+
+var _ = gc.Suite(FlatSuite{})
+
+const flatSchema = `
+type: object
+definitions:
+  node:
+    type: object
+    properties:
+      address: {type: string}
+      port: {type: integer}
+properties:
+  name: {type: string}
+  replicas: {type: integer, minimum: 1}
+  ratio: {type: number}
+  enabled: {type: boolean}
+  tags: {type: array, items: {type: string}}
+  ports: {type: array, items: {type: integer}}
+  disk: {type: integer, unit: MiB}
+  annotations:
+    type: object
+    additionalProperties: {type: string}
+  nodes:
+    type: array
+    items: {$ref: "#/definitions/node"}
+  auth:
+    type: object
+    properties:
+      type:
+        type: string
+        variants:
+          userpass:
+            properties:
+              username: {type: string}
+`
+
+func (FlatSuite) TestExpandFlat(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(flatSchema))
+	c.Assert(err, jc.ErrorIsNil)
+	doc, err := s.ExpandFlat(map[string]string{
+		"name":              "db",
+		"replicas":          "3",
+		"ratio":             "0.5",
+		"enabled":           "true",
+		"tags":              "a,b",
+		"ports":             "80,443",
+		"disk":              "2G",
+		"annotations.owner": "ops",
+		"nodes[0].address":  "10.0.0.1",
+		"nodes[0].port":     "5432",
+		"nodes[1].address":  "10.0.0.2",
+		"auth.type":         "userpass",
+		"auth.username":     "admin",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(doc, jc.DeepEquals, map[string]interface{}{
+		"name":        "db",
+		"replicas":    3,
+		"ratio":       0.5,
+		"enabled":     true,
+		"tags":        []interface{}{"a", "b"},
+		"ports":       []interface{}{80, 443},
+		"disk":        2048,
+		"annotations": map[string]interface{}{"owner": "ops"},
+		"nodes": []interface{}{
+			map[string]interface{}{"address": "10.0.0.1", "port": 5432},
+			map[string]interface{}{"address": "10.0.0.2"},
+		},
+		"auth": map[string]interface{}{"type": "userpass", "username": "admin"},
+	})
+}
+
+func (FlatSuite) TestExpandFlatErrors(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(flatSchema))
+	c.Assert(err, jc.ErrorIsNil)
+	for _, test := range []struct {
+		flat map[string]string
+		err  string
+	}{{
+		flat: map[string]string{"replicas": "many"},
+		err:  `key "replicas": expected integer, got "many"`,
+	}, {
+		flat: map[string]string{"replicas": "0"},
+		err:  `replicas: .*`,
+	}, {
+		flat: map[string]string{"colour": "blue"},
+		err:  `unknown key "colour"`,
+	}, {
+		flat: map[string]string{"auth.username": "admin"},
+		err:  `unknown key "auth.username"`,
+	}, {
+		flat: map[string]string{"nodes[1].address": "x"},
+		err:  `key "nodes\[0\]" is missing`,
+	}, {
+		flat: map[string]string{"name": "a", "name.first": "b"},
+		err:  `key "name.first" conflicts with another key`,
+	}, {
+		flat: map[string]string{"nodes..port": "1"},
+		err:  `invalid key "nodes..port"`,
+	}} {
+		c.Logf("test %v", test.flat)
+		_, err := s.ExpandFlat(test.flat)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (FlatSuite) TestFlattenDoc(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(flatSchema + `
+  password: {type: string, secret: true}
+`))
+	c.Assert(err, jc.ErrorIsNil)
+	doc := map[string]interface{}{
+		"name":        "db",
+		"replicas":    3,
+		"ratio":       float64(0.5),
+		"enabled":     true,
+		"tags":        []interface{}{"a", "b"},
+		"ports":       []interface{}{float64(80), 443},
+		"password":    "hunter2",
+		"annotations": map[string]interface{}{"owner": "ops"},
+		"nodes": []interface{}{
+			map[string]interface{}{"address": "10.0.0.1", "port": 5432},
+		},
+		"extra": 1e21,
+	}
+	flat := FlattenDoc(s, doc)
+	c.Check(flat, jc.DeepEquals, map[string]string{
+		"name":              "db",
+		"replicas":          "3",
+		"ratio":             "0.5",
+		"enabled":           "true",
+		"tags":              "a,b",
+		"ports":             "80,443",
+		"password":          "<redacted>",
+		"annotations.owner": "ops",
+		"nodes[0].address":  "10.0.0.1",
+		"nodes[0].port":     "5432",
+		"extra":             "1000000000000000000000",
+	})
+
+	// Apart from secrets, the result can be expanded again.
+	delete(flat, "password")
+	delete(flat, "extra")
+	delete(doc, "password")
+	delete(doc, "extra")
+	expanded, err := s.ExpandFlat(flat)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(FlattenDoc(s, expanded), jc.DeepEquals, flat)
+}
@@ -0,0 +1,48 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import "sync"
+
+// FormatFunc checks that a string value conforms to a format, returning an
+// error describing the problem if it does not.
+type FormatFunc func(value string) error
+
+type registeredFormat struct {
+	f         FormatFunc
+	expensive bool
+}
+
+var (
+	formatsMu sync.RWMutex
+	formats   = make(map[Format]registeredFormat)
+)
+
+// RegisterFormat registers a checker for string values with the given format,
+// in addition to the standard formats checked by jsonschema. Registering a
+// checker for a format that already has one replaces it, which allows, for
+// example, juju to use its own parser for the constraints format.
+func RegisterFormat(format Format, f FormatFunc) {
+	registerFormat(format, registeredFormat{f: f})
+}
+
+// RegisterExpensiveFormat registers a format checker in the same way as
+// RegisterFormat, marking it as expensive. Expensive checks are limited by
+// ValidationOptions.ExpensiveBudget.
+func RegisterExpensiveFormat(format Format, f FormatFunc) {
+	registerFormat(format, registeredFormat{f: f, expensive: true})
+}
+
+func registerFormat(format Format, rf registeredFormat) {
+	formatsMu.Lock()
+	defer formatsMu.Unlock()
+	formats[format] = rf
+}
+
+func lookupFormat(format Format) (registeredFormat, bool) {
+	formatsMu.RLock()
+	defer formatsMu.RUnlock()
+	rf, ok := formats[format]
+	return rf, ok
+}
@@ -0,0 +1,278 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// maxGenerateDepth limits how deeply Generate nests values, so that a
+// schema which requires a property to hold another value like itself
+// doesn't generate forever.
+const maxGenerateDepth = 32
+
+// formatExamples holds strings in each of the standard formats.
+var formatExamples = map[Format]string{
+	FormatDate:     "1970-01-01",
+	FormatDateTime: "1970-01-01T00:00:00Z",
+	FormatEmail:    "user@example.com",
+	FormatHostname: "example.com",
+	FormatIPv4:     "192.0.2.1",
+	FormatIPv6:     "2001:db8::1",
+	FormatURI:      "https://example.com/",
+}
+
+// Generate returns a document described by s, for use in tests and mocks.
+// Values are taken from the example, default or first enum value of their
+// schemas where given, and otherwise made up to satisfy the type, bounds,
+// lengths and format of their schemas. Objects hold their required
+// properties and those with examples or defaults, and arrays as few items
+// as they may. An error is returned if a valid document can't be
+// found this way, for example because a string must match a pattern that
+// no made-up string does.
+func (s *Schema) Generate() (interface{}, error) {
+	g := &generator{root: s}
+	x, err := g.generate(s, 0)
+	if err != nil {
+		return nil, fmt.Errorf("cannot generate document: %v", err)
+	}
+	if err := s.Validate(x); err != nil {
+		return nil, fmt.Errorf("cannot generate document: generated document is invalid: %v", err)
+	}
+	return x, nil
+}
+
+type generator struct {
+	root *Schema
+}
+
+// generate returns a value described by s, which is nested to the given
+// depth in the document.
+func (g *generator) generate(s *Schema, depth int) (interface{}, error) {
+	if depth > maxGenerateDepth {
+		return nil, fmt.Errorf("schema nests values more than %d deep", maxGenerateDepth)
+	}
+	s = derefLocal(g.root, s)
+	switch {
+	case s.Example != nil:
+		return copyValue(s.Example), nil
+	case s.Default != nil:
+		return copyValue(s.Default), nil
+	case len(s.Enum) > 0:
+		return copyValue(s.Enum[0]), nil
+	}
+	composed := composedSchemas(g.root, s, false)
+	for _, alts := range [][]*Schema{s.AnyOf, s.OneOf} {
+		if len(alts) > 0 {
+			composed = append(composed, composedSchemas(g.root, alts[0], false)...)
+		}
+	}
+	switch t := generateType(composed); t {
+	case ObjectType:
+		return g.object(composed, depth)
+	case ArrayType:
+		return g.array(composed[0], depth)
+	case StringType:
+		return generateString(composed)
+	case IntegerType, NumberType:
+		return generateNumber(composed, t == IntegerType)
+	case BooleanType:
+		return false, nil
+	}
+	return nil, nil
+}
+
+// generateType returns the type of the value to generate for the composed
+// schemas: the first type allowed by the first of them which says, other
+// than null unless nothing else is allowed.
+func generateType(composed []*Schema) Type {
+	for _, cs := range composed {
+		for _, t := range cs.Type {
+			if t != NullType {
+				return t
+			}
+		}
+		if len(cs.Type) > 0 {
+			return NullType
+		}
+	}
+	for _, cs := range composed {
+		if describesArray(cs) && !describesObject(cs) {
+			return ArrayType
+		}
+	}
+	return ObjectType
+}
+
+// object returns an object described by the composed schemas.
+func (g *generator) object(composed []*Schema, depth int) (interface{}, error) {
+	obj := make(map[string]interface{})
+	add := func(name string, ps *Schema) error {
+		if _, ok := obj[name]; ok {
+			return nil
+		}
+		v, err := g.generate(ps, depth+1)
+		if err != nil {
+			return fmt.Errorf("%s: %v", name, err)
+		}
+		obj[name] = v
+		return nil
+	}
+	for _, cs := range composed {
+		for _, name := range cs.Required {
+			ps, ok := cs.Properties[name]
+			if !ok {
+				for _, other := range composed {
+					if ps, ok = other.Properties[name]; ok {
+						break
+					}
+				}
+			}
+			if !ok {
+				ps = &Schema{}
+			}
+			if err := add(name, ps); err != nil {
+				return nil, err
+			}
+		}
+	}
+	minProperties := 0
+	for _, cs := range composed {
+		if cs.MinProperties != nil && *cs.MinProperties > minProperties {
+			minProperties = *cs.MinProperties
+		}
+	}
+	for _, cs := range composed {
+		for _, name := range orderedProperties(cs) {
+			ps := derefLocal(g.root, cs.Properties[name])
+			if len(obj) < minProperties || ps.Example != nil || ps.Default != nil {
+				if err := add(name, ps); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+	return obj, nil
+}
+
+// array returns an array described by s.
+func (g *generator) array(s *Schema, depth int) (interface{}, error) {
+	n := 0
+	if s.MinItems != nil {
+		n = *s.MinItems
+	}
+	if s.Items != nil && s.Items.TupleMode && len(s.Items.Schemas) > n {
+		n = len(s.Items.Schemas)
+	}
+	arr := make([]interface{}, n)
+	for i := range arr {
+		v, err := g.generate(itemSchema(s, i), depth+1)
+		if err != nil {
+			return nil, fmt.Errorf("[%d]: %v", i, err)
+		}
+		arr[i] = v
+	}
+	return arr, nil
+}
+
+// generateString returns a string described by the composed schemas.
+func generateString(composed []*Schema) (interface{}, error) {
+	minLength, maxLength := 0, -1
+	var candidates []string
+	for _, cs := range composed {
+		if cs.MinLength != nil && *cs.MinLength > minLength {
+			minLength = *cs.MinLength
+		}
+		if cs.MaxLength != nil && (maxLength < 0 || *cs.MaxLength < maxLength) {
+			maxLength = *cs.MaxLength
+		}
+		if example, ok := formatExamples[cs.Format]; ok {
+			candidates = append(candidates, example)
+		}
+	}
+	candidates = append(candidates, "example", "a", "0", "")
+	for _, c := range candidates {
+		if n := len([]rune(c)); n < minLength {
+			c += strings.Repeat("a", minLength-n)
+		}
+		if maxLength >= 0 && len([]rune(c)) > maxLength {
+			continue
+		}
+		matches := true
+		for _, cs := range composed {
+			if cs.Pattern != nil && !cs.Pattern.MatchString(c) {
+				matches = false
+			}
+		}
+		if matches {
+			return c, nil
+		}
+	}
+	for _, cs := range composed {
+		if cs.Pattern != nil {
+			return nil, fmt.Errorf("cannot make up a string matching %q", cs.Pattern)
+		}
+	}
+	return nil, fmt.Errorf("cannot make up a string between %d and %d characters long", minLength, maxLength)
+}
+
+// generateNumber returns a number described by the composed schemas,
+// which is an integer if integer is set.
+func generateNumber(composed []*Schema, integer bool) (interface{}, error) {
+	lo, hi := math.Inf(-1), math.Inf(1)
+	loExclusive, hiExclusive := false, false
+	multipleOf := 0.0
+	for _, cs := range composed {
+		if b, exclusive, ok := cs.lowerBound(); ok && (b > lo || (b == lo && exclusive)) {
+			lo, loExclusive = b, exclusive
+		}
+		if b, exclusive, ok := cs.upperBound(); ok && (b < hi || (b == hi && exclusive)) {
+			hi, hiExclusive = b, exclusive
+		}
+		if cs.MultipleOf != nil {
+			multipleOf = *cs.MultipleOf
+		}
+	}
+	step := multipleOf
+	if step == 0 && integer {
+		step = 1
+	}
+	v := 0.0
+	switch {
+	case lo > 0 || (lo == 0 && loExclusive):
+		v = lo
+	case hi < 0 || (hi == 0 && hiExclusive):
+		v = hi
+	}
+	if step > 0 {
+		// Move to the nearest allowed multiple within the bounds.
+		if v == lo {
+			v = math.Ceil(lo/step) * step
+		} else {
+			v = math.Floor(v/step) * step
+		}
+	}
+	if v == lo && loExclusive {
+		if step > 0 {
+			v += step
+		} else if !math.IsInf(hi, 1) {
+			v = (lo + hi) / 2
+		} else {
+			v = lo + 1
+		}
+	}
+	if v == hi && hiExclusive {
+		if step > 0 {
+			v -= step
+		} else {
+			v = hi - 1
+		}
+	}
+	if v < lo || v > hi || (v == lo && loExclusive) || (v == hi && hiExclusive) {
+		return nil, fmt.Errorf("cannot make up a number between %v and %v", lo, hi)
+	}
+	return v, nil
+}
@@ -0,0 +1,144 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"regexp"
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type GenerateSuite struct{}
+
+var _ = gc.Suite(GenerateSuite{})
+
+const generateSchema = `
+type: object
+required: [name, port, endpoints, owner, created]
+properties:
+  name:
+    type: string
+    minLength: 12
+  port:
+    type: integer
+    minimum: 1024
+    exclusiveMinimum: true
+    multipleOf: 10
+  ratio:
+    type: number
+    maximum: -0.5
+  series:
+    type: string
+    default: noble
+  region:
+    type: string
+    example: us-east-1
+  channel:
+    enum: [stable, edge]
+  enabled:
+    type: boolean
+  endpoints:
+    type: array
+    minItems: 2
+    items:
+      $ref: "#/definitions/endpoint"
+  owner:
+    type: string
+    format: email
+  created:
+    type: string
+    format: date-time
+  labels:
+    type: object
+    additionalProperties:
+      type: string
+definitions:
+  endpoint:
+    type: object
+    required: [space]
+    properties:
+      space:
+        type: [string, "null"]
+`
+
+func (GenerateSuite) TestGenerate(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(generateSchema))
+	c.Assert(err, jc.ErrorIsNil)
+	doc, err := s.Generate()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(doc, jc.DeepEquals, map[string]interface{}{
+		"name":   "exampleaaaaa",
+		"port":   float64(1030),
+		"series": "noble",
+		"region": "us-east-1",
+		"endpoints": []interface{}{
+			map[string]interface{}{"space": "example"},
+			map[string]interface{}{"space": "example"},
+		},
+		"owner":   "user@example.com",
+		"created": "1970-01-01T00:00:00Z",
+	})
+}
+
+func (GenerateSuite) TestGenerateScalars(c *gc.C) {
+	for _, test := range []struct {
+		schema string
+		want   interface{}
+	}{
+		{"{type: number, maximum: -0.5}", -0.5},
+		{"{type: number, minimum: 0, exclusiveMinimum: true, maximum: 1}", 0.5},
+		{"{type: integer, maximum: -3, multipleOf: 2}", float64(-4)},
+		{"{type: string, pattern: '^[0-9]+$'}", "0"},
+		{"{type: string, maxLength: 3}", "a"},
+		{"{type: 'null'}", nil},
+		{"{type: boolean}", false},
+		{"{anyOf: [{type: string}, {type: integer}]}", "example"},
+	} {
+		s, err := FromYAML(strings.NewReader(test.schema))
+		c.Assert(err, jc.ErrorIsNil)
+		got, err := s.Generate()
+		c.Check(err, jc.ErrorIsNil, gc.Commentf("%s", test.schema))
+		c.Check(got, jc.DeepEquals, test.want, gc.Commentf("%s", test.schema))
+	}
+}
+
+func (GenerateSuite) TestGenerateAllOf(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(allOfSchema))
+	c.Assert(err, jc.ErrorIsNil)
+	doc, err := s.Generate()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(doc, jc.DeepEquals, map[string]interface{}{
+		"name":          "example",
+		"port":          float64(17070),
+		"zone":          "example",
+		"instance-type": "m5.large",
+	})
+}
+
+func (GenerateSuite) TestGenerateErrors(c *gc.C) {
+	_, err := (&Schema{Type: []Type{StringType}, Pattern: regexp.MustCompile("^x{3}$")}).Generate()
+	c.Check(err, gc.ErrorMatches, `cannot generate document: cannot make up a string matching "\^x\{3\}\$"`)
+
+	s, err := FromYAML(strings.NewReader("{type: integer, minimum: 1.2, maximum: 1.8}"))
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.Generate()
+	c.Check(err, gc.ErrorMatches, `cannot generate document: cannot make up a number between 1.2 and 1.8`)
+
+	s = &Schema{
+		Type:     []Type{ObjectType},
+		Required: []string{"next"},
+		Properties: map[string]*Schema{
+			"next": {Reference: "#"},
+		},
+	}
+	_, err = s.Generate()
+	c.Check(err, gc.ErrorMatches, `cannot generate document: next: next: .*: schema nests values more than 32 deep`)
+
+	// Documents which made-up values don't satisfy are reported.
+	s = &Schema{Type: []Type{StringType}, Format: FormatDate, Default: "yesterday"}
+	_, err = s.Generate()
+	c.Check(err, gc.ErrorMatches, `cannot generate document: generated document is invalid: .*`)
+}
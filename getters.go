@@ -0,0 +1,158 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// GetString returns the string at the dotted path in doc, such as
+// "network.name" or "nodes[0].address", or the default that s gives it if
+// it isn't set, including one chosen by default-when. The value is first
+// converted as described by Coerce, so that a quantity is given with its
+// unit. An error is returned if there is no value at path or if it isn't a
+// string.
+func (s *Schema) GetString(doc map[string]interface{}, path string) (string, error) {
+	v, err := s.getValue(doc, path)
+	if err != nil {
+		return "", err
+	}
+	str, ok := v.(string)
+	if !ok {
+		return "", fmt.Errorf("%s: got %v, want string", path, valueType(nil, v))
+	}
+	return str, nil
+}
+
+// GetInt returns the integer at the dotted path in doc, as described by
+// GetString. A quantity with a unit is given in that unit, and a string is
+// parsed if s says that the value is a number, as for values given on the
+// command line. An error is returned if there is no value at path or if it
+// isn't an integer.
+func (s *Schema) GetInt(doc map[string]interface{}, path string) (int, error) {
+	f, err := s.GetFloat(doc, path)
+	if err != nil {
+		return 0, err
+	}
+	if f != math.Trunc(f) || f < math.MinInt64 || f >= math.MaxInt64 {
+		return 0, fmt.Errorf("%s: got %v, want integer", path, f)
+	}
+	return int(f), nil
+}
+
+// GetFloat returns the number at the dotted path in doc, as described by
+// GetInt. An error is returned if there is no value at path or if it isn't
+// a number.
+func (s *Schema) GetFloat(doc map[string]interface{}, path string) (float64, error) {
+	v, err := s.getValue(doc, path)
+	if err != nil {
+		return 0, err
+	}
+	f, ok := normalizeValue(v).(float64)
+	if !ok {
+		return 0, fmt.Errorf("%s: got %v, want number", path, valueType(nil, v))
+	}
+	return f, nil
+}
+
+// GetBool returns the boolean at the dotted path in doc, as described by
+// GetString. A string such as "true" or "false" is parsed if s says that
+// the value is a boolean. An error is returned if there is no value at path
+// or if it isn't a boolean.
+func (s *Schema) GetBool(doc map[string]interface{}, path string) (bool, error) {
+	v, err := s.getValue(doc, path)
+	if err != nil {
+		return false, err
+	}
+	b, ok := v.(bool)
+	if !ok {
+		return false, fmt.Errorf("%s: got %v, want boolean", path, valueType(nil, v))
+	}
+	return b, nil
+}
+
+// getValue returns the value at path in doc, or its default, converted as
+// described by its schema, or an error if there is none.
+func (s *Schema) getValue(doc map[string]interface{}, path string) (_ interface{}, err error) {
+	defer recoverPanic(&err)
+	v, ps, ok, err := s.lookupPath(doc, path)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, fmt.Errorf("%s: no value", path)
+	}
+	if ps == nil {
+		return v, nil
+	}
+	ps = derefLocal(s, ps)
+	if v, err = coerceValue(s, []*Schema{ps}, copyValue(v), path); err != nil {
+		return nil, err
+	}
+	if str, ok := v.(string); ok {
+		switch {
+		case hasType(ps, IntegerType) || hasType(ps, NumberType):
+			if f, err := strconv.ParseFloat(str, 64); err == nil {
+				return f, nil
+			}
+		case hasType(ps, BooleanType):
+			if b, err := strconv.ParseBool(str); err == nil {
+				return b, nil
+			}
+		}
+	}
+	return v, nil
+}
+
+// lookupPath returns the value at the dotted path in doc, or the default
+// that s gives it if it isn't set, along with the schema that describes it,
+// if any, and reports whether a value was found.
+func (s *Schema) lookupPath(doc map[string]interface{}, path string) (interface{}, *Schema, bool, error) {
+	elems, ok := parseFlatKey(path)
+	if !ok {
+		return nil, nil, false, fmt.Errorf("%s: invalid path", path)
+	}
+	current := s
+	var container interface{} = doc
+	for i, m := range elems {
+		if current != nil {
+			current = derefLocal(s, current)
+		}
+		if m[1] != "" {
+			arr, ok := container.([]interface{})
+			index, _ := strconv.Atoi(m[1])
+			if !ok || index >= len(arr) {
+				return nil, nil, false, nil
+			}
+			if current != nil {
+				current = itemSchema(current, index)
+			}
+			container = arr[index]
+			continue
+		}
+		obj, ok := container.(map[string]interface{})
+		if !ok {
+			return nil, nil, false, nil
+		}
+		var ps *Schema
+		if current != nil {
+			if schemas := propertySchemas(current.WithVariants(obj), m[2]); len(schemas) > 0 {
+				ps = schemas[0]
+			}
+		}
+		v, ok := obj[m[2]]
+		if !ok {
+			if i == len(elems)-1 && ps != nil {
+				if def := derefLocal(s, ps).defaultFor(obj); def != nil {
+					return copyValue(def), ps, true, nil
+				}
+			}
+			return nil, nil, false, nil
+		}
+		current, container = ps, v
+	}
+	return container, current, true, nil
+}
@@ -0,0 +1,124 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type GettersSuite struct{}
+
+var _ = gc.Suite(GettersSuite{})
+
+const gettersSchema = `
+type: object
+properties:
+  name:
+    type: string
+  port:
+    type: integer
+    default: 17070
+  ha:
+    type: boolean
+    default: false
+  memory:
+    type: integer
+    unit: MiB
+  disk:
+    type: string
+    unit: MiB
+  proxy:
+    type: object
+    properties:
+      enabled:
+        type: boolean
+      mode:
+        type: string
+        default-when:
+        - when: {enabled: true}
+          value: http
+  addresses:
+    type: array
+    items:
+      type: string
+`
+
+func (GettersSuite) TestGetters(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(gettersSchema))
+	c.Assert(err, jc.ErrorIsNil)
+	doc := map[string]interface{}{
+		"name":      "controller",
+		"memory":    "2G",
+		"disk":      float64(4096),
+		"proxy":     map[string]interface{}{"enabled": "true"},
+		"addresses": []interface{}{"10.0.0.1"},
+	}
+	name, err := s.GetString(doc, "name")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(name, gc.Equals, "controller")
+
+	// Values that aren't set are given their defaults.
+	port, err := s.GetInt(doc, "port")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(port, gc.Equals, 17070)
+	ha, err := s.GetBool(doc, "ha")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(ha, jc.IsFalse)
+
+	// Values are converted as described by their schemas.
+	memory, err := s.GetInt(doc, "memory")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(memory, gc.Equals, 2048)
+	disk, err := s.GetString(doc, "disk")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(disk, gc.Equals, "4G")
+	enabled, err := s.GetBool(doc, "proxy.enabled")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(enabled, jc.IsTrue)
+
+	mode, err := s.GetString(map[string]interface{}{"proxy": map[string]interface{}{"enabled": true}}, "proxy.mode")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(mode, gc.Equals, "http")
+
+	address, err := s.GetString(doc, "addresses[0]")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(address, gc.Equals, "10.0.0.1")
+
+	// The document is left alone.
+	c.Check(doc["memory"], gc.Equals, "2G")
+	c.Check(doc["port"], gc.IsNil)
+}
+
+func (GettersSuite) TestGettersStringsParsedByType(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(gettersSchema))
+	c.Assert(err, jc.ErrorIsNil)
+	doc := map[string]interface{}{"port": "8080", "ha": "yes", "name": "42"}
+	port, err := s.GetInt(doc, "port")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(port, gc.Equals, 8080)
+
+	_, err = s.GetBool(doc, "ha")
+	c.Check(err, gc.ErrorMatches, `ha: got string, want boolean`)
+	_, err = s.GetInt(doc, "name")
+	c.Check(err, gc.ErrorMatches, `name: got string, want number`)
+}
+
+func (GettersSuite) TestGettersErrors(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(gettersSchema))
+	c.Assert(err, jc.ErrorIsNil)
+	doc := map[string]interface{}{"port": 1.5, "memory": "lots"}
+	_, err = s.GetInt(doc, "port")
+	c.Check(err, gc.ErrorMatches, `port: got 1.5, want integer`)
+	_, err = s.GetInt(doc, "memory")
+	c.Check(err, gc.ErrorMatches, `memory: expected a size such as 512M or 8G, got "lots"`)
+	_, err = s.GetString(doc, "name")
+	c.Check(err, gc.ErrorMatches, `name: no value`)
+	_, err = s.GetString(doc, "proxy.mode")
+	c.Check(err, gc.ErrorMatches, `proxy.mode: no value`)
+	_, err = s.GetString(doc, "[0]")
+	c.Check(err, gc.ErrorMatches, `\[0\]: invalid path`)
+}
@@ -0,0 +1,179 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Edge records that a property of a schema depends on another property of
+// the same object. See DependencyGraph.
+type Edge struct {
+	// From holds the dotted path of the dependent property, such as
+	// "network.mtu".
+	From string
+
+	// To holds the dotted path of the property it depends on, which need
+	// not be declared by the schema.
+	To string
+
+	// Keyword holds the keyword that gives the dependency:
+	//
+	//   - "visible-when", when From is only visible if To has a value;
+	//   - "default-when", when the default of From depends on the value
+	//     of To;
+	//   - "path-for", when the value of From may be read from the file
+	//     named by To;
+	//   - "dependentRequired", when From is required if To is set, as
+	//     given by dependentRequired or dependencies.
+	Keyword string
+}
+
+// DependencyGraph returns the dependencies between the properties of s,
+// including those of nested objects and of the objects held by arrays,
+// whose paths are given as for ToMarkdown. The edges for each object are
+// listed in the order of its properties, as given by s.Order.
+//
+// Whether a property is visible, its default and the file its value is read
+// from must be decided after the properties they depend on, so these
+// dependencies give the order in which properties should be prompted for.
+// An error is returned if any of them are circular. A property may be
+// required whenever another is, and the other way around, so
+// dependentRequired edges aren't considered.
+func DependencyGraph(s *Schema) ([]Edge, error) {
+	g := &dependencyGraph{root: s, visiting: make(map[*Schema]bool)}
+	g.properties(s, "")
+	if cycle := orderingCycle(g.edges); cycle != nil {
+		return nil, fmt.Errorf("circular dependency between properties: %s", strings.Join(cycle, " -> "))
+	}
+	return g.edges, nil
+}
+
+type dependencyGraph struct {
+	root  *Schema
+	edges []Edge
+
+	// visiting holds the schemas whose properties are being visited, so
+	// that recursive schemas are only visited once.
+	visiting map[*Schema]bool
+}
+
+// properties adds the edges between the properties of s, which describes
+// the object found at path.
+func (g *dependencyGraph) properties(s *Schema, path string) {
+	s = derefLocal(g.root, s)
+	if g.visiting[s] {
+		return
+	}
+	g.visiting[s] = true
+	defer delete(g.visiting, s)
+	required := s.dependentRequired()
+	dependents := make([]string, 0, len(required))
+	for name := range required {
+		dependents = append(dependents, name)
+	}
+	sort.Strings(dependents)
+	for _, name := range orderedProperties(s) {
+		ps := derefLocal(g.root, s.Properties[name])
+		ppath := propertyPath(path, name)
+		for _, dep := range sortedConditionKeys(ps.VisibleWhen) {
+			g.add(ppath, propertyPath(path, dep), "visible-when")
+		}
+		seen := make(map[string]bool)
+		for _, d := range ps.DefaultWhen {
+			for _, dep := range sortedConditionKeys(d.When) {
+				if !seen[dep] {
+					seen[dep] = true
+					g.add(ppath, propertyPath(path, dep), "default-when")
+				}
+			}
+		}
+		for _, other := range orderedProperties(s) {
+			if derefLocal(g.root, s.Properties[other]).PathFor == name {
+				g.add(ppath, propertyPath(path, other), "path-for")
+			}
+		}
+		for _, dep := range dependents {
+			for _, r := range required[dep] {
+				if r == name {
+					g.add(ppath, propertyPath(path, dep), "dependentRequired")
+				}
+			}
+		}
+		g.properties(ps, ppath)
+		if hasType(ps, ArrayType) && ps.Items != nil && !ps.Items.TupleMode && len(ps.Items.Schemas) == 1 {
+			g.properties(ps.Items.Schemas[0], ppath+"[]")
+		}
+	}
+}
+
+func (g *dependencyGraph) add(from, to, keyword string) {
+	g.edges = append(g.edges, Edge{From: from, To: to, Keyword: keyword})
+}
+
+// sortedConditionKeys returns the names of the properties c refers to, in
+// sorted order.
+func sortedConditionKeys(c Condition) []string {
+	names := make([]string, 0, len(c))
+	for name := range c {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// orderingCycle returns the paths of the properties making up a cycle of
+// the edges that order properties, as described by DependencyGraph,
+// starting and ending with the same property, or nil if there is none.
+func orderingCycle(edges []Edge) []string {
+	deps := make(map[string][]string)
+	var nodes []string
+	for _, e := range edges {
+		if e.Keyword == "dependentRequired" {
+			continue
+		}
+		if _, ok := deps[e.From]; !ok {
+			nodes = append(nodes, e.From)
+		}
+		deps[e.From] = append(deps[e.From], e.To)
+	}
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[string]int)
+	var stack []string
+	var visit func(node string) []string
+	visit = func(node string) []string {
+		switch state[node] {
+		case visiting:
+			for i, n := range stack {
+				if n == node {
+					return append(append([]string(nil), stack[i:]...), node)
+				}
+			}
+		case visited:
+			return nil
+		}
+		state[node] = visiting
+		stack = append(stack, node)
+		for _, dep := range deps[node] {
+			if cycle := visit(dep); cycle != nil {
+				return cycle
+			}
+		}
+		stack = stack[:len(stack)-1]
+		state[node] = visited
+		return nil
+	}
+	for _, node := range nodes {
+		if cycle := visit(node); cycle != nil {
+			return cycle
+		}
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type GraphSuite struct{}
+
+var _ = gc.Suite(GraphSuite{})
+
+func (GraphSuite) TestDependencyGraph(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(`
+type: object
+order: [auth-type, access-key, secret-key, key-file]
+properties:
+  auth-type:
+    type: string
+    enum: [access-key, oauth]
+  access-key:
+    type: string
+    visible-when: {auth-type: access-key}
+  secret-key:
+    type: string
+    visible-when: {auth-type: access-key}
+  key-file:
+    type: string
+    path-for: secret-key
+  network:
+    type: object
+    properties:
+      mode: {type: string}
+      mtu:
+        type: integer
+        default-when:
+        - when: {mode: jumbo}
+          value: 9000
+        - when: {mode: jumbo, vlan: true}
+          value: 8996
+  nodes:
+    type: array
+    items:
+      type: object
+      properties:
+        name: {type: string}
+        port: {type: integer, visible-when: {name: web}}
+dependentRequired:
+  access-key: [secret-key]
+`))
+	c.Assert(err, jc.ErrorIsNil)
+	edges, err := DependencyGraph(s)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(edges, jc.DeepEquals, []Edge{
+		{From: "access-key", To: "auth-type", Keyword: "visible-when"},
+		{From: "secret-key", To: "auth-type", Keyword: "visible-when"},
+		{From: "secret-key", To: "key-file", Keyword: "path-for"},
+		{From: "secret-key", To: "access-key", Keyword: "dependentRequired"},
+		{From: "network.mtu", To: "network.mode", Keyword: "default-when"},
+		{From: "network.mtu", To: "network.vlan", Keyword: "default-when"},
+		{From: "nodes[].port", To: "nodes[].name", Keyword: "visible-when"},
+	})
+}
+
+func (GraphSuite) TestDependencyGraphCycle(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(`
+type: object
+properties:
+  a: {type: string, visible-when: {b: x}}
+  b:
+    type: string
+    default-when:
+    - when: {c: y}
+      value: z
+  c: {type: string, visible-when: {a: x}}
+`))
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = DependencyGraph(s)
+	c.Check(err, gc.ErrorMatches, `circular dependency between properties: a -> b -> c -> a`)
+}
+
+func (GraphSuite) TestDependencyGraphRequiredCycle(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(`
+type: object
+properties:
+  user: {type: string}
+  password: {type: string}
+dependentRequired:
+  user: [password]
+  password: [user]
+`))
+	c.Assert(err, jc.ErrorIsNil)
+	edges, err := DependencyGraph(s)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(edges, jc.DeepEquals, []Edge{
+		{From: "password", To: "user", Keyword: "dependentRequired"},
+		{From: "user", To: "password", Keyword: "dependentRequired"},
+	})
+}
+
+func (GraphSuite) TestDependencyGraphRecursive(c *gc.C) {
+	s, err := FromJSON(strings.NewReader(`{
+		"type": "object",
+		"properties": {
+			"kind": {"type": "string"},
+			"size": {"type": "integer", "visible-when": {"kind": "disk"}},
+			"child": {"$ref": "#"}
+		}
+	}`))
+	c.Assert(err, jc.ErrorIsNil)
+	edges, err := DependencyGraph(s)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(edges, jc.DeepEquals, []Edge{
+		{From: "size", To: "kind", Keyword: "visible-when"},
+	})
+}
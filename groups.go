@@ -0,0 +1,72 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+// GroupSpec declares a group of properties, for presenting the properties of
+// an object over several pages or steps.
+type GroupSpec struct {
+	// Name identifies the group, and is referred to by the group keyword of
+	// the properties in it.
+	Name string `json:"name"`
+
+	// Title holds a short human-friendly name for the group.
+	Title string `json:"title,omitempty"`
+
+	// Description holds a longer explanation of the group's purpose.
+	Description string `json:"description,omitempty"`
+}
+
+// PropertyGroup holds the properties in a group, as returned by Groups.
+type PropertyGroup struct {
+	GroupSpec
+
+	// Properties holds the names of the properties in the group, in the
+	// order they should be presented.
+	Properties []string
+}
+
+// Groups returns the properties of s arranged into groups. Groups are
+// returned in the order they are declared in GroupSpecs, followed by any
+// undeclared groups in the order they are first used. Properties which don't
+// belong to a group are returned in a final group with an empty name.
+// Within each group, the properties are ordered according to s.Order.
+// Groups without any properties are omitted, as are properties without a
+// schema.
+func (s *Schema) Groups() []PropertyGroup {
+	var groups []PropertyGroup
+	index := make(map[string]int)
+	addGroup := func(spec GroupSpec) {
+		if _, ok := index[spec.Name]; !ok {
+			index[spec.Name] = len(groups)
+			groups = append(groups, PropertyGroup{GroupSpec: spec})
+		}
+	}
+	for _, spec := range s.GroupSpecs {
+		addGroup(spec)
+	}
+	var ordered []string
+	for _, name := range orderedProperties(s) {
+		if s.Properties[name] != nil {
+			ordered = append(ordered, name)
+		}
+	}
+	for _, name := range ordered {
+		if group := s.Properties[name].Group; group != "" {
+			addGroup(GroupSpec{Name: group})
+		}
+	}
+	addGroup(GroupSpec{})
+	for _, name := range ordered {
+		i := index[s.Properties[name].Group]
+		groups[i].Properties = append(groups[i].Properties, name)
+	}
+
+	nonEmpty := groups[:0]
+	for _, g := range groups {
+		if len(g.Properties) > 0 {
+			nonEmpty = append(nonEmpty, g)
+		}
+	}
+	return nonEmpty
+}
@@ -0,0 +1,85 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type GroupsSuite struct{}
+
+var _ = gc.Suite(GroupsSuite{})
+
+const groupsExample = `
+type: object
+groups:
+  - name: auth
+    title: Authentication
+    description: How to log in to the cloud.
+  - name: unused
+  - name: network
+    title: Networking
+order: [password, username, endpoint]
+properties:
+  username:
+    type: string
+    group: auth
+  password:
+    type: string
+    group: auth
+  endpoint:
+    type: string
+  proxy:
+    type: string
+    group: network
+  region:
+    type: string
+    group: placement
+`
+
+func (GroupsSuite) TestGroups(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(groupsExample))
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(s.Groups(), jc.DeepEquals, []PropertyGroup{{
+		GroupSpec: GroupSpec{
+			Name:        "auth",
+			Title:       "Authentication",
+			Description: "How to log in to the cloud.",
+		},
+		Properties: []string{"password", "username"},
+	}, {
+		GroupSpec:  GroupSpec{Name: "network", Title: "Networking"},
+		Properties: []string{"proxy"},
+	}, {
+		GroupSpec:  GroupSpec{Name: "placement"},
+		Properties: []string{"region"},
+	}, {
+		Properties: []string{"endpoint"},
+	}})
+}
+
+func (GroupsSuite) TestGroupsUngrouped(c *gc.C) {
+	c.Check(objExample.Groups(), jc.DeepEquals, []PropertyGroup{{
+		Properties: []string{"payload"},
+	}})
+	c.Check((&Schema{}).Groups(), gc.HasLen, 0)
+}
+
+func (GroupsSuite) TestGroupsNilProperty(c *gc.C) {
+	s := &Schema{
+		Type: []Type{ObjectType},
+		Properties: map[string]*Schema{
+			"a": nil,
+			"b": {Type: []Type{StringType}, Group: "auth"},
+		},
+	}
+	c.Check(s.Groups(), jc.DeepEquals, []PropertyGroup{{
+		GroupSpec:  GroupSpec{Name: "auth"},
+		Properties: []string{"b"},
+	}})
+}
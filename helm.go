@@ -0,0 +1,264 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// draft07Schema is the meta-schema declared by ToHelmValuesSchema.
+const draft07Schema = "http://json-schema.org/draft-07/schema#"
+
+// draft07Keywords holds the draft-07 keywords which are kept by
+// ToHelmValuesSchema.
+var draft07Keywords = map[string]bool{
+	"$id": true, "$schema": true, "$ref": true, "definitions": true,
+	"title": true, "description": true, "default": true, "examples": true,
+	"type": true, "enum": true, "const": true, "format": true,
+	"multipleOf": true, "minimum": true, "maximum": true,
+	"exclusiveMinimum": true, "exclusiveMaximum": true,
+	"maxLength": true, "minLength": true, "pattern": true,
+	"items": true, "additionalItems": true, "maxItems": true, "minItems": true,
+	"uniqueItems": true, "contains": true,
+	"maxProperties": true, "minProperties": true, "required": true,
+	"properties": true, "patternProperties": true, "additionalProperties": true,
+	"dependencies": true, "propertyNames": true,
+	"if": true, "then": true, "else": true,
+	"allOf": true, "anyOf": true, "oneOf": true, "not": true,
+}
+
+// draft07Renames maps the keywords of later drafts which are converted by
+// ToHelmValuesSchema to their draft-07 equivalents, so that json pointers
+// into them can be rewritten.
+var draft07Renames = map[string]string{
+	"$defs":            "definitions",
+	"prefixItems":      "items",
+	"dependentSchemas": "dependencies",
+}
+
+// schemaContainers holds the keywords whose values hold schemas by name or
+// by position, rather than being schemas themselves.
+var schemaContainers = map[string]bool{
+	"properties": true, "patternProperties": true, "definitions": true,
+	"$defs": true, "dependencies": true, "dependentSchemas": true,
+	"allOf": true, "anyOf": true, "oneOf": true, "prefixItems": true,
+}
+
+// ToHelmValuesSchema returns s as a draft-07 json schema, suitable for use as
+// the values.schema.json of a Helm chart. Juju extensions are removed, and
+// keywords from other drafts are converted into their draft-07 form, with
+// any references to them rewritten. An error is returned if s uses keywords
+// which draft-07 has no equivalent for.
+func ToHelmValuesSchema(s *Schema) ([]byte, error) {
+	b, err := json.Marshal(s)
+	if err != nil {
+		return nil, err
+	}
+	var m map[string]interface{}
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, err
+	}
+	rewriteRefs(m, m)
+	if err := toDraft07(m); err != nil {
+		return nil, err
+	}
+	m["$schema"] = draft07Schema
+	return json.MarshalIndent(m, "", "  ")
+}
+
+// rewriteRefs rewrites the json pointers held by the references in m, and
+// the schemas nested within it, to address the same schemas once root has
+// been converted by toDraft07.
+func rewriteRefs(root, m map[string]interface{}) {
+	if ref, ok := m["$ref"].(string); ok && strings.HasPrefix(ref, "#/") {
+		m["$ref"] = "#/" + draft07Pointer(root, strings.Split(ref[2:], "/"))
+	}
+	eachSubschema(m, func(sub map[string]interface{}) {
+		rewriteRefs(root, sub)
+	})
+}
+
+// draft07Pointer returns the json pointer, without its leading "#/", which
+// addresses the value found by following segments from node once node has
+// been converted by toDraft07.
+func draft07Pointer(node interface{}, segments []string) string {
+	isSchema := true
+	for i, seg := range segments {
+		key := strings.NewReplacer("~1", "/", "~0", "~").Replace(seg)
+		switch n := node.(type) {
+		case map[string]interface{}:
+			if isSchema {
+				if renamed, ok := draft07Renames[key]; ok {
+					segments[i] = renamed
+				} else if key == "items" && n["prefixItems"] != nil {
+					segments[i] = "additionalItems"
+				}
+				_, tuple := n[key].([]interface{})
+				isSchema = !schemaContainers[key] && !(key == "items" && tuple)
+			} else {
+				isSchema = true
+			}
+			node = n[key]
+		case []interface{}:
+			isSchema = true
+			node = nil
+			if index, err := strconv.Atoi(key); err == nil && index >= 0 && index < len(n) {
+				node = n[index]
+			}
+		default:
+			return strings.Join(segments, "/")
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// eachSubschema calls fn for each schema directly nested within m.
+func eachSubschema(m map[string]interface{}, fn func(map[string]interface{})) {
+	for _, k := range []string{"properties", "patternProperties", "definitions", "$defs", "dependencies", "dependentSchemas"} {
+		if subs, ok := m[k].(map[string]interface{}); ok {
+			for _, sub := range subs {
+				if sm, ok := sub.(map[string]interface{}); ok {
+					fn(sm)
+				}
+			}
+		}
+	}
+	for _, k := range []string{"items", "prefixItems", "additionalItems", "additionalProperties", "contains", "propertyNames", "if", "then", "else", "not", "allOf", "anyOf", "oneOf", "unevaluatedItems", "unevaluatedProperties"} {
+		switch sub := m[k].(type) {
+		case map[string]interface{}:
+			fn(sub)
+		case []interface{}:
+			for _, item := range sub {
+				if sm, ok := item.(map[string]interface{}); ok {
+					fn(sm)
+				}
+			}
+		}
+	}
+}
+
+func toDraft07(m map[string]interface{}) error {
+	if id, ok := m["id"]; ok {
+		m["$id"] = id
+		delete(m, "id")
+	}
+	// Draft-04 exclusive limits are booleans modifying minimum and
+	// maximum; later drafts give the limit itself.
+	for _, limit := range []struct{ exclusive, bound string }{
+		{"exclusiveMinimum", "minimum"},
+		{"exclusiveMaximum", "maximum"},
+	} {
+		switch m[limit.exclusive] {
+		case true:
+			m[limit.exclusive] = m[limit.bound]
+			delete(m, limit.bound)
+		case false:
+			delete(m, limit.exclusive)
+		}
+	}
+	if anchor, ok := m["$anchor"]; ok {
+		// Draft-07 gives anchors as fragment-only ids.
+		if _, ok := m["$id"]; ok {
+			return fmt.Errorf("cannot convert $anchor %q of a schema with an id to draft-07", anchor)
+		}
+		m["$id"] = fmt.Sprintf("#%v", anchor)
+		delete(m, "$anchor")
+	}
+	if example, ok := m["example"]; ok {
+		// Keep any examples already given, adding the example to them.
+		examples, _ := m["examples"].([]interface{})
+		found := false
+		for _, e := range examples {
+			found = found || valuesEqual(e, example)
+		}
+		if !found {
+			m["examples"] = append(examples, example)
+		}
+	}
+	if defs, ok := m["$defs"].(map[string]interface{}); ok {
+		definitions, _ := m["definitions"].(map[string]interface{})
+		if definitions == nil {
+			definitions = make(map[string]interface{})
+		}
+		for name, def := range defs {
+			if _, ok := definitions[name]; ok {
+				return fmt.Errorf("cannot convert $defs to draft-07: %q is also in definitions", name)
+			}
+			definitions[name] = def
+		}
+		m["definitions"] = definitions
+		delete(m, "$defs")
+	}
+	if prefix, ok := m["prefixItems"]; ok {
+		// Draft-07 gives positional items as an array of items, with
+		// the items for the rest of the array as additionalItems.
+		if _, ok := m["additionalItems"]; ok {
+			return fmt.Errorf("cannot convert prefixItems with additionalItems to draft-07")
+		}
+		if rest, ok := m["items"]; ok {
+			m["additionalItems"] = rest
+		}
+		m["items"] = prefix
+		delete(m, "prefixItems")
+	}
+	toDraft07Dependencies(m)
+	// additionalItems only means something for tuples.
+	if _, ok := m["items"].([]interface{}); !ok {
+		delete(m, "additionalItems")
+	}
+	for k := range m {
+		if draft07Keywords[k] {
+			continue
+		}
+		if knownExtras[k] && !extensionKeywords[k] {
+			return fmt.Errorf("cannot convert %s to draft-07", k)
+		}
+		delete(m, k)
+	}
+
+	var err error
+	eachSubschema(m, func(sub map[string]interface{}) {
+		if err == nil {
+			err = toDraft07(sub)
+		}
+	})
+	return err
+}
+
+// toDraft07Dependencies merges the dependentRequired and dependentSchemas
+// keywords of m into dependencies. Where a property has more than one
+// dependency, they are combined with allOf.
+func toDraft07Dependencies(m map[string]interface{}) {
+	deps, _ := m["dependencies"].(map[string]interface{})
+	for _, k := range []string{"dependentRequired", "dependentSchemas"} {
+		extra, ok := m[k].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if deps == nil {
+			deps = make(map[string]interface{})
+		}
+		for name, dep := range extra {
+			if existing, ok := deps[name]; ok {
+				dep = map[string]interface{}{
+					"allOf": []interface{}{dependencySchema(existing), dependencySchema(dep)},
+				}
+			}
+			deps[name] = dep
+		}
+		m["dependencies"] = deps
+		delete(m, k)
+	}
+}
+
+// dependencySchema returns dep, a value of dependencies, as a schema.
+func dependencySchema(dep interface{}) interface{} {
+	if names, ok := dep.([]interface{}); ok {
+		return map[string]interface{}{"required": names}
+	}
+	return dep
+}
@@ -0,0 +1,136 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"encoding/json"
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type HelmSuite struct{}
+
+var _ = gc.Suite(HelmSuite{})
+
+func (HelmSuite) TestToHelmValuesSchema(c *gc.C) {
+	s := &Schema{
+		ID:   "https://example.com/values.json",
+		Type: []Type{ObjectType},
+		Properties: map[string]*Schema{
+			"replicas": {
+				Type:             []Type{IntegerType},
+				Minimum:          Float(0),
+				ExclusiveMinimum: Bool(true),
+				Maximum:          Float(10),
+				ExclusiveMaximum: Bool(false),
+				Example:          3,
+				Immutable:        true,
+			},
+			"image": {
+				Type:     []Type{StringType},
+				Secret:   true,
+				Singular: "image",
+				Example:  "nginx",
+				Examples: []interface{}{"redis"},
+			},
+			"tag": {
+				Type:     []Type{StringType},
+				Example:  "latest",
+				Examples: []interface{}{"latest", "1.25"},
+			},
+		},
+		Order: []string{"image", "replicas", "tag"},
+	}
+	b, err := ToHelmValuesSchema(s)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var m map[string]interface{}
+	err = json.Unmarshal(b, &m)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(m, jc.DeepEquals, map[string]interface{}{
+		"$schema": "http://json-schema.org/draft-07/schema#",
+		"$id":     "https://example.com/values.json",
+		"type":    "object",
+		// Objects without additionalProperties reject unknown properties.
+		"additionalProperties": false,
+		"properties": map[string]interface{}{
+			"replicas": map[string]interface{}{
+				"type":             "integer",
+				"exclusiveMinimum": float64(0),
+				"maximum":          float64(10),
+				"examples":         []interface{}{float64(3)},
+			},
+			// The example is added to any examples already given.
+			"image": map[string]interface{}{
+				"type":     "string",
+				"examples": []interface{}{"redis", "nginx"},
+			},
+			"tag": map[string]interface{}{
+				"type":     "string",
+				"examples": []interface{}{"latest", "1.25"},
+			},
+		},
+	})
+}
+
+func (HelmSuite) TestToHelmValuesSchemaLaterDrafts(c *gc.C) {
+	s, err := FromJSON(strings.NewReader(`{
+  "type": "object",
+  "$defs": {"port": {"type": "integer"}},
+  "properties": {
+    "port": {"$ref": "#/$defs/port"},
+    "pair": {
+      "type": "array",
+      "prefixItems": [{"type": "string"}, {"$ref": "#/properties/pair/items"}],
+      "items": {"type": "boolean"}
+    },
+    "first": {"$ref": "#/properties/pair/prefixItems/0"}
+  },
+  "dependentRequired": {"port": ["pair"]},
+  "dependentSchemas": {"port": {"required": ["first"]}}
+}`))
+	c.Assert(err, jc.ErrorIsNil)
+	b, err := ToHelmValuesSchema(s)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var m map[string]interface{}
+	err = json.Unmarshal(b, &m)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(m["definitions"], jc.DeepEquals, map[string]interface{}{
+		"port": map[string]interface{}{"type": "integer"},
+	})
+	props := m["properties"].(map[string]interface{})
+	c.Check(props["port"].(map[string]interface{})["$ref"], gc.Equals, "#/definitions/port")
+	c.Check(props["first"].(map[string]interface{})["$ref"], gc.Equals, "#/properties/pair/items/0")
+	c.Check(props["pair"], jc.DeepEquals, map[string]interface{}{
+		"type": "array",
+		"items": []interface{}{
+			map[string]interface{}{"type": "string"},
+			map[string]interface{}{"$ref": "#/properties/pair/additionalItems", "additionalProperties": false},
+		},
+		"additionalItems": map[string]interface{}{"type": "boolean"},
+	})
+	c.Check(m["dependencies"], jc.DeepEquals, map[string]interface{}{
+		"port": map[string]interface{}{
+			"allOf": []interface{}{
+				map[string]interface{}{"required": []interface{}{"pair"}},
+				map[string]interface{}{"required": []interface{}{"first"}, "additionalProperties": false},
+			},
+		},
+	})
+	c.Check(m["$defs"], gc.IsNil)
+	c.Check(m["dependentRequired"], gc.IsNil)
+}
+
+func (HelmSuite) TestToHelmValuesSchemaUnsupported(c *gc.C) {
+	_, err := ToHelmValuesSchema(&Schema{
+		Type: []Type{ObjectType},
+		Properties: map[string]*Schema{
+			"a": {Type: []Type{ObjectType}, UnevaluatedProperties: &Schema{Not: &Schema{}}},
+		},
+	})
+	c.Check(err, gc.ErrorMatches, `cannot convert unevaluatedProperties to draft-07`)
+}
@@ -0,0 +1,94 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// ErrHostNotAllowed is matched by errors returned when a schema may not be
+// loaded from a uri because of a HostPolicy.
+var ErrHostNotAllowed = errors.New("host not allowed")
+
+// HostPolicy restricts the uris that schemas may be loaded from, so that
+// a schema supplied by an untrusted party can't use references to make
+// requests to arbitrary hosts. By default every uri is denied: the zero
+// HostPolicy allows nothing.
+type HostPolicy struct {
+	// Schemes holds the uri schemes which may be used. If it is empty,
+	// only "https" may be used.
+	Schemes []string
+
+	// AllowHosts holds the hosts which may be contacted. A name starting
+	// with "*." matches any subdomain of the rest of the name, so
+	// "*.example.com" matches "schemas.example.com" but not
+	// "example.com". Ports are not compared.
+	AllowHosts []string
+
+	// DenyHosts holds hosts which may not be contacted, even if they are
+	// matched by AllowHosts. Names are matched as for AllowHosts.
+	DenyHosts []string
+}
+
+// Check returns an error matching ErrHostNotAllowed if p doesn't allow
+// schemas to be loaded from uri.
+func (p HostPolicy) Check(uri string) error {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return err
+	}
+	schemes := p.Schemes
+	if len(schemes) == 0 {
+		schemes = []string{"https"}
+	}
+	if !containsFold(schemes, u.Scheme) {
+		return fmt.Errorf("cannot load %s: scheme %q: %w", uri, u.Scheme, ErrHostNotAllowed)
+	}
+	host := strings.ToLower(u.Hostname())
+	if !matchHost(p.AllowHosts, host) || matchHost(p.DenyHosts, host) {
+		return fmt.Errorf("cannot load %s: %w", uri, ErrHostNotAllowed)
+	}
+	return nil
+}
+
+// RestrictLoader returns a Loader which loads schemas using l only from the
+// uris allowed by p.
+func RestrictLoader(l Loader, p HostPolicy) Loader {
+	return LoaderFunc(func(uri string) (*Schema, error) {
+		if err := p.Check(uri); err != nil {
+			return nil, err
+		}
+		return l.Load(uri)
+	})
+}
+
+// matchHost reports whether host is matched by any of the given patterns.
+func matchHost(patterns []string, host string) bool {
+	if host == "" {
+		return false
+	}
+	for _, pattern := range patterns {
+		pattern = strings.ToLower(pattern)
+		if strings.HasPrefix(pattern, "*.") {
+			if strings.HasSuffix(host, pattern[1:]) {
+				return true
+			}
+		} else if host == pattern {
+			return true
+		}
+	}
+	return false
+}
+
+func containsFold(list []string, s string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, s) {
+			return true
+		}
+	}
+	return false
+}
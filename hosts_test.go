@@ -0,0 +1,89 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"errors"
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type HostsSuite struct{}
+
+var _ = gc.Suite(HostsSuite{})
+
+func (HostsSuite) TestCheck(c *gc.C) {
+	policy := HostPolicy{
+		AllowHosts: []string{"schemas.example.com", "*.charmhub.io"},
+		DenyHosts:  []string{"internal.charmhub.io"},
+	}
+	for i, test := range []struct {
+		policy  HostPolicy
+		uri     string
+		allowed bool
+	}{{
+		uri: "https://schemas.example.com/common.json",
+	}, {
+		policy:  policy,
+		uri:     "https://schemas.example.com/common.json",
+		allowed: true,
+	}, {
+		policy:  policy,
+		uri:     "https://Schemas.Example.com:8443/common.json",
+		allowed: true,
+	}, {
+		policy:  policy,
+		uri:     "https://api.charmhub.io/schema.json",
+		allowed: true,
+	}, {
+		policy: policy,
+		uri:    "https://charmhub.io/schema.json",
+	}, {
+		policy: policy,
+		uri:    "https://internal.charmhub.io/schema.json",
+	}, {
+		policy: policy,
+		uri:    "https://evilcharmhub.io/schema.json",
+	}, {
+		policy: policy,
+		uri:    "http://schemas.example.com/common.json",
+	}, {
+		policy: policy,
+		uri:    "file:///etc/passwd",
+	}, {
+		policy: policy,
+		uri:    "https://169.254.169.254/latest/meta-data",
+	}, {
+		policy:  HostPolicy{Schemes: []string{"http", "https"}, AllowHosts: []string{"localhost"}},
+		uri:     "http://localhost:8080/schema.json",
+		allowed: true,
+	}} {
+		c.Logf("test %d: %s", i, test.uri)
+		err := test.policy.Check(test.uri)
+		if test.allowed {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(errors.Is(err, ErrHostNotAllowed), jc.IsTrue, gc.Commentf("%v", err))
+		}
+	}
+}
+
+func (HostsSuite) TestRestrictLoader(c *gc.C) {
+	var loaded []string
+	loader := RestrictLoader(mapLoader(&loaded), HostPolicy{
+		AllowHosts: []string{"schemas.example.com"},
+	})
+	_, err := FromJSONWithOptions(strings.NewReader(loaderSchema), DecodeOptions{Loader: loader})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(loaded, gc.HasLen, 2)
+
+	loaded = nil
+	_, err = FromJSONWithOptions(strings.NewReader(`{
+		"properties": {"a": {"$ref": "https://169.254.169.254/latest/meta-data#"}}
+	}`), DecodeOptions{Loader: loader})
+	c.Check(err, gc.ErrorMatches, `cannot resolve \$ref ".*": cannot load https://169.254.169.254/latest/meta-data: host not allowed`)
+	c.Check(loaded, gc.HasLen, 0)
+}
@@ -0,0 +1,110 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// HTTPLoader is a Loader which fetches schemas over http or https, such as
+// published meta-schemas and shared components hosted on internal servers.
+// Each schema is fetched once, and cached by uri for the life of the
+// loader.
+//
+// Schemas are only fetched from the uris allowed by Policy, which are
+// checked again whenever a request is redirected. As the zero HostPolicy
+// allows nothing, Policy must be set for the loader to be of any use.
+type HTTPLoader struct {
+	// Policy restricts the uris that schemas may be fetched from.
+	Policy HostPolicy
+
+	// HTTPClient holds the client used to make requests. If it is nil, a
+	// client using Proxy is used.
+	HTTPClient *http.Client
+
+	// Proxy returns the proxy to use for a request, as described by
+	// http.Transport.Proxy, when HTTPClient is nil. If it is nil, the proxy
+	// is taken from the environment.
+	Proxy func(*http.Request) (*url.URL, error)
+
+	// Timeout limits the time taken by each request, including reading
+	// the response. If it is zero, there is no limit beyond any imposed by
+	// HTTPClient.
+	Timeout time.Duration
+
+	// MaxSize holds the size of the largest schema accepted, or zero to
+	// use DefaultMaxSchemaSize.
+	MaxSize int64
+
+	mu    sync.Mutex
+	cache map[string]*Schema
+}
+
+// Load implements Loader.
+func (l *HTTPLoader) Load(uri string) (*Schema, error) {
+	l.mu.Lock()
+	s, ok := l.cache[uri]
+	l.mu.Unlock()
+	if ok {
+		return s, nil
+	}
+	data, err := l.fetch(uri)
+	if err != nil {
+		return nil, err
+	}
+	// YAML is a superset of json, so this handles both.
+	s, err = FromYAML(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse schema from %s: %v", uri, err)
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.cache == nil {
+		l.cache = make(map[string]*Schema)
+	}
+	l.cache[uri] = s
+	return s, nil
+}
+
+// fetch returns the document at uri, if l.Policy allows it.
+func (l *HTTPLoader) fetch(uri string) ([]byte, error) {
+	if err := l.Policy.Check(uri); err != nil {
+		return nil, err
+	}
+	data, _, err := getHTTP(context.Background(), l.client(), uri, "", l.Timeout, l.MaxSize)
+	if err != nil {
+		return nil, fmt.Errorf("cannot load %s: %w", uri, err)
+	}
+	return data, nil
+}
+
+// client returns the client to make requests with, which checks that
+// redirects are allowed by l.Policy.
+func (l *HTTPLoader) client() *http.Client {
+	base := l.HTTPClient
+	if base == nil {
+		base = proxyClient(l.Proxy)
+	}
+	client := *base
+	client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		if err := l.Policy.Check(req.URL.String()); err != nil {
+			return err
+		}
+		if base.CheckRedirect != nil {
+			return base.CheckRedirect(req, via)
+		}
+		if len(via) >= 10 {
+			return errors.New("stopped after 10 redirects")
+		}
+		return nil
+	}
+	return &client
+}
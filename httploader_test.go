@@ -0,0 +1,109 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type HTTPLoaderSuite struct{}
+
+var _ = gc.Suite(HTTPLoaderSuite{})
+
+// newLoaderServer returns a server serving the schemas in loaderDocs at
+// their paths, and counting the requests for each.
+func newLoaderServer(requests map[string]int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		requests[req.URL.Path]++
+		switch req.URL.Path {
+		case "/redirect":
+			http.Redirect(w, req, "http://localhost"+req.URL.RawQuery, http.StatusFound)
+			return
+		case "/slow":
+			time.Sleep(200 * time.Millisecond)
+		}
+		doc, ok := loaderDocs["https://schemas.example.com"+req.URL.Path]
+		if !ok {
+			http.NotFound(w, req)
+			return
+		}
+		w.Write([]byte(doc))
+	}))
+}
+
+func newHTTPLoader() *HTTPLoader {
+	return &HTTPLoader{
+		Policy: HostPolicy{
+			Schemes:    []string{"http"},
+			AllowHosts: []string{"127.0.0.1"},
+		},
+	}
+}
+
+func (HTTPLoaderSuite) TestLoad(c *gc.C) {
+	requests := make(map[string]int)
+	srv := newLoaderServer(requests)
+	defer srv.Close()
+	loader := newHTTPLoader()
+
+	// The ids of the schemas are those of the documents on the server.
+	schema := strings.Replace(loaderSchema, "https://schemas.example.com", srv.URL, 1)
+	s, err := FromJSONWithOptions(strings.NewReader(schema), DecodeOptions{Loader: loader})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(requests, jc.DeepEquals, map[string]int{
+		"/common.json":     1,
+		"/units/time.json": 1,
+	})
+	c.Check(s.Validate(map[string]interface{}{"timeout": -1}), gc.ErrorMatches, `timeout: numeric value is less than the minimum`)
+
+	// Loaded schemas are cached.
+	_, err = FromJSONWithOptions(strings.NewReader(schema), DecodeOptions{Loader: loader})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(requests["/common.json"], gc.Equals, 1)
+
+	_, err = loader.Load(srv.URL + "/missing.json")
+	c.Check(err, gc.ErrorMatches, `cannot load http://127.0.0.1:[0-9]+/missing.json: 404 Not Found`)
+}
+
+func (HTTPLoaderSuite) TestLoadPolicy(c *gc.C) {
+	requests := make(map[string]int)
+	srv := newLoaderServer(requests)
+	defer srv.Close()
+
+	// By default, nothing may be loaded.
+	_, err := (&HTTPLoader{}).Load(srv.URL + "/common.json")
+	c.Check(errors.Is(err, ErrHostNotAllowed), jc.IsTrue)
+	c.Check(requests, gc.HasLen, 0)
+
+	// Redirects are checked too.
+	loader := newHTTPLoader()
+	port := srv.URL[strings.LastIndex(srv.URL, ":"):]
+	_, err = loader.Load(srv.URL + "/redirect?" + port + "/common.json")
+	c.Check(errors.Is(err, ErrHostNotAllowed), jc.IsTrue, gc.Commentf("%v", err))
+	c.Check(requests["/redirect"], gc.Equals, 1)
+	c.Check(requests["/common.json"], gc.Equals, 0)
+}
+
+func (HTTPLoaderSuite) TestLoadLimits(c *gc.C) {
+	requests := make(map[string]int)
+	srv := newLoaderServer(requests)
+	defer srv.Close()
+
+	loader := newHTTPLoader()
+	loader.MaxSize = 10
+	_, err := loader.Load(srv.URL + "/common.json")
+	c.Check(err, gc.ErrorMatches, `cannot load .*: response exceeds 10 bytes`)
+
+	loader = newHTTPLoader()
+	loader.Timeout = 10 * time.Millisecond
+	_, err = loader.Load(srv.URL + "/slow")
+	c.Check(err, gc.ErrorMatches, `cannot load .*: .*context deadline exceeded.*`)
+}
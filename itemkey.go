@@ -0,0 +1,136 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import "fmt"
+
+// keyedItems returns the keys of the items of arr, as identified by the
+// item-key keyword of s, in order, and the items keyed by them. It returns
+// false if any item isn't an object with a simple value for the key.
+func keyedItems(s *Schema, arr []interface{}) ([]string, map[string]interface{}, bool) {
+	keys := make([]string, 0, len(arr))
+	items := make(map[string]interface{}, len(arr))
+	for _, item := range arr {
+		key, ok := itemKey(s, item)
+		if !ok {
+			return nil, nil, false
+		}
+		if _, ok := items[key]; !ok {
+			keys = append(keys, key)
+		}
+		items[key] = item
+	}
+	return keys, items, true
+}
+
+// itemKey returns the key of item, as identified by the item-key keyword
+// of s.
+func itemKey(s *Schema, item interface{}) (string, bool) {
+	obj, ok := asObject(item)
+	if !ok {
+		return "", false
+	}
+	switch v := normalizeValue(obj[s.ItemKey]).(type) {
+	case string, float64, bool:
+		return formatFlatValue(v), true
+	}
+	return "", false
+}
+
+// checkItemKeys returns an error if any item of arr lacks the key named by
+// the item-key keyword of s, or has the same key as an earlier item.
+func checkItemKeys(s *Schema, arr []interface{}) error {
+	seen := make(map[string]int)
+	for i, item := range arr {
+		key, ok := itemKey(s, item)
+		if !ok {
+			return fmt.Errorf("item %d has no %s", i, s.ItemKey)
+		}
+		if j, ok := seen[key]; ok {
+			return fmt.Errorf("items %d and %d have the same %s %q", j, i, s.ItemKey, key)
+		}
+		seen[key] = i
+	}
+	return nil
+}
+
+// keyedItemPath returns the path of the item with the given key in the
+// array found at path, such as "endpoints[name=api]".
+func keyedItemPath(path, name, key string) string {
+	return fmt.Sprintf("%s[%s=%s]", path, name, key)
+}
+
+// MergeDocs returns the document dst, described by s, with the values set
+// in src merged into it: objects are merged property by property, and
+// arrays whose schema has the item-key keyword are merged item by item,
+// with items in src replacing or merging into those in dst with the same
+// key and any others appended. Any other value in src replaces the one in
+// dst. Neither document is modified.
+func MergeDocs(s *Schema, dst, src map[string]interface{}) map[string]interface{} {
+	out, _ := mergeValues(s, s, dst, src).(map[string]interface{})
+	return out
+}
+
+func mergeValues(root, s *Schema, dst, src interface{}) interface{} {
+	if s != nil {
+		s = derefLocal(root, s)
+	}
+	switch src := src.(type) {
+	case map[string]interface{}:
+		dstObj, ok := dst.(map[string]interface{})
+		if !ok {
+			return src
+		}
+		out := make(map[string]interface{}, len(dstObj)+len(src))
+		for k, v := range dstObj {
+			out[k] = v
+		}
+		if s != nil {
+			s = s.WithVariants(out)
+		}
+		for k, v := range src {
+			var ps *Schema
+			if s != nil {
+				if schemas := propertySchemas(s, k); len(schemas) > 0 {
+					ps = schemas[0]
+				}
+			}
+			if old, ok := out[k]; ok {
+				out[k] = mergeValues(root, ps, old, v)
+			} else {
+				out[k] = v
+			}
+		}
+		return out
+	case []interface{}:
+		dstArr, ok := dst.([]interface{})
+		if !ok || s == nil || s.ItemKey == "" {
+			return src
+		}
+		dstKeys, dstItems, ok := keyedItems(s, dstArr)
+		if !ok {
+			return src
+		}
+		srcKeys, srcItems, ok := keyedItems(s, src)
+		if !ok {
+			return src
+		}
+		item := itemSchema(s, 0)
+		out := make([]interface{}, 0, len(dstKeys)+len(srcKeys))
+		for _, key := range dstKeys {
+			if v, ok := srcItems[key]; ok {
+				out = append(out, mergeValues(root, item, dstItems[key], v))
+			} else {
+				out = append(out, dstItems[key])
+			}
+		}
+		for _, key := range srcKeys {
+			if _, ok := dstItems[key]; !ok {
+				out = append(out, srcItems[key])
+			}
+		}
+		return out
+	}
+	return src
+}
@@ -0,0 +1,107 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type ItemKeySuite struct{}
+
+var _ = gc.Suite(ItemKeySuite{})
+
+const itemKeySchema = `
+type: object
+properties:
+  endpoints:
+    type: array
+    item-key: name
+    items:
+      type: object
+      properties:
+        name:
+          type: string
+        port:
+          type: integer
+        token:
+          type: string
+          secret: true
+  tags:
+    type: array
+    items:
+      type: string
+`
+
+func endpoint(name string, port int) map[string]interface{} {
+	return map[string]interface{}{"name": name, "port": port}
+}
+
+func (ItemKeySuite) TestValidate(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(itemKeySchema))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.Properties["endpoints"].ItemKey, gc.Equals, "name")
+
+	c.Check(s.Validate(map[string]interface{}{
+		"endpoints": []interface{}{endpoint("api", 17070), endpoint("web", 443)},
+	}), jc.ErrorIsNil)
+	c.Check(s.Validate(map[string]interface{}{
+		"endpoints": []interface{}{endpoint("api", 17070), endpoint("api", 443)},
+	}), gc.ErrorMatches, `endpoints: items 0 and 1 have the same name "api"`)
+	c.Check(s.Validate(map[string]interface{}{
+		"endpoints": []interface{}{map[string]interface{}{"port": 443}},
+	}), gc.ErrorMatches, `endpoints: item 0 has no name`)
+}
+
+func (ItemKeySuite) TestCompareDocs(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(itemKeySchema))
+	c.Assert(err, jc.ErrorIsNil)
+	before := map[string]interface{}{
+		"endpoints": []interface{}{endpoint("api", 17070), endpoint("web", 443), endpoint("metrics", 9090)},
+		"tags":      []interface{}{"a", "b"},
+	}
+	after := map[string]interface{}{
+		"endpoints": []interface{}{endpoint("metrics", 9091), endpoint("ssh", 22), endpoint("api", 17070)},
+		"tags":      []interface{}{"b", "a"},
+	}
+	// Items which are only in one document are compared property by
+	// property, as objects are.
+	c.Check(CompareDocs(s, before, after), jc.DeepEquals, []DocChange{
+		{Path: "endpoints[name=web].name", Kind: ChangeRemoved, Type: StringType, Old: "web"},
+		{Path: "endpoints[name=web].port", Kind: ChangeRemoved, Type: IntegerType, Old: "443"},
+		{Path: "endpoints[name=metrics].port", Kind: ChangeModified, Type: IntegerType, Old: "9090", New: "9091"},
+		{Path: "endpoints[name=ssh].name", Kind: ChangeAdded, Type: StringType, New: "ssh"},
+		{Path: "endpoints[name=ssh].port", Kind: ChangeAdded, Type: IntegerType, New: "22"},
+		{Path: "tags", Kind: ChangeModified, Type: ArrayType, Old: `["a","b"]`, New: `["b","a"]`},
+	})
+}
+
+func (ItemKeySuite) TestMergeDocs(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(itemKeySchema))
+	c.Assert(err, jc.ErrorIsNil)
+	dst := map[string]interface{}{
+		"endpoints": []interface{}{endpoint("api", 17070), endpoint("web", 443)},
+		"tags":      []interface{}{"a", "b"},
+	}
+	src := map[string]interface{}{
+		"endpoints": []interface{}{
+			map[string]interface{}{"name": "web", "token": "s3cret"},
+			endpoint("ssh", 22),
+		},
+		"tags": []interface{}{"c"},
+	}
+	c.Check(MergeDocs(s, dst, src), jc.DeepEquals, map[string]interface{}{
+		"endpoints": []interface{}{
+			endpoint("api", 17070),
+			map[string]interface{}{"name": "web", "port": 443, "token": "s3cret"},
+			endpoint("ssh", 22),
+		},
+		"tags": []interface{}{"c"},
+	})
+	// Neither document is modified.
+	c.Check(dst["endpoints"], jc.DeepEquals, []interface{}{endpoint("api", 17070), endpoint("web", 443)})
+	c.Check(src["endpoints"].([]interface{})[0], jc.DeepEquals, map[string]interface{}{"name": "web", "token": "s3cret"})
+}
@@ -0,0 +1,169 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import "strings"
+
+// Keys returns the paths of all the leaf properties of s in dotted form, such
+// as "auth.username", in schema order. The items of arrays of objects are
+// written as [*], as in "nodes[*].address", while arrays of simple values are
+// themselves leaves. Properties introduced by allOf and variants are
+// included, and references to definitions are followed.
+func (s *Schema) Keys() []string {
+	var keys []string
+	for _, leaf := range s.leaves() {
+		keys = append(keys, dottedKey(leaf.path))
+	}
+	return keys
+}
+
+// Pointers returns the same paths as Keys in json pointer form, such as
+// "/nodes/*/address".
+func (s *Schema) Pointers() []string {
+	var pointers []string
+	for _, leaf := range s.leaves() {
+		pointers = append(pointers, pointerKey(leaf.path))
+	}
+	return pointers
+}
+
+// namedSchema holds a property name and its schema, and whether the object
+// holding it requires it.
+type namedSchema struct {
+	name     string
+	schema   *Schema
+	required bool
+}
+
+// leaf holds a leaf property found by leaves.
+type leaf struct {
+	// path holds the path of the property as a list of property names,
+	// with "*" standing for any array item.
+	path []string
+
+	// schema holds the schema of the property, with any reference to a
+	// definition followed.
+	schema *Schema
+
+	// required reports whether the property is required by the object
+	// holding it.
+	required bool
+}
+
+// leaves returns every leaf property of s.
+func (s *Schema) leaves() []leaf {
+	root := s
+	var leaves []leaf
+	seen := make(map[string]bool)
+	visiting := make(map[*Schema]bool)
+	var walk func(s *Schema, path []string, required bool)
+	walk = func(s *Schema, path []string, required bool) {
+		s = derefLocal(root, s)
+		if visiting[s] {
+			return
+		}
+		visiting[s] = true
+		defer delete(visiting, s)
+
+		if props := keyProperties(root, s); len(props) > 0 {
+			for _, p := range props {
+				walk(p.schema, appendPath(path, p.name), p.required)
+			}
+			return
+		}
+		if item := arrayItem(s); item != nil && len(keyProperties(root, derefLocal(root, item))) > 0 {
+			walk(item, appendPath(path, "*"), false)
+			return
+		}
+		if key := dottedKey(path); len(path) > 0 && !seen[key] {
+			seen[key] = true
+			leaves = append(leaves, leaf{path: path, schema: s, required: required})
+		}
+	}
+	walk(s, nil, false)
+	return leaves
+}
+
+// keyProperties returns the properties of objects described by s, including
+// those introduced by allOf and by the variants of its properties.
+func keyProperties(root, s *Schema) []namedSchema {
+	var props []namedSchema
+	add := func(s *Schema) {
+		required := make(map[string]bool)
+		for _, name := range s.Required {
+			required[name] = true
+		}
+		for _, name := range orderedProperties(s) {
+			props = append(props, namedSchema{name, s.Properties[name], required[name]})
+		}
+	}
+	add(s)
+	for _, sub := range s.AllOf {
+		add(derefLocal(root, sub))
+	}
+	for _, name := range orderedProperties(s) {
+		for _, variant := range sortedSchemas(s.Properties[name].Variants) {
+			add(variant)
+		}
+	}
+	return props
+}
+
+// arrayItem returns the schema of every item of arrays described by s, or
+// nil if s doesn't describe an array with a single item schema.
+func arrayItem(s *Schema) *Schema {
+	if !hasType(s, ArrayType) || s.Items == nil || s.Items.TupleMode || len(s.Items.Schemas) != 1 || len(s.PrefixItems) > 0 {
+		return nil
+	}
+	return s.Items.Schemas[0]
+}
+
+// derefLocal returns the schema that s refers to, following any chain of
+// references, if it is a reference to a schema within root, and s itself
+// otherwise.
+func derefLocal(root, s *Schema) *Schema {
+	seen := make(map[*Schema]bool)
+	for s.ref() != "" && !seen[s] {
+		seen[s] = true
+		target := refTarget(root, s)
+		if target == nil {
+			break
+		}
+		s = target
+	}
+	return s
+}
+
+// appendPath returns a new path with name appended to path.
+func appendPath(path []string, name string) []string {
+	return append(path[:len(path):len(path)], name)
+}
+
+// dottedKey returns path in dotted form, as used by Keys.
+func dottedKey(path []string) string {
+	var b strings.Builder
+	for i, name := range path {
+		switch {
+		case name == "*":
+			b.WriteString("[*]")
+		case i > 0:
+			b.WriteString(".")
+			fallthrough
+		default:
+			b.WriteString(name)
+		}
+	}
+	return b.String()
+}
+
+// pointerKey returns path as a json pointer, as used by Pointers.
+func pointerKey(path []string) string {
+	var b strings.Builder
+	escape := strings.NewReplacer("~", "~0", "/", "~1")
+	for _, name := range path {
+		b.WriteString("/")
+		b.WriteString(escape.Replace(name))
+	}
+	return b.String()
+}
@@ -0,0 +1,87 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type KeysSuite struct{}
+
+var _ = gc.Suite(KeysSuite{})
+
+const keysSchema = `
+type: object
+order: [name, auth]
+definitions:
+  node:
+    type: object
+    properties:
+      address: {type: string}
+      port: {type: integer}
+properties:
+  name: {type: string}
+  tags: {type: array, items: {type: string}}
+  auth:
+    type: object
+    properties:
+      type:
+        type: string
+        variants:
+          userpass:
+            properties:
+              username: {type: string}
+              password: {type: string}
+  nodes:
+    type: array
+    items: {$ref: "#/definitions/node"}
+  primary: {$ref: "#/definitions/node"}
+  a/b: {type: string}
+`
+
+func (KeysSuite) TestKeys(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(keysSchema))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.Keys(), jc.DeepEquals, []string{
+		"name",
+		"auth.type",
+		"auth.password",
+		"auth.username",
+		"a/b",
+		"nodes[*].address",
+		"nodes[*].port",
+		"primary.address",
+		"primary.port",
+		"tags",
+	})
+}
+
+func (KeysSuite) TestPointers(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(keysSchema))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.Pointers(), jc.DeepEquals, []string{
+		"/name",
+		"/auth/type",
+		"/auth/password",
+		"/auth/username",
+		"/a~1b",
+		"/nodes/*/address",
+		"/nodes/*/port",
+		"/primary/address",
+		"/primary/port",
+		"/tags",
+	})
+}
+
+func (KeysSuite) TestKeysCycle(c *gc.C) {
+	s := &Schema{Type: []Type{ObjectType}}
+	s.Properties = map[string]*Schema{
+		"name":  {Type: []Type{StringType}},
+		"child": s,
+	}
+	c.Check(s.Keys(), jc.DeepEquals, []string{"name"})
+}
@@ -0,0 +1,203 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Lint returns a description of each contradiction found in s and the
+// schemas nested within it: constraints that no value of the kind they
+// describe can satisfy, such as a minLength greater than the maxLength, an
+// enum none of whose values match the pattern, or a required property that
+// isn't allowed by the schema. Each description starts with the json
+// pointer of the schema holding the contradiction, such as
+// "#/properties/name". Such a schema is almost certainly a mistake, so
+// Lint is best called when the schema is loaded.
+func (s *Schema) Lint() []string {
+	// The schemas composed into others, merged into them or which
+	// constrain them are allowed the properties of the schemas they are
+	// applied with.
+	composed := make(map[*Schema]bool)
+	walkSchema(s, func(sub *Schema) {
+		for _, l := range [][]*Schema{
+			sub.AllOf, sub.AnyOf, sub.OneOf,
+			{sub.Not, sub.If, sub.Then, sub.Else, sub.Contains},
+			sortedSchemas(sub.Variants), sortedSchemas(sub.Profiles),
+		} {
+			for _, cs := range l {
+				composed[cs] = true
+			}
+		}
+		for _, l := range sub.dependentSchemas() {
+			for _, cs := range l {
+				composed[cs] = true
+			}
+		}
+	})
+	var problems []string
+	walkSchemaPointers(s, func(sub *Schema, pointer string) {
+		for _, p := range contradictions(s, sub, !composed[sub]) {
+			problems = append(problems, pointer+": "+p)
+		}
+	})
+	return problems
+}
+
+// Check returns an error describing the contradictions found in s by
+// Lint, or nil if there are none.
+func (s *Schema) Check() error {
+	problems := s.Lint()
+	if len(problems) == 0 {
+		return nil
+	}
+	return errors.New("unsatisfiable schema: " + strings.Join(problems, "; "))
+}
+
+// contradictions returns a description of each contradiction in the
+// keywords of s, found within root. Required properties are only checked if
+// checkRequired is set.
+func contradictions(root, s *Schema, checkRequired bool) []string {
+	var problems []string
+	bounds := func(minName string, min *int, maxName string, max *int) {
+		if min != nil && max != nil && *min > *max {
+			problems = append(problems, fmt.Sprintf("%s %d is greater than %s %d", minName, *min, maxName, *max))
+		}
+	}
+	bounds("minLength", s.MinLength, "maxLength", s.MaxLength)
+	bounds("minItems", s.MinItems, "maxItems", s.MaxItems)
+	bounds("minProperties", s.MinProperties, "maxProperties", s.MaxProperties)
+	if s.Contains != nil {
+		bounds("minContains", s.MinContains, "maxContains", s.MaxContains)
+	}
+	lo, loExclusive, hasLo := s.lowerBound()
+	hi, hiExclusive, hasHi := s.upperBound()
+	if hasLo && hasHi {
+		switch {
+		case lo > hi:
+			problems = append(problems, fmt.Sprintf("%s %v is greater than %s %v",
+				boundKeyword("minimum", loExclusive), lo, boundKeyword("maximum", hiExclusive), hi))
+		case lo == hi && (loExclusive || hiExclusive):
+			problems = append(problems, fmt.Sprintf("exclusive bounds of %v allow no value", lo))
+		}
+	}
+	if s.Pattern != nil && len(s.Enum) > 0 {
+		matched := false
+		for _, v := range s.Enum {
+			if str, ok := v.(string); !ok || s.Pattern.MatchString(str) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			problems = append(problems, fmt.Sprintf("no enum value matches pattern %q", s.Pattern.String()))
+		}
+	}
+	if checkRequired && (len(s.Type) == 0 || hasType(s, ObjectType)) {
+		for _, name := range undeclaredRequired(root, s) {
+			problems = append(problems, fmt.Sprintf("required property %q is not allowed by the schema", name))
+		}
+	}
+	return problems
+}
+
+// boundKeyword returns the keyword giving a bound, named by the inclusive
+// keyword, such as minimum, or its exclusive form.
+func boundKeyword(inclusive string, exclusive bool) string {
+	if !exclusive {
+		return inclusive
+	}
+	return "exclusive" + strings.ToUpper(inclusive[:1]) + inclusive[1:]
+}
+
+// undeclaredRequired returns the required properties of s, found within
+// root, that objects described by s may not hold, because neither s nor
+// any schema composed into it gives a schema for them and s doesn't allow
+// additional or unevaluated properties.
+func undeclaredRequired(root, s *Schema) []string {
+	if s.UnevaluatedProperties != nil {
+		return nil
+	}
+	composed := composedSchemas(root, s, true)
+	var names []string
+	for _, name := range s.Required {
+		declared := false
+		for _, cs := range composed {
+			if len(propertySchemas(cs, name)) > 0 {
+				declared = true
+				break
+			}
+		}
+		if !declared {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// walkSchemaPointers calls fn for s and every schema nested within it, with
+// the json pointer to it from s, such as "#/properties/name". Each schema
+// is visited once, at the first place it is found, even if it is reachable
+// from several places.
+func walkSchemaPointers(s *Schema, fn func(sub *Schema, pointer string)) {
+	seen := make(map[*Schema]bool)
+	var walk func(s *Schema, pointer string)
+	walk = func(s *Schema, pointer string) {
+		if s == nil || seen[s] {
+			return
+		}
+		seen[s] = true
+		fn(s, pointer)
+		sub := func(keyword string, s *Schema) {
+			walk(s, pointer+"/"+keyword)
+		}
+		named := func(keyword string, m map[string]*Schema) {
+			for _, name := range sortedKeys(m) {
+				walk(m[name], pointer+"/"+keyword+"/"+escapePointer(name))
+			}
+		}
+		indexed := func(keyword string, l []*Schema) {
+			for i, s := range l {
+				walk(s, pointer+"/"+keyword+"/"+strconv.Itoa(i))
+			}
+		}
+		named("definitions", s.Definitions)
+		named("$defs", s.Defs)
+		named("properties", s.Properties)
+		patterns := make(map[string]*Schema)
+		for re, ps := range s.PatternProperties {
+			patterns[re.String()] = ps
+		}
+		named("patternProperties", patterns)
+		sub("additionalProperties", s.AdditionalProperties)
+		sub("propertyNames", s.PropertyNames)
+		named("dependencies", s.Dependencies.Schemas)
+		named("dependentSchemas", s.DependentSchemas)
+		indexed("prefixItems", s.PrefixItems)
+		if s.Items != nil {
+			if s.Items.TupleMode {
+				indexed("items", s.Items.Schemas)
+			} else if len(s.Items.Schemas) == 1 {
+				sub("items", s.Items.Schemas[0])
+			}
+		}
+		sub("additionalItems", s.AdditionalItems)
+		sub("contains", s.Contains)
+		indexed("allOf", s.AllOf)
+		indexed("anyOf", s.AnyOf)
+		indexed("oneOf", s.OneOf)
+		sub("not", s.Not)
+		sub("if", s.If)
+		sub("then", s.Then)
+		sub("else", s.Else)
+		sub("unevaluatedProperties", s.UnevaluatedProperties)
+		sub("unevaluatedItems", s.UnevaluatedItems)
+		named("variants", s.Variants)
+		named("profiles", s.Profiles)
+	}
+	walk(s, "#")
+}
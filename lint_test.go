@@ -0,0 +1,118 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type LintSuite struct{}
+
+var _ = gc.Suite(LintSuite{})
+
+func (LintSuite) TestLint(c *gc.C) {
+	for i, test := range []struct {
+		schema   string
+		problems []string
+	}{{
+		schema: `
+type: object
+properties:
+  name: {type: string, minLength: 5, maxLength: 3}
+  size: {type: integer, minimum: 10, maximum: 1}
+  exact: {type: number, minimum: 1, maximum: 1, exclusiveMaximum: true}
+  tags: {type: array, minItems: 3, maxItems: 2}
+  labels: {type: object, minProperties: 2, maxProperties: 1, additionalProperties: {type: string}}
+  zones: {type: array, contains: {type: string}, minContains: 2, maxContains: 1}
+`,
+		problems: []string{
+			`#/properties/exact: exclusive bounds of 1 allow no value`,
+			`#/properties/labels: minProperties 2 is greater than maxProperties 1`,
+			`#/properties/name: minLength 5 is greater than maxLength 3`,
+			`#/properties/size: minimum 10 is greater than maximum 1`,
+			`#/properties/tags: minItems 3 is greater than maxItems 2`,
+			`#/properties/zones: minContains 2 is greater than maxContains 1`,
+		},
+	}, {
+		schema: `
+type: string
+enum: [abc, def]
+pattern: ^[0-9]+$
+`,
+		problems: []string{
+			`#: no enum value matches pattern "^[0-9]+$"`,
+		},
+	}, {
+		schema: `
+type: object
+properties:
+  name: {type: string}
+required: [name, password]
+`,
+		problems: []string{
+			`#: required property "password" is not allowed by the schema`,
+		},
+	}, {
+		schema: `
+type: object
+definitions:
+  closed:
+    type: object
+    additionalProperties: false
+    required: [id]
+properties:
+  items:
+    type: array
+    items: {$ref: "#/definitions/closed"}
+`,
+		problems: []string{
+			`#/definitions/closed: required property "id" is not allowed by the schema`,
+		},
+	}, {
+		// Satisfiable schemas have no problems.
+		schema: `
+type: object
+properties:
+  name: {type: string, minLength: 3, maxLength: 3, enum: [abc, "123"], pattern: "^[0-9]+$"}
+  size: {type: integer, minimum: 1, maximum: 1}
+  kind: {type: string, variants: {disk: {required: [name]}}}
+patternProperties:
+  ^x-: {}
+allOf:
+- required: [size]
+- properties: {extra: {type: string}}
+if: {required: [kind]}
+then: {required: [name]}
+not: {required: [password]}
+required: [name, extra, x-note]
+`,
+	}, {
+		schema: `
+type: object
+additionalProperties: {type: string}
+required: [anything]
+`,
+	}, {
+		schema: `
+type: object
+unevaluatedProperties: false
+anyOf:
+- properties: {a: {type: string}}
+required: [a]
+`,
+	}} {
+		c.Logf("test %d: %s", i, test.schema)
+		s, err := FromYAML(strings.NewReader(test.schema))
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(s.Lint(), jc.DeepEquals, test.problems)
+		if len(test.problems) == 0 {
+			c.Check(s.Check(), jc.ErrorIsNil)
+		} else {
+			c.Check(s.Check(), gc.ErrorMatches, `unsatisfiable schema: .*`)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"strings"
+)
+
+// Loader loads the schemas referred to by external references, so that a
+// schema may refer to schemas published elsewhere, such as
+// {"$ref": "https://example.com/common.json#/definitions/port"}. How they are
+// loaded, whether from a filesystem or over the network, is up to the
+// Loader. A Loader used with schemas from untrusted sources should be
+// restricted with RestrictLoader.
+type Loader interface {
+	// Load returns the schema identified by uri, which has no fragment.
+	// The uri is resolved relative to that of the document holding the
+	// reference, or to the id of the schema being loaded, where known.
+	Load(uri string) (*Schema, error)
+}
+
+// LoaderFunc adapts a function to the Loader interface.
+type LoaderFunc func(uri string) (*Schema, error)
+
+// Load implements Loader by calling f.
+func (f LoaderFunc) Load(uri string) (*Schema, error) {
+	return f(uri)
+}
+
+// resolveExternalRefs resolves the external references in s using loader,
+// if it is not nil. References are resolved relative to the base uris given
+// by the ids of s and the schemas within it; references to other parts of s
+// itself are left to be resolved when they are followed.
+func resolveExternalRefs(s *Schema, loader Loader) error {
+	if loader == nil {
+		return nil
+	}
+	return newRefResolver(loader).resolve(s, "", true)
+}
+
+// withExternalRefs returns s, or a copy of s with its external references
+// resolved using loader if it has any that haven't already been resolved.
+func withExternalRefs(s *Schema, loader Loader) (*Schema, error) {
+	if loader == nil || !hasExternalRefs(s) {
+		return s, nil
+	}
+	s = cloneSchema(s)
+	if err := resolveExternalRefs(s, loader); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// hasExternalRefs reports whether s holds any external references which
+// haven't been resolved.
+func hasExternalRefs(s *Schema) bool {
+	found := false
+	walkSchema(s, func(sub *Schema) {
+		if sub.ref() != "" && sub.target == nil && !strings.HasPrefix(sub.ref(), "#") {
+			found = true
+		}
+	})
+	return found
+}
+
+// refTarget returns the schema that s refers to, found within root, or nil
+// if it can't be found. Dynamic references are resolved with root as the
+// outermost schema being validated against.
+func refTarget(root, s *Schema) *Schema {
+	target := s.target
+	if target == nil {
+		target = resolveLocalRef(root, s.ref())
+	}
+	if target == nil || s.Reference != "" {
+		return target
+	}
+	return dynamicTarget(root, s, target)
+}
+
+// walkWithTargets calls fn for s and every schema nested within it, in the
+// same way as walkSchema, and for every schema within the other documents
+// that they refer to.
+func walkWithTargets(s *Schema, fn func(*Schema)) {
+	seen := make(map[*Schema]bool)
+	var walk func(s *Schema)
+	walk = func(s *Schema) {
+		var targets []*Schema
+		walkSchema(s, func(sub *Schema) {
+			if seen[sub] {
+				return
+			}
+			seen[sub] = true
+			fn(sub)
+			if sub.target != nil && !seen[sub.target] {
+				targets = append(targets, sub.target)
+			}
+		})
+		for _, target := range targets {
+			walk(target)
+		}
+	}
+	walk(s)
+}
@@ -0,0 +1,120 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"fmt"
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type LoaderSuite struct{}
+
+var _ = gc.Suite(LoaderSuite{})
+
+var loaderDocs = map[string]string{
+	"https://schemas.example.com/common.json": `{
+		"definitions": {
+			"port": {"type": "integer", "maximum": 65535, "default": 80},
+			"host": {"$ref": "#/definitions/hostname"},
+			"hostname": {"type": "string", "format": "hostname"},
+			"timeout": {"$ref": "units/time.json#/definitions/seconds"}
+		}
+	}`,
+	"https://schemas.example.com/units/time.json": `{
+		"definitions": {
+			"seconds": {"type": "integer", "minimum": 0}
+		}
+	}`,
+}
+
+const loaderSchema = `{
+	"id": "https://schemas.example.com/service.json",
+	"type": "object",
+	"properties": {
+		"host": {"$ref": "common.json#/definitions/host"},
+		"port": {"$ref": "common.json#/definitions/port"},
+		"timeout": {"$ref": "common.json#/definitions/timeout"},
+		"backup": {"$ref": "#/properties/port"}
+	}
+}`
+
+// mapLoader returns a Loader which loads the schemas in loaderDocs,
+// recording the uris it is asked for.
+func mapLoader(loaded *[]string) Loader {
+	return LoaderFunc(func(uri string) (*Schema, error) {
+		*loaded = append(*loaded, uri)
+		doc, ok := loaderDocs[uri]
+		if !ok {
+			return nil, fmt.Errorf("%s not found", uri)
+		}
+		return FromJSON(strings.NewReader(doc))
+	})
+}
+
+func (LoaderSuite) TestDecodeWithLoader(c *gc.C) {
+	var loaded []string
+	s, err := FromJSONWithOptions(strings.NewReader(loaderSchema), DecodeOptions{Loader: mapLoader(&loaded)})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(loaded, jc.DeepEquals, []string{
+		"https://schemas.example.com/common.json",
+		"https://schemas.example.com/units/time.json",
+	})
+
+	c.Check(s.Validate(map[string]interface{}{"host": "example.com", "port": 443, "timeout": 30}), jc.ErrorIsNil)
+	c.Check(s.Validate(map[string]interface{}{"host": "not a host"}), gc.ErrorMatches, `host: .*`)
+	c.Check(s.Validate(map[string]interface{}{"port": 70000}), gc.ErrorMatches, `port: numeric value is greater than maximum`)
+	c.Check(s.Validate(map[string]interface{}{"backup": 70000}), gc.ErrorMatches, `backup: numeric value is greater than maximum`)
+	c.Check(s.Validate(map[string]interface{}{"timeout": -1}), gc.ErrorMatches, `timeout: numeric value is less than the minimum`)
+
+	v, err := NewValidator(s)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(v.Validate(map[string]interface{}{"port": 70000}), gc.ErrorMatches, `port: numeric value is greater than maximum`)
+
+	doc := map[string]interface{}{}
+	s.InsertDefaults(doc)
+	c.Check(doc, jc.DeepEquals, map[string]interface{}{"port": float64(80), "backup": float64(80)})
+
+	// The references are still written as they were given.
+	data, err := s.MarshalJSON()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(data), jc.Contains, `"$ref":"common.json#/definitions/port"`)
+}
+
+func (LoaderSuite) TestValidateWithLoader(c *gc.C) {
+	s, err := FromJSON(strings.NewReader(loaderSchema))
+	c.Assert(err, jc.ErrorIsNil)
+	doc := map[string]interface{}{"port": 70000}
+
+	c.Check(s.Validate(doc), gc.ErrorMatches, `.*failed to build validator.*`)
+
+	var loaded []string
+	opts := ValidationOptions{Loader: mapLoader(&loaded)}
+	c.Check(s.ValidateWith(opts, doc), gc.ErrorMatches, `port: numeric value is greater than maximum`)
+	c.Check(s.ValidateWith(opts, map[string]interface{}{"port": 8080}), jc.ErrorIsNil)
+	c.Check(hasExternalRefs(s), jc.IsTrue)
+
+	_, err = s.ValidateBytes([]byte(`{"timeout": -1}`), DecodeOptions{Loader: mapLoader(&loaded)})
+	c.Check(err, gc.ErrorMatches, `timeout: numeric value is less than the minimum`)
+}
+
+func (LoaderSuite) TestLoaderErrors(c *gc.C) {
+	var loaded []string
+	for i, test := range []struct {
+		schema string
+		expect string
+	}{{
+		schema: `{"properties": {"a": {"$ref": "https://schemas.example.com/missing.json#/definitions/a"}}}`,
+		expect: `cannot resolve \$ref "https://schemas.example.com/missing.json#/definitions/a": https://schemas.example.com/missing.json not found`,
+	}, {
+		schema: `{"properties": {"a": {"$ref": "https://schemas.example.com/common.json#/definitions/missing"}}}`,
+		expect: `cannot resolve \$ref "https://schemas.example.com/common.json#/definitions/missing": no schema at "#/definitions/missing"`,
+	}} {
+		c.Logf("test %d", i)
+		_, err := FromYAMLWithOptions(strings.NewReader(test.schema), DecodeOptions{Loader: mapLoader(&loaded)})
+		c.Check(err, gc.ErrorMatches, test.expect)
+	}
+}
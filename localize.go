@@ -0,0 +1,42 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import "strings"
+
+// LocalizedTitle returns the title of s in the language identified by the
+// given language tag. If there is no translation for lang, the translation
+// for its base language is used (so "fr-CA" falls back to "fr"), and failing
+// that the untranslated Title is returned.
+func (s *Schema) LocalizedTitle(lang string) string {
+	return localize(s.Titles, lang, s.Title)
+}
+
+// LocalizedDescription returns the description of s in the language
+// identified by the given language tag, falling back in the same way as
+// LocalizedTitle.
+func (s *Schema) LocalizedDescription(lang string) string {
+	return localize(s.Descriptions, lang, s.Description)
+}
+
+// LocalizedPatternDescription returns the pattern description of s in the
+// language identified by the given language tag, falling back in the same
+// way as LocalizedTitle.
+func (s *Schema) LocalizedPatternDescription(lang string) string {
+	return localize(s.PatternDescriptions, lang, s.PatternDescription)
+}
+
+func localize(translations map[string]string, lang, def string) string {
+	for lang != "" {
+		if v, ok := translations[lang]; ok {
+			return v
+		}
+		i := strings.LastIndexAny(lang, "-_")
+		if i < 0 {
+			break
+		}
+		lang = lang[:i]
+	}
+	return def
+}
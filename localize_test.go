@@ -0,0 +1,48 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type LocalizeSuite struct{}
+
+var _ = gc.Suite(LocalizeSuite{})
+
+const localizedExample = `
+title: Region
+titles:
+  fr: Région
+description: The region to deploy to.
+descriptions:
+  fr: La région de déploiement.
+  pt-BR: A região para implantar.
+`
+
+func (LocalizeSuite) TestLocalized(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(localizedExample))
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(s.LocalizedTitle("fr"), gc.Equals, "Région")
+	c.Check(s.LocalizedTitle("fr-CA"), gc.Equals, "Région")
+	c.Check(s.LocalizedTitle("pt-BR"), gc.Equals, "Region")
+	c.Check(s.LocalizedTitle(""), gc.Equals, "Region")
+
+	c.Check(s.LocalizedDescription("pt-BR"), gc.Equals, "A região para implantar.")
+	c.Check(s.LocalizedDescription("de"), gc.Equals, "The region to deploy to.")
+}
+
+func (LocalizeSuite) TestLocalizedStripped(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(localizedExample))
+	c.Assert(err, jc.ErrorIsNil)
+
+	stripped := StripAnnotations(s, "title")
+	c.Check(stripped.Titles, gc.IsNil)
+	c.Check(stripped.Descriptions, gc.IsNil)
+	c.Check(stripped.LocalizedTitle("fr"), gc.Equals, "Region")
+}
@@ -0,0 +1,25 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import "regexp"
+
+// MapOf returns the schema for an object used as a map from strings to
+// values described by value, such as a set of named storage pools. If
+// keyPattern is not empty, every key must match it, in the same way as the
+// pattern keyword matches strings; otherwise any key is allowed.
+//
+// MapOf panics if keyPattern is not a valid regular expression, as patterns
+// are normally constants.
+func MapOf(value *Schema, keyPattern string) *Schema {
+	s := &Schema{Type: []Type{ObjectType}}
+	if keyPattern == "" {
+		s.AdditionalProperties = value
+	} else {
+		s.PatternProperties = map[*regexp.Regexp]*Schema{
+			regexp.MustCompile(keyPattern): value,
+		}
+	}
+	return s
+}
@@ -0,0 +1,176 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"encoding/json"
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type MapsSuite struct{}
+
+var _ = gc.Suite(MapsSuite{})
+
+var poolSchema = &Schema{
+	Type: []Type{ObjectType},
+	Properties: map[string]*Schema{
+		"type": {Type: []Type{StringType}, Default: "lxd"},
+		"size": {Type: []Type{IntegerType}},
+	},
+}
+
+func (MapsSuite) TestMapOf(c *gc.C) {
+	s := &Schema{
+		Type: []Type{ObjectType},
+		Properties: map[string]*Schema{
+			"pools":  MapOf(poolSchema, "^[a-z][a-z0-9-]*$"),
+			"labels": MapOf(&Schema{Type: []Type{StringType}}, ""),
+		},
+	}
+	c.Check(s.Validate(map[string]interface{}{
+		"pools":  map[string]interface{}{"fast": map[string]interface{}{"size": 10}},
+		"labels": map[string]interface{}{"Team Name": "storage"},
+	}), jc.ErrorIsNil)
+	c.Check(s.Validate(map[string]interface{}{
+		"pools": map[string]interface{}{"fast": map[string]interface{}{"size": "10"}},
+	}), gc.ErrorMatches, `pools.fast.size: .*`)
+	c.Check(s.Validate(map[string]interface{}{
+		"pools": map[string]interface{}{"Fast": map[string]interface{}{}},
+	}), gc.ErrorMatches, `pools: .*`)
+	c.Check(s.Validate(map[string]interface{}{
+		"labels": map[string]interface{}{"team": 1},
+	}), gc.ErrorMatches, `labels.team: .*`)
+
+	data, err := json.Marshal(s.Properties["pools"])
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(data), jc.Contains, `"patternProperties":{"^[a-z][a-z0-9-]*$":`)
+}
+
+func (MapsSuite) TestMapOfInvalidPattern(c *gc.C) {
+	c.Check(func() { MapOf(poolSchema, "[") }, gc.PanicMatches, `regexp: Compile.*`)
+}
+
+func (MapsSuite) TestInsertDefaultsIntoMapValues(c *gc.C) {
+	s := &Schema{
+		Type: []Type{ObjectType},
+		Properties: map[string]*Schema{
+			"pools":  MapOf(poolSchema, "^[a-z]+$"),
+			"config": MapOf(poolSchema, ""),
+		},
+	}
+	doc := map[string]interface{}{
+		"pools": map[string]interface{}{
+			"fast": map[string]interface{}{"size": 10},
+			"slow": map[string]interface{}{"type": "ceph"},
+		},
+		"config": map[string]interface{}{
+			"default": map[string]interface{}{},
+		},
+	}
+	s.InsertDefaults(doc)
+	c.Check(doc, jc.DeepEquals, map[string]interface{}{
+		"pools": map[string]interface{}{
+			"fast": map[string]interface{}{"size": 10, "type": "lxd"},
+			"slow": map[string]interface{}{"type": "ceph"},
+		},
+		"config": map[string]interface{}{
+			"default": map[string]interface{}{"type": "lxd"},
+		},
+	})
+
+	// Maps with no entries are left alone.
+	doc = map[string]interface{}{}
+	s.InsertDefaults(doc)
+	c.Check(doc, gc.HasLen, 0)
+}
+
+func (MapsSuite) TestInsertDefaultsIntoAdditionalProperties(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(`
+type: object
+properties:
+  applications:
+    type: object
+    additionalProperties:
+      $ref: "#/definitions/application"
+definitions:
+  application:
+    type: object
+    properties:
+      scale:
+        type: integer
+        default: 1
+      offers:
+        type: object
+        additionalProperties:
+          type: object
+          properties:
+            endpoint:
+              type: string
+              default: db
+`))
+	c.Assert(err, jc.ErrorIsNil)
+	doc := map[string]interface{}{
+		"applications": map[string]interface{}{
+			"mysql": map[string]interface{}{
+				"offers": map[string]interface{}{
+					"shared": map[string]interface{}{},
+				},
+			},
+			"wordpress": map[string]interface{}{"scale": 3},
+		},
+	}
+	s.InsertDefaults(doc)
+	c.Check(doc, jc.DeepEquals, map[string]interface{}{
+		"applications": map[string]interface{}{
+			"mysql": map[string]interface{}{
+				"scale": float64(1),
+				"offers": map[string]interface{}{
+					"shared": map[string]interface{}{"endpoint": "db"},
+				},
+			},
+			"wordpress": map[string]interface{}{"scale": 3},
+		},
+	})
+}
+
+func (MapsSuite) TestPatternProperties(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(`
+type: object
+properties:
+  region:
+    type: string
+  zone-default:
+    type: string
+patternProperties:
+  "^zone-":
+    type: string
+    minLength: 3
+  "^zone-[a-z]+$":
+    type: string
+    pattern: "^[a-z]"
+additionalProperties: false
+`))
+	c.Assert(err, jc.ErrorIsNil)
+	v, err := NewValidator(s)
+	c.Assert(err, jc.ErrorIsNil)
+	for _, validate := range []func(interface{}) error{s.Validate, v.Validate} {
+		c.Check(validate(map[string]interface{}{
+			"region":       "eu",
+			"zone-default": "az1",
+			"zone-a":       "az1",
+			"zone-1":       "123",
+		}), jc.ErrorIsNil)
+		c.Check(validate(map[string]interface{}{"zone-a": 1}), gc.ErrorMatches, `zone-a: .*`)
+		c.Check(validate(map[string]interface{}{"zonea": "az1"}), gc.NotNil)
+
+		// A property is checked against every schema that applies to
+		// it: those of the properties and patterns it matches.
+		c.Check(validate(map[string]interface{}{"zone-default": "a"}), gc.ErrorMatches, `zone-default: .*`)
+		c.Check(validate(map[string]interface{}{"zone-a": "1az"}), gc.ErrorMatches, `zone-a: .*`)
+		c.Check(validate(map[string]interface{}{"zone-a": "az"}), gc.ErrorMatches, `zone-a: .*`)
+	}
+}
@@ -0,0 +1,128 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ToMarkdown returns Markdown documentation for the properties of s, for
+// publishing alongside the schema. The title and description of s are
+// followed by a table with a row for each property, giving its type,
+// whether it is required, its default, its description along with the
+// values allowed by its enum, and its examples. The properties of nested
+// objects, and of the objects held by arrays, are listed under dotted paths
+// such as "network.mtu" and "nodes[].port".
+func ToMarkdown(s *Schema) string {
+	var b strings.Builder
+	if s.Title != "" {
+		fmt.Fprintf(&b, "# %s\n\n", s.Title)
+	}
+	if s.Description != "" {
+		fmt.Fprintf(&b, "%s\n\n", s.Description)
+	}
+	md := &markdown{root: s, visiting: make(map[*Schema]bool)}
+	md.properties(s, "")
+	if len(md.rows) == 0 {
+		return b.String()
+	}
+	b.WriteString("| Property | Type | Required | Default | Description | Examples |\n")
+	b.WriteString("|---|---|---|---|---|---|\n")
+	for _, row := range md.rows {
+		b.WriteString("| " + strings.Join(row, " | ") + " |\n")
+	}
+	return b.String()
+}
+
+type markdown struct {
+	root *Schema
+	rows [][]string
+
+	// visiting holds the schemas whose properties are being listed, so
+	// that recursive schemas are only listed once.
+	visiting map[*Schema]bool
+}
+
+// properties adds the rows for the properties of s, which describes the
+// object found at path.
+func (md *markdown) properties(s *Schema, path string) {
+	s = derefLocal(md.root, s)
+	if md.visiting[s] {
+		return
+	}
+	md.visiting[s] = true
+	defer delete(md.visiting, s)
+	required := make(map[string]bool)
+	for _, name := range s.Required {
+		required[name] = true
+	}
+	for _, name := range orderedProperties(s) {
+		ps := derefLocal(md.root, s.Properties[name])
+		ppath := propertyPath(path, name)
+		md.rows = append(md.rows, []string{
+			"`" + ppath + "`",
+			markdownEscape(mermaidType(ps)),
+			markdownRequired(required[name]),
+			markdownValue(ps.Default),
+			markdownEscape(markdownDescription(ps)),
+			markdownExamples(ps.examples()),
+		})
+		md.properties(ps, ppath)
+		if hasType(ps, ArrayType) && ps.Items != nil && !ps.Items.TupleMode && len(ps.Items.Schemas) == 1 {
+			md.properties(ps.Items.Schemas[0], ppath+"[]")
+		}
+	}
+}
+
+// markdownDescription returns the description of s, followed by the values
+// allowed by its enum with their labels.
+func markdownDescription(s *Schema) string {
+	options := s.EnumOptions()
+	if len(options) == 0 {
+		return s.Description
+	}
+	values := make([]string, len(options))
+	for i, option := range options {
+		values[i] = "`" + exampleString(option.Value) + "`"
+		if option.Label != exampleString(option.Value) {
+			values[i] += " (" + option.Label + ")"
+		}
+	}
+	desc := "One of " + strings.Join(values, ", ") + "."
+	if s.Description != "" {
+		desc = s.Description + " " + desc
+	}
+	return desc
+}
+
+func markdownRequired(required bool) string {
+	if required {
+		return "yes"
+	}
+	return "no"
+}
+
+// markdownValue returns v as inline code, or the empty string if v is nil.
+func markdownValue(v interface{}) string {
+	if v == nil {
+		return ""
+	}
+	return "`" + markdownEscape(exampleString(v)) + "`"
+}
+
+// markdownExamples returns the given examples as a comma-separated list of
+// inline code.
+func markdownExamples(examples []interface{}) string {
+	strs := make([]string, len(examples))
+	for i, example := range examples {
+		strs[i] = markdownValue(example)
+	}
+	return strings.Join(strs, ", ")
+}
+
+// markdownEscape escapes text for use in a cell of a Markdown table.
+func markdownEscape(text string) string {
+	return strings.NewReplacer("|", `\|`, "\r\n", " ", "\n", " ").Replace(text)
+}
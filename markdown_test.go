@@ -0,0 +1,72 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type MarkdownSuite struct{}
+
+var _ = gc.Suite(MarkdownSuite{})
+
+const markdownSchema = `
+title: Cloud
+description: Describes a cloud.
+type: object
+required: [region]
+order: [region]
+definitions:
+  node:
+    type: object
+    properties:
+      address: {type: string, examples: [10.0.0.1]}
+properties:
+  region:
+    type: string
+    description: The region to deploy to.
+    examples: [us-east-1, eu-west-2]
+  mtu: {type: integer, default: 1500, description: "MTU | bytes"}
+  auth:
+    type: object
+    properties:
+      user: {type: string, example: admin}
+  nodes: {type: array, items: {$ref: "#/definitions/node"}}
+`
+
+func (MarkdownSuite) TestToMarkdown(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(markdownSchema))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(ToMarkdown(s), gc.Equals, "# Cloud\n\n"+
+		"Describes a cloud.\n\n"+
+		"| Property | Type | Required | Default | Description | Examples |\n"+
+		"|---|---|---|---|---|---|\n"+
+		"| `region` | string | yes |  | The region to deploy to. | `us-east-1`, `eu-west-2` |\n"+
+		"| `auth` | object | no |  |  |  |\n"+
+		"| `auth.user` | string | no |  |  | `admin` |\n"+
+		"| `mtu` | integer | no | `1500` | MTU \\| bytes |  |\n"+
+		"| `nodes` | ref[] | no |  |  |  |\n"+
+		"| `nodes[].address` | string | no |  |  | `10.0.0.1` |\n")
+}
+
+func (MarkdownSuite) TestToMarkdownRecursive(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(`
+type: object
+definitions:
+  tree:
+    type: object
+    properties:
+      child: {$ref: "#/definitions/tree"}
+properties:
+  root: {$ref: "#/definitions/tree"}
+`))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(ToMarkdown(s), gc.Equals, "| Property | Type | Required | Default | Description | Examples |\n"+
+		"|---|---|---|---|---|---|\n"+
+		"| `root` | object | no |  |  |  |\n"+
+		"| `root.child` | object | no |  |  |  |\n")
+}
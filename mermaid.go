@@ -0,0 +1,167 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ToMermaid returns a Mermaid flowchart showing the structure of s, for
+// reviewing large schemas visually. Each object is drawn as a node listing
+// its simple properties, with edges to the nodes for properties which are
+// objects or arrays of objects, for references to definitions (drawn dashed),
+// and for the branches of allOf, anyOf, oneOf and not.
+func ToMermaid(s *Schema) string {
+	m := &mermaid{
+		root: s,
+		ids:  make(map[*Schema]string),
+	}
+	m.node(s, "schema")
+	for _, name := range sortedKeys(s.Definitions) {
+		m.node(s.Definitions[name], name)
+	}
+	lines := append([]string{"flowchart LR"}, m.nodes...)
+	lines = append(lines, m.edges...)
+	return strings.Join(lines, "\n") + "\n"
+}
+
+type mermaid struct {
+	root  *Schema
+	ids   map[*Schema]string
+	nodes []string
+	edges []string
+}
+
+// node returns the id of the node for s, adding it to the diagram if it
+// isn't already there. The name is used as its heading if s has no title.
+func (m *mermaid) node(s *Schema, name string) string {
+	if id, ok := m.ids[s]; ok {
+		return id
+	}
+	id := fmt.Sprintf("n%d", len(m.ids))
+	m.ids[s] = id
+	// Reserve the node's place, so that nodes appear in the order they are
+	// reached.
+	index := len(m.nodes)
+	m.nodes = append(m.nodes, "")
+
+	heading := name
+	if s.Title != "" {
+		heading = s.Title
+	}
+	rows := []string{fmt.Sprintf("%s (%s)", heading, mermaidType(s))}
+	for _, pname := range orderedProperties(s) {
+		ps := s.Properties[pname]
+		if mermaidLeaf(ps) {
+			rows = append(rows, fmt.Sprintf("%s: %s", pname, mermaidType(ps)))
+			continue
+		}
+		m.link(id, ps, pname)
+	}
+	if s.AdditionalProperties != nil && !mermaidLeaf(s.AdditionalProperties) {
+		m.link(id, s.AdditionalProperties, "additionalProperties")
+	}
+	for _, ps := range sortedPatternSchemas(s.PatternProperties) {
+		if !mermaidLeaf(ps) {
+			m.link(id, ps, "patternProperties")
+		}
+	}
+	if s.Items != nil {
+		for i, item := range s.Items.Schemas {
+			if !mermaidLeaf(item) {
+				m.link(id, item, fmt.Sprintf("items[%d]", i))
+			}
+		}
+	}
+	for _, c := range []struct {
+		keyword string
+		schemas []*Schema
+	}{
+		{"allOf", s.AllOf},
+		{"anyOf", s.AnyOf},
+		{"oneOf", s.OneOf},
+		{"not", []*Schema{s.Not}},
+	} {
+		for i, sub := range c.schemas {
+			if sub != nil {
+				m.edge(id, m.node(sub, fmt.Sprintf("%s %s[%d]", heading, c.keyword, i)), c.keyword, false)
+			}
+		}
+	}
+	m.nodes[index] = fmt.Sprintf(`  %s["%s"]`, id, mermaidEscape(strings.Join(rows, "<br/>")))
+	return id
+}
+
+// link adds an edge labelled with name from the node with the given id to
+// the node for ps. References are linked to the node for the definition they
+// refer to, and arrays to the node for their items.
+func (m *mermaid) link(from string, ps *Schema, name string) {
+	if ps.Reference != "" {
+		if target := resolveLocalRef(m.root, ps.Reference); target != nil {
+			m.edge(from, m.node(target, refName(ps.Reference)), name, true)
+			return
+		}
+	}
+	if hasType(ps, ArrayType) && ps.Items != nil && !ps.Items.TupleMode && len(ps.Items.Schemas) == 1 {
+		if item := ps.Items.Schemas[0]; !mermaidLeaf(item) {
+			m.link(from, item, name+"[]")
+			return
+		}
+	}
+	m.edge(from, m.node(ps, name), name, false)
+}
+
+func (m *mermaid) edge(from, to, label string, dashed bool) {
+	arrow := "-->"
+	if dashed {
+		arrow = "-.->"
+	}
+	if label == "" {
+		m.edges = append(m.edges, fmt.Sprintf("  %s %s %s", from, arrow, to))
+		return
+	}
+	m.edges = append(m.edges, fmt.Sprintf(`  %s %s|"%s"| %s`, from, arrow, mermaidEscape(label), to))
+}
+
+// mermaidLeaf reports whether s is simple enough to be shown as a row in
+// its parent's node rather than as a node of its own.
+func mermaidLeaf(s *Schema) bool {
+	if len(s.Properties) > 0 || s.AdditionalProperties != nil || len(s.PatternProperties) > 0 ||
+		s.Reference != "" || len(s.AllOf) > 0 || len(s.AnyOf) > 0 || len(s.OneOf) > 0 || s.Not != nil {
+		return false
+	}
+	if s.Items != nil {
+		for _, item := range s.Items.Schemas {
+			if !mermaidLeaf(item) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// mermaidType returns a short description of the type of s.
+func mermaidType(s *Schema) string {
+	if s.Reference != "" && len(s.Type) == 0 {
+		return "ref"
+	}
+	if len(s.Type) == 0 {
+		return "any"
+	}
+	names := make([]string, len(s.Type))
+	for i, t := range s.Type {
+		names[i] = t.String()
+	}
+	desc := strings.Join(names, "|")
+	if hasType(s, ArrayType) && s.Items != nil && !s.Items.TupleMode && len(s.Items.Schemas) == 1 {
+		desc = strings.Replace(desc, "array", mermaidType(s.Items.Schemas[0])+"[]", 1)
+	}
+	return desc
+}
+
+// mermaidEscape escapes text for use in a quoted Mermaid label.
+func mermaidEscape(text string) string {
+	return strings.NewReplacer(`"`, "#quot;").Replace(text)
+}
@@ -0,0 +1,68 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type MermaidSuite struct{}
+
+var _ = gc.Suite(MermaidSuite{})
+
+const mermaidSchema = `
+title: Config
+type: object
+definitions:
+  node:
+    type: object
+    properties:
+      address: {type: string}
+properties:
+  name: {type: string}
+  tags: {type: array, items: {type: string}}
+  primary: {$ref: "#/definitions/node"}
+  nodes: {type: array, items: {$ref: "#/definitions/node"}}
+  auth:
+    type: object
+    properties:
+      user: {type: string}
+  credential:
+    oneOf:
+    - type: string
+    - type: object
+      properties:
+        token: {type: string}
+`
+
+func (MermaidSuite) TestToMermaid(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(mermaidSchema))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(ToMermaid(s), gc.Equals, `flowchart LR
+  n0["Config (object)<br/>name: string<br/>tags: string[]"]
+  n1["auth (object)<br/>user: string"]
+  n2["credential (any)"]
+  n3["credential oneOf[0] (string)"]
+  n4["credential oneOf[1] (object)<br/>token: string"]
+  n5["node (object)<br/>address: string"]
+  n0 -->|"auth"| n1
+  n2 -->|"oneOf"| n3
+  n2 -->|"oneOf"| n4
+  n0 -->|"credential"| n2
+  n0 -.->|"nodes[]"| n5
+  n0 -.->|"primary"| n5
+`)
+}
+
+func (MermaidSuite) TestToMermaidCycle(c *gc.C) {
+	s := &Schema{Title: `A "tree"`, Type: []Type{ObjectType}}
+	s.Properties = map[string]*Schema{"child": s}
+	c.Check(ToMermaid(s), gc.Equals, `flowchart LR
+  n0["A #quot;tree#quot; (object)"]
+  n0 -->|"child"| n0
+`)
+}
@@ -0,0 +1,114 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// maxMockBodySize limits the size of the bodies accepted by MockHandler.
+const maxMockBodySize = 1 << 20
+
+// MockHandler returns an http.Handler which serves the documents described
+// by s, for integration tests of components that consume schema-shaped
+// APIs. A GET request is answered with the document returned by
+// s.Generate. The json body of a POST or PUT request is validated against
+// s: a valid body is echoed back, with any defaults that validation
+// inserts, and an invalid one is answered with status 422 and the
+// validation errors, as in
+//
+//	{"errors": [{"path": "port", "keyword": "maximum", "message": "..."}]}
+//
+// An error is returned if s can't generate a document.
+func MockHandler(s *Schema) (http.Handler, error) {
+	doc, err := s.Generate()
+	if err != nil {
+		return nil, err
+	}
+	generated, err := json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+	return &mockHandler{schema: s, generated: generated}, nil
+}
+
+type mockHandler struct {
+	schema    *Schema
+	generated []byte
+}
+
+// mockError describes a ValidationError in the responses of MockHandler.
+type mockError struct {
+	Path    string `json:"path,omitempty"`
+	Keyword string `json:"keyword,omitempty"`
+	Message string `json:"message"`
+}
+
+// ServeHTTP implements http.Handler.
+func (h *mockHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead:
+		writeMockJSON(w, http.StatusOK, json.RawMessage(h.generated))
+	case http.MethodPost, http.MethodPut:
+		data, err := io.ReadAll(http.MaxBytesReader(w, req.Body, maxMockBodySize))
+		if err != nil {
+			writeMockErrors(w, http.StatusRequestEntityTooLarge, []mockError{{Message: err.Error()}})
+			return
+		}
+		var doc interface{}
+		if err := json.Unmarshal(data, &doc); err != nil {
+			writeMockErrors(w, http.StatusBadRequest, []mockError{{
+				Message: fmt.Sprintf("invalid json: %v", err),
+			}})
+			return
+		}
+		if err := h.schema.Validate(doc); err != nil {
+			writeMockErrors(w, http.StatusUnprocessableEntity, mockErrors(err))
+			return
+		}
+		writeMockJSON(w, http.StatusOK, doc)
+	default:
+		w.Header().Set("Allow", "GET, HEAD, POST, PUT")
+		writeMockErrors(w, http.StatusMethodNotAllowed, []mockError{{
+			Message: fmt.Sprintf("method %s not allowed", req.Method),
+		}})
+	}
+}
+
+// mockErrors returns the errors in err, as returned by Schema.Validate.
+func mockErrors(err error) []mockError {
+	var errs ValidationErrors
+	var verr *ValidationError
+	switch {
+	case errors.As(err, &errs):
+	case errors.As(err, &verr):
+		errs = ValidationErrors{verr}
+	default:
+		return []mockError{{Message: err.Error()}}
+	}
+	out := make([]mockError, len(errs))
+	for i, e := range errs {
+		out[i] = mockError{Path: e.Path, Keyword: e.Keyword, Message: e.Err.Error()}
+	}
+	return out
+}
+
+func writeMockErrors(w http.ResponseWriter, status int, errs []mockError) {
+	writeMockJSON(w, status, map[string]interface{}{"errors": errs})
+}
+
+func writeMockJSON(w http.ResponseWriter, status int, v interface{}) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(data)
+}
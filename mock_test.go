@@ -0,0 +1,98 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type MockSuite struct{}
+
+var _ = gc.Suite(MockSuite{})
+
+const mockSchema = `
+type: object
+required: [name]
+properties:
+  name:
+    type: string
+  port:
+    type: integer
+    maximum: 65535
+    default: 17070
+`
+
+func newMockServer(c *gc.C) *httptest.Server {
+	s, err := FromYAML(strings.NewReader(mockSchema))
+	c.Assert(err, jc.ErrorIsNil)
+	h, err := MockHandler(s)
+	c.Assert(err, jc.ErrorIsNil)
+	return httptest.NewServer(h)
+}
+
+// mockRequest makes a request to srv, returning the status and decoded
+// body of the response.
+func mockRequest(c *gc.C, srv *httptest.Server, method, body string) (int, interface{}) {
+	req, err := http.NewRequest(method, srv.URL, strings.NewReader(body))
+	c.Assert(err, jc.ErrorIsNil)
+	resp, err := http.DefaultClient.Do(req)
+	c.Assert(err, jc.ErrorIsNil)
+	defer resp.Body.Close()
+	c.Check(resp.Header.Get("Content-Type"), gc.Equals, "application/json")
+	data, err := io.ReadAll(resp.Body)
+	c.Assert(err, jc.ErrorIsNil)
+	var out interface{}
+	c.Assert(json.Unmarshal(data, &out), jc.ErrorIsNil)
+	return resp.StatusCode, out
+}
+
+func (MockSuite) TestGet(c *gc.C) {
+	srv := newMockServer(c)
+	defer srv.Close()
+	status, body := mockRequest(c, srv, "GET", "")
+	c.Check(status, gc.Equals, http.StatusOK)
+	c.Check(body, jc.DeepEquals, map[string]interface{}{"name": "example", "port": float64(17070)})
+}
+
+func (MockSuite) TestPost(c *gc.C) {
+	srv := newMockServer(c)
+	defer srv.Close()
+	status, body := mockRequest(c, srv, "POST", `{"name": "juju"}`)
+	c.Check(status, gc.Equals, http.StatusOK)
+	c.Check(body, jc.DeepEquals, map[string]interface{}{"name": "juju", "port": float64(17070)})
+
+	status, body = mockRequest(c, srv, "PUT", `{"port": 70000}`)
+	c.Check(status, gc.Equals, http.StatusUnprocessableEntity)
+	errs := body.(map[string]interface{})["errors"].([]interface{})
+	c.Assert(errs, gc.HasLen, 2)
+	c.Check(errs[0], jc.DeepEquals, map[string]interface{}{
+		"path":    "name",
+		"keyword": "required",
+		"message": "property is required",
+	})
+	c.Check(errs[1].(map[string]interface{})["path"], gc.Equals, "port")
+
+	status, body = mockRequest(c, srv, "POST", `{`)
+	c.Check(status, gc.Equals, http.StatusBadRequest)
+	c.Check(body.(map[string]interface{})["errors"], gc.HasLen, 1)
+}
+
+func (MockSuite) TestMethodNotAllowed(c *gc.C) {
+	srv := newMockServer(c)
+	defer srv.Close()
+	status, _ := mockRequest(c, srv, "DELETE", "")
+	c.Check(status, gc.Equals, http.StatusMethodNotAllowed)
+}
+
+func (MockSuite) TestMockHandlerError(c *gc.C) {
+	_, err := MockHandler(&Schema{Type: []Type{StringType}, MinLength: new(int), MaxLength: new(int), Format: FormatEmail})
+	c.Check(err, gc.ErrorMatches, `cannot generate document: .*`)
+}
@@ -0,0 +1,69 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/lestrrat/go-jsschema"
+)
+
+// multipleOfTolerance holds the difference from an exact multiple, relative
+// to the divisor, within which a number is taken to be a multiple, so that
+// the rounding of decimal fractions such as 0.1 doesn't make 0.3 fail a
+// multipleOf of 0.1.
+const multipleOfTolerance = 1e-9
+
+// epsilon holds the difference between 1 and the next larger float64.
+const epsilon = 0x1p-52
+
+// multipleOfErrors returns an error for each of the composed schemas whose
+// multipleOf x, found at path, isn't a multiple of.
+func multipleOfErrors(composed []*Schema, x interface{}, path string) ValidationErrors {
+	f, ok := normalizeValue(x).(float64)
+	if !ok {
+		return nil
+	}
+	var errs ValidationErrors
+	for _, cs := range composed {
+		if cs.MultipleOf == nil || isMultipleOf(f, *cs.MultipleOf) {
+			continue
+		}
+		errs = append(errs, &ValidationError{
+			Path:    path,
+			Keyword: "multipleOf",
+			Err:     fmt.Errorf("must be a multiple of %v", *cs.MultipleOf),
+		})
+	}
+	return errs
+}
+
+// isMultipleOf reports whether f is a multiple of m. Integers are compared
+// exactly, and other numbers to within multipleOfTolerance, allowing also
+// for the rounding of f itself when it is large. A multipleOf
+// that isn't a positive number, and values that aren't finite, are left to
+// be rejected elsewhere.
+func isMultipleOf(f, m float64) bool {
+	if !(m > 0) || math.IsInf(m, 0) || math.IsNaN(f) || math.IsInf(f, 0) {
+		return true
+	}
+	if f == math.Trunc(f) && m == math.Trunc(m) {
+		return math.Mod(f, m) == 0
+	}
+	r := math.Abs(math.Remainder(f, m))
+	return r <= multipleOfTolerance*m+4*math.Abs(f)*epsilon
+}
+
+// multipleOfInternal adjusts in, the internal form of s, so that multipleOf
+// is only checked by this package, as jsschema finds the remainder exactly
+// and so rejects numbers such as 0.3 as multiples of 0.1. It is not taken
+// into account where a schema is only checked by jsschema, as when deciding
+// whether an if schema matches. The internal form is only adjusted for
+// validation, so that s is still marshaled with its own keywords.
+func multipleOfInternal(s *Schema, in *schema.Schema) {
+	if s.MultipleOf != nil {
+		in.MultipleOf = schema.Number{}
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"encoding/json"
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type MultipleOfSuite struct{}
+
+var _ = gc.Suite(MultipleOfSuite{})
+
+var multipleOfSchema = `
+type: object
+properties:
+  memory:
+    type: integer
+    multipleOf: 256
+    minimum: 256
+  cpu-power:
+    type: number
+    multipleOf: 0.1
+  ratio:
+    type: number
+    multipleOf: 0.01
+`
+
+func (MultipleOfSuite) TestMultipleOf(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(multipleOfSchema))
+	c.Assert(err, jc.ErrorIsNil)
+	for i, test := range []struct {
+		doc map[string]interface{}
+		err string
+	}{{
+		doc: map[string]interface{}{"memory": 1024, "cpu-power": 0.3, "ratio": 0.07},
+	}, {
+		doc: map[string]interface{}{"memory": 512.0, "cpu-power": 2.2, "ratio": 1.15},
+	}, {
+		doc: map[string]interface{}{"cpu-power": 0.0, "ratio": -0.29},
+	}, {
+		doc: map[string]interface{}{"memory": 1000},
+		err: `memory: must be a multiple of 256`,
+	}, {
+		doc: map[string]interface{}{"cpu-power": 0.35},
+		err: `cpu-power: must be a multiple of 0.1`,
+	}, {
+		doc: map[string]interface{}{"ratio": 0.005},
+		err: `ratio: must be a multiple of 0.01`,
+	}} {
+		c.Logf("test %d: %v", i, test.doc)
+		err := s.Validate(test.doc)
+		if test.err == "" {
+			c.Check(err, jc.ErrorIsNil)
+			continue
+		}
+		c.Check(err, gc.ErrorMatches, test.err)
+		c.Check(err.(*ValidationError).Keyword, gc.Equals, "multipleOf")
+	}
+}
+
+func (MultipleOfSuite) TestMultipleOfWithOtherErrors(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(multipleOfSchema))
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.Validate(map[string]interface{}{"memory": 100})
+	c.Assert(err, gc.FitsTypeOf, ValidationErrors{})
+	errs := err.(ValidationErrors)
+	c.Assert(errs, gc.HasLen, 2)
+	c.Check(errs[0], gc.ErrorMatches, `memory: .*minimum.*`)
+	c.Check(errs[1], gc.ErrorMatches, `memory: must be a multiple of 256`)
+}
+
+func (MultipleOfSuite) TestIsMultipleOf(c *gc.C) {
+	for i, test := range []struct {
+		f, m float64
+		want bool
+	}{
+		{f: 0.3, m: 0.1, want: true},
+		{f: 0.7, m: 0.1, want: true},
+		{f: 1e10, m: 0.1, want: true},
+		{f: 1e10 + 0.05, m: 0.1, want: false},
+		{f: 9007199254740992, m: 3, want: false},
+		{f: 9007199254740990, m: 3, want: true},
+		{f: 10, m: 2.5, want: true},
+		{f: 11, m: 2.5, want: false},
+		{f: -8080, m: 8080, want: true},
+	} {
+		c.Logf("test %d: %v multipleOf %v", i, test.f, test.m)
+		c.Check(isMultipleOf(test.f, test.m), gc.Equals, test.want)
+	}
+}
+
+func (MultipleOfSuite) TestMultipleOfRoundTrip(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(multipleOfSchema))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.Validate(map[string]interface{}{"cpu-power": 0.3}), jc.ErrorIsNil)
+	data, err := json.Marshal(s)
+	c.Assert(err, jc.ErrorIsNil)
+	var out Schema
+	c.Assert(json.Unmarshal(data, &out), jc.ErrorIsNil)
+	c.Assert(out.Properties["cpu-power"].MultipleOf, gc.NotNil)
+	c.Check(*out.Properties["cpu-power"].MultipleOf, gc.Equals, 0.1)
+}
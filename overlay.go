@@ -0,0 +1,229 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// SchemaPatch holds an overlay to apply to a schema with ApplyOverlay. It is
+// written in the same format as a schema, but only the keywords which are set
+// are applied.
+type SchemaPatch struct {
+	Schema
+}
+
+// ApplyOverlay returns a copy of base with the overlay applied to it. This
+// allows schemas provided by a third party to be adjusted without editing
+// them. An overlay may:
+//
+//   - replace annotations, such as titles, descriptions, examples and
+//     defaults;
+//   - mark properties as secret or immutable;
+//   - tighten constraints, by raising minimums, lowering maximums,
+//     restricting types and enums, and adding required properties, patterns
+//     and formats;
+//   - do the same for any property already defined by base.
+//
+// An error is returned if the overlay would loosen a constraint, refers to a
+// property not defined by base, or uses any other keyword.
+func ApplyOverlay(base *Schema, overlay SchemaPatch) (*Schema, error) {
+	out := cloneSchema(base)
+	if err := applyOverlay(out, &overlay.Schema, ""); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func applyOverlay(s, patch *Schema, path string) error {
+	// rest holds the keywords in patch that haven't been applied yet.
+	rest := *patch
+	fail := func(format string, args ...interface{}) error {
+		where := "schema"
+		if path != "" {
+			where = fmt.Sprintf("property %q", path)
+		}
+		return fmt.Errorf("cannot apply overlay to %s: %s", where, fmt.Sprintf(format, args...))
+	}
+
+	// Annotations are simply replaced.
+	if patch.Title != "" {
+		s.Title, rest.Title = patch.Title, ""
+	}
+	if patch.Description != "" {
+		s.Description, rest.Description = patch.Description, ""
+	}
+	if patch.Titles != nil {
+		s.Titles, rest.Titles = patch.Titles, nil
+	}
+	if patch.Descriptions != nil {
+		s.Descriptions, rest.Descriptions = patch.Descriptions, nil
+	}
+	if patch.EnumLabels != nil {
+		s.EnumLabels, rest.EnumLabels = patch.EnumLabels, nil
+	}
+	if patch.EnumDescriptions != nil {
+		s.EnumDescriptions, rest.EnumDescriptions = patch.EnumDescriptions, nil
+	}
+	if patch.PatternDescription != "" {
+		s.PatternDescription, rest.PatternDescription = patch.PatternDescription, ""
+	}
+	if patch.PatternDescriptions != nil {
+		s.PatternDescriptions, rest.PatternDescriptions = patch.PatternDescriptions, nil
+	}
+	if patch.Default != nil {
+		s.Default, rest.Default = patch.Default, nil
+	}
+	if patch.DefaultWhen != nil {
+		s.DefaultWhen, rest.DefaultWhen = patch.DefaultWhen, nil
+	}
+	if patch.Example != nil {
+		s.Example, rest.Example = patch.Example, nil
+	}
+	if patch.Examples != nil {
+		s.Examples, rest.Examples = patch.Examples, nil
+	}
+	if patch.PromptDefault != nil {
+		s.PromptDefault, rest.PromptDefault = patch.PromptDefault, nil
+	}
+	if patch.Singular != "" {
+		s.Singular, rest.Singular = patch.Singular, ""
+	}
+	if patch.Plural != "" {
+		s.Plural, rest.Plural = patch.Plural, ""
+	}
+	if patch.Order != nil {
+		s.Order, rest.Order = patch.Order, nil
+	}
+	if patch.Group != "" {
+		s.Group, rest.Group = patch.Group, ""
+	}
+	if patch.EnvVars != nil {
+		s.EnvVars, rest.EnvVars = patch.EnvVars, nil
+	}
+	s.Secret = s.Secret || patch.Secret
+	s.Immutable = s.Immutable || patch.Immutable
+	rest.Secret, rest.Immutable = false, false
+
+	// Constraints may only become stricter.
+	if patch.Minimum != nil {
+		if s.Minimum != nil && *patch.Minimum < *s.Minimum {
+			return fail("minimum %v is lower than %v", *patch.Minimum, *s.Minimum)
+		}
+		s.Minimum, rest.Minimum = patch.Minimum, nil
+	}
+	if patch.Maximum != nil {
+		if s.Maximum != nil && *patch.Maximum > *s.Maximum {
+			return fail("maximum %v is higher than %v", *patch.Maximum, *s.Maximum)
+		}
+		s.Maximum, rest.Maximum = patch.Maximum, nil
+	}
+	for _, limit := range []struct {
+		name       string
+		base, over **int
+		isMax      bool
+		restField  **int
+	}{
+		{"minLength", &s.MinLength, &patch.MinLength, false, &rest.MinLength},
+		{"maxLength", &s.MaxLength, &patch.MaxLength, true, &rest.MaxLength},
+		{"minItems", &s.MinItems, &patch.MinItems, false, &rest.MinItems},
+		{"maxItems", &s.MaxItems, &patch.MaxItems, true, &rest.MaxItems},
+		{"minProperties", &s.MinProperties, &patch.MinProperties, false, &rest.MinProperties},
+		{"maxProperties", &s.MaxProperties, &patch.MaxProperties, true, &rest.MaxProperties},
+	} {
+		over := *limit.over
+		if over == nil {
+			continue
+		}
+		if base := *limit.base; base != nil {
+			if limit.isMax && *over > *base {
+				return fail("%s %d is higher than %d", limit.name, *over, *base)
+			}
+			if !limit.isMax && *over < *base {
+				return fail("%s %d is lower than %d", limit.name, *over, *base)
+			}
+		}
+		*limit.base, *limit.restField = over, nil
+	}
+	if patch.Type != nil {
+		for _, t := range patch.Type {
+			if len(s.Type) > 0 && !hasType(s, t) {
+				return fail("type %s is not allowed by the schema", t)
+			}
+		}
+		s.Type, rest.Type = patch.Type, nil
+	}
+	if patch.Enum != nil {
+		for _, v := range patch.Enum {
+			if len(s.Enum) > 0 && !enumContains(s.Enum, v) {
+				return fail("enum value %v is not allowed by the schema", v)
+			}
+		}
+		s.Enum, rest.Enum = patch.Enum, nil
+	}
+	s.Required, rest.Required = appendMissing(s.Required, patch.Required...), nil
+
+	// Patterns and formats can't be compared, so if the schema already has
+	// one both must hold.
+	if patch.Pattern != nil {
+		if s.Pattern == nil {
+			s.Pattern = patch.Pattern
+		} else if s.Pattern.String() != patch.Pattern.String() {
+			s.AllOf = append(s.AllOf, &Schema{Pattern: patch.Pattern})
+		}
+		rest.Pattern = nil
+	}
+	if patch.Format != "" {
+		if s.Format == "" {
+			s.Format = patch.Format
+		} else if s.Format != patch.Format {
+			s.AllOf = append(s.AllOf, &Schema{Format: patch.Format})
+		}
+		rest.Format = ""
+	}
+
+	for _, name := range sortedKeys(patch.Properties) {
+		ps, ok := s.Properties[name]
+		if !ok {
+			return fail("property %q is not defined", name)
+		}
+		if err := applyOverlay(ps, patch.Properties[name], propertyPath(path, name)); err != nil {
+			return err
+		}
+	}
+	rest.Properties = nil
+
+	if keywords := setKeywords(&rest); len(keywords) > 0 {
+		return fail("cannot change %s", strings.Join(keywords, ", "))
+	}
+	return nil
+}
+
+// setKeywords returns the json names of the keywords set in s.
+func setKeywords(s *Schema) []string {
+	var keywords []string
+	v := reflect.ValueOf(s).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		if v.Field(i).IsZero() {
+			continue
+		}
+		name := fieldKeyword(v.Type().Field(i))
+		if name == "" {
+			name = v.Type().Field(i).Name
+		}
+		keywords = append(keywords, name)
+	}
+	return keywords
+}
+
+func enumContains(enum []interface{}, v interface{}) bool {
+	for _, e := range enum {
+		if valuesEqual(e, v) {
+			return true
+		}
+	}
+	return false
+}
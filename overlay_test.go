@@ -0,0 +1,125 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"regexp"
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type OverlaySuite struct{}
+
+var _ = gc.Suite(OverlaySuite{})
+
+const vendorSchema = `
+type: object
+properties:
+  token:
+    type: string
+    minLength: 8
+    maxLength: 128
+  replicas:
+    type: integer
+    minimum: 1
+    maximum: 10
+  mode:
+    type: string
+    enum: [fast, safe, debug]
+`
+
+func loadOverlay(c *gc.C, overlay string) SchemaPatch {
+	s, err := FromYAML(strings.NewReader(overlay))
+	c.Assert(err, jc.ErrorIsNil)
+	return SchemaPatch{*s}
+}
+
+func (OverlaySuite) TestApplyOverlay(c *gc.C) {
+	base, err := FromYAML(strings.NewReader(vendorSchema))
+	c.Assert(err, jc.ErrorIsNil)
+
+	out, err := ApplyOverlay(base, loadOverlay(c, `
+description: Settings for the vendor charm.
+required: [token]
+properties:
+  token:
+    description: The API token.
+    secret: true
+    minLength: 32
+  replicas:
+    maximum: 5
+    default: 3
+  mode:
+    enum: [fast, safe]
+`))
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(out.Description, gc.Equals, "Settings for the vendor charm.")
+	c.Check(out.Required, jc.DeepEquals, []string{"token"})
+	token := out.Properties["token"]
+	c.Check(token.Description, gc.Equals, "The API token.")
+	c.Check(token.Secret, jc.IsTrue)
+	c.Check(*token.MinLength, gc.Equals, 32)
+	c.Check(*token.MaxLength, gc.Equals, 128)
+	c.Check(*out.Properties["replicas"].Maximum, gc.Equals, 5.0)
+	c.Check(out.Properties["replicas"].Default, gc.Equals, float64(3))
+	c.Check(out.Properties["mode"].Enum, jc.DeepEquals, []interface{}{"fast", "safe"})
+
+	// The base schema is unchanged.
+	c.Check(base.Properties["token"].Secret, jc.IsFalse)
+	c.Check(*base.Properties["token"].MinLength, gc.Equals, 8)
+
+	err = out.Validate(map[string]interface{}{"token": strings.Repeat("x", 32), "replicas": 5})
+	c.Check(err, jc.ErrorIsNil)
+	err = out.Validate(map[string]interface{}{"token": strings.Repeat("x", 32), "mode": "debug"})
+	c.Check(err, gc.NotNil)
+}
+
+func (OverlaySuite) TestApplyOverlayPattern(c *gc.C) {
+	base := &Schema{
+		Type:    []Type{StringType},
+		Pattern: regexp.MustCompile(`^[a-z]+$`),
+	}
+	out, err := ApplyOverlay(base, SchemaPatch{Schema{
+		Pattern: regexp.MustCompile(`^abc`),
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(out.Validate("abcdef"), jc.ErrorIsNil)
+	c.Check(out.Validate("defabc"), gc.NotNil)
+	c.Check(out.Validate("abc1"), gc.NotNil)
+}
+
+func (OverlaySuite) TestApplyOverlayErrors(c *gc.C) {
+	base, err := FromYAML(strings.NewReader(vendorSchema))
+	c.Assert(err, jc.ErrorIsNil)
+
+	for i, test := range []struct {
+		overlay string
+		err     string
+	}{{
+		overlay: "properties: {token: {minLength: 4}}",
+		err:     `cannot apply overlay to property "token": minLength 4 is lower than 8`,
+	}, {
+		overlay: "properties: {replicas: {maximum: 20}}",
+		err:     `cannot apply overlay to property "replicas": maximum 20 is higher than 10`,
+	}, {
+		overlay: "properties: {mode: {enum: [fast, reckless]}}",
+		err:     `cannot apply overlay to property "mode": enum value reckless is not allowed by the schema`,
+	}, {
+		overlay: "properties: {mode: {type: integer}}",
+		err:     `cannot apply overlay to property "mode": type integer is not allowed by the schema`,
+	}, {
+		overlay: "properties: {other: {type: string}}",
+		err:     `cannot apply overlay to schema: property "other" is not defined`,
+	}, {
+		overlay: "properties: {token: {not: {maxLength: 10}}}",
+		err:     `cannot apply overlay to property "token": cannot change not`,
+	}} {
+		c.Logf("test %d: %s", i, test.overlay)
+		_, err := ApplyOverlay(base, loadOverlay(c, test.overlay))
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+// PatternError is the error found in a string which doesn't match the
+// pattern of a schema with a pattern description. Its message is the
+// description.
+type PatternError struct {
+	// Description holds the pattern description, in the language given
+	// by ValidationOptions.Language where the schema translates it.
+	Description string
+
+	// descriptions holds the translations of Description.
+	descriptions map[string]string
+}
+
+// Error implements the error interface.
+func (e *PatternError) Error() string {
+	return e.Description
+}
+
+// patternErrors returns an error for each of the composed schemas with a
+// pattern description whose pattern x, found at path, doesn't match, if x
+// is a string.
+func patternErrors(composed []*Schema, x interface{}, path string) ValidationErrors {
+	str, ok := x.(string)
+	if !ok {
+		return nil
+	}
+	var errs ValidationErrors
+	for _, cs := range composed {
+		if cs.Pattern == nil || cs.PatternDescription == "" || cs.Pattern.MatchString(str) {
+			continue
+		}
+		errs = append(errs, &ValidationError{
+			Path:    path,
+			Keyword: "pattern",
+			Err:     &PatternError{Description: cs.PatternDescription, descriptions: cs.PatternDescriptions},
+		})
+	}
+	return errs
+}
+
+// localizeErrors translates the pattern descriptions given by errs into
+// the language identified by the given language tag, falling back in the
+// same way as Schema.LocalizedPatternDescription.
+func localizeErrors(errs ValidationErrors, lang string) ValidationErrors {
+	if lang == "" {
+		return errs
+	}
+	for _, e := range errs {
+		if pe, ok := e.Err.(*PatternError); ok {
+			e.Err = &PatternError{
+				Description:  localize(pe.descriptions, lang, pe.Description),
+				descriptions: pe.descriptions,
+			}
+		}
+	}
+	return errs
+}
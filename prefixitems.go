@@ -0,0 +1,50 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import "github.com/lestrrat/go-jsschema"
+
+// positionalInternal adjusts in, the internal form of s, held in cache, if
+// s has positional items, given by prefixItems or by items in tuple mode.
+// The internal form is only adjusted for validation, so that s is still
+// marshaled with its own items.
+//
+// jsschema only knows the positional items of earlier drafts, which
+// prefixItems are given as, with the items for the rest of the array given
+// as additionalItems. It also checks the last positional item against
+// additionalItems, so the schema for the rest of the array is added as a
+// final positional item too, which it applies to anyway.
+func positionalInternal(s *Schema, in *schema.Schema, cache map[*Schema]*schema.Schema) error {
+	if len(s.PrefixItems) == 0 {
+		if s.Items == nil || !s.Items.TupleMode || in.AdditionalItems == nil || in.AdditionalItems.Schema == nil {
+			return nil
+		}
+		in.Items = &schema.ItemSpec{
+			TupleMode: true,
+			Schemas:   append(in.Items.Schemas[:len(in.Items.Schemas):len(in.Items.Schemas)], in.AdditionalItems.Schema),
+		}
+		return nil
+	}
+	prefix, err := toInternalSchemaList(s.PrefixItems, cache)
+	if err != nil {
+		return err
+	}
+	in.Items = &schema.ItemSpec{TupleMode: true, Schemas: prefix}
+	switch {
+	case s.Items == nil || len(s.Items.Schemas) == 0:
+		in.AdditionalItems = &schema.AdditionalItems{}
+	case rejectsAll(s.Items.Schemas[0]):
+		// jsschema rejects any items after the positional ones unless
+		// additionalItems is given.
+		in.AdditionalItems = nil
+	default:
+		rest, err := toInternal(s.Items.Schemas[0], cache)
+		if err != nil {
+			return err
+		}
+		in.Items.Schemas = append(prefix[:len(prefix):len(prefix)], rest)
+		in.AdditionalItems = &schema.AdditionalItems{Schema: rest}
+	}
+	return nil
+}
@@ -0,0 +1,176 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type PrefixItemsSuite struct{}
+
+var _ = gc.Suite(PrefixItemsSuite{})
+
+func (PrefixItemsSuite) TestPrefixItems(c *gc.C) {
+	for i, test := range []struct {
+		schema string
+		valid  [][]interface{}
+		errors map[string][]interface{}
+	}{{
+		schema: `{"type": "array", "prefixItems": [{"type": "string"}, {"type": "integer"}]}`,
+		valid: [][]interface{}{
+			{},
+			{"a"},
+			{"a", 1},
+			{"a", 1, true, "anything"},
+		},
+		errors: map[string][]interface{}{
+			`.*`: {1, 1},
+		},
+	}, {
+		schema: `{"type": "array", "prefixItems": [{"type": "string"}], "items": {"type": "integer"}}`,
+		valid: [][]interface{}{
+			{"a"},
+			{"a", 1},
+			{"a", 1, 2, 3},
+		},
+		errors: map[string][]interface{}{
+			`\[2\]: .*`: {"a", 1, "b"},
+			`\[0\]: .*`: {1, 2},
+		},
+	}, {
+		schema: `{"type": "array", "prefixItems": [{"type": "string"}, {"type": "integer"}], "items": false}`,
+		valid: [][]interface{}{
+			{"a", 1},
+		},
+		errors: map[string][]interface{}{
+			`\[2\]: must not match the schema`: {"a", 1, 2},
+		},
+	}} {
+		c.Logf("test %d: %s", i, test.schema)
+		s, err := FromJSON(strings.NewReader(test.schema))
+		c.Assert(err, jc.ErrorIsNil)
+		data, err := json.Marshal(s)
+		c.Assert(err, jc.ErrorIsNil)
+		var out Schema
+		c.Assert(json.Unmarshal(data, &out), jc.ErrorIsNil)
+		for _, s := range []*Schema{s, &out} {
+			for _, doc := range test.valid {
+				c.Check(s.Validate(doc), jc.ErrorIsNil, gc.Commentf("%v", doc))
+			}
+			for msg, doc := range test.errors {
+				c.Check(s.Validate(doc), gc.ErrorMatches, msg, gc.Commentf("%v", doc))
+			}
+		}
+	}
+}
+
+func (PrefixItemsSuite) TestPrefixItemsNested(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(`
+type: object
+properties:
+  point:
+    type: array
+    prefixItems:
+    - {type: integer, minimum: 0}
+    - {type: integer, validators: [prefix-items-even]}
+`))
+	c.Assert(err, jc.ErrorIsNil)
+	RegisterValidator("prefix-items-even", func(_ ValidationOptions, v interface{}) error {
+		if n, ok := v.(int); ok && n%2 != 0 {
+			return errors.New("odd")
+		}
+		return nil
+	})
+	c.Check(s.Validate(map[string]interface{}{"point": []interface{}{1, 2}}), jc.ErrorIsNil)
+	c.Check(s.Validate(map[string]interface{}{"point": []interface{}{1, 3}}), gc.ErrorMatches, `point\[1\]: odd`)
+	c.Check(s.Validate(map[string]interface{}{"point": []interface{}{-1, 2}}), gc.ErrorMatches, `point\[0\]: .*`)
+}
+
+func (PrefixItemsSuite) TestPrefixItemsWithUnevaluatedItems(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(`
+type: array
+allOf:
+- prefixItems: [{type: string}]
+unevaluatedItems: false
+`))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.Validate([]interface{}{"a"}), jc.ErrorIsNil)
+	c.Check(s.Validate([]interface{}{"a", "b"}), gc.ErrorMatches, `\[1\]: unevaluated items are not allowed`)
+}
+
+func (PrefixItemsSuite) TestAdditionalItems(c *gc.C) {
+	for i, test := range []struct {
+		schema string
+		valid  [][]interface{}
+		errors map[string][]interface{}
+	}{{
+		schema: `{"type": "array", "items": [{"type": "string"}, {"type": "integer"}]}`,
+		valid: [][]interface{}{
+			{"a"},
+			{"a", 1},
+		},
+		errors: map[string][]interface{}{
+			`.*additional elements found in array`: {"a", 1, 2},
+		},
+	}, {
+		schema: `{"type": "array", "items": [{"type": "string"}, {"type": "integer"}], "additionalItems": false}`,
+		valid: [][]interface{}{
+			{"a", 1},
+		},
+		errors: map[string][]interface{}{
+			`.*additional elements found in array`: {"a", 1, 2},
+		},
+	}, {
+		schema: `{"type": "array", "items": [{"type": "string"}, {"type": "integer"}], "additionalItems": true}`,
+		valid: [][]interface{}{
+			{"a", 1},
+			{"a", 1, true, "anything"},
+		},
+		errors: map[string][]interface{}{
+			`\[1\]: .*`: {"a", "b"},
+		},
+	}, {
+		schema: `{"type": "array", "items": [{"type": "string"}, {"type": "integer"}], "additionalItems": {"type": "boolean"}}`,
+		valid: [][]interface{}{
+			{"a"},
+			{"a", 1},
+			{"a", 1, true, false},
+		},
+		errors: map[string][]interface{}{
+			`\[2\]: .*`: {"a", 1, "b"},
+			`\[3\]: .*`: {"a", 1, true, 2},
+		},
+	}, {
+		schema: `{"type": "array", "items": [], "additionalItems": {"type": "boolean"}}`,
+		valid: [][]interface{}{
+			{},
+			{true, false},
+		},
+		errors: map[string][]interface{}{
+			`\[0\]: .*`: {1},
+		},
+	}} {
+		c.Logf("test %d: %s", i, test.schema)
+		s, err := FromJSON(strings.NewReader(test.schema))
+		c.Assert(err, jc.ErrorIsNil)
+		data, err := json.Marshal(s)
+		c.Assert(err, jc.ErrorIsNil)
+		var out Schema
+		c.Assert(json.Unmarshal(data, &out), jc.ErrorIsNil)
+		c.Check(out.AdditionalItems, jc.DeepEquals, s.AdditionalItems)
+		for _, s := range []*Schema{s, &out} {
+			for _, doc := range test.valid {
+				c.Check(s.Validate(doc), jc.ErrorIsNil, gc.Commentf("%v", doc))
+			}
+			for msg, doc := range test.errors {
+				c.Check(s.Validate(doc), gc.ErrorMatches, msg, gc.Commentf("%v", doc))
+			}
+		}
+	}
+}
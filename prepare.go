@@ -0,0 +1,165 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"fmt"
+	"os"
+)
+
+// Source identifies where a value in a prepared document came from.
+type Source string
+
+const (
+	// SourceUser is the source of values supplied in the document.
+	SourceUser Source = "user"
+
+	// SourceEnv is the source of values taken from environment variables
+	// named by the env-vars keyword.
+	SourceEnv Source = "env"
+
+	// SourceDefault is the source of values taken from the default keyword.
+	SourceDefault Source = "default"
+
+	// SourceComputed is the source of values chosen by the default-when
+	// keyword, based on the values of other properties.
+	SourceComputed Source = "computed"
+)
+
+// PrepareOptions holds options for Prepare.
+type PrepareOptions struct {
+	// LookupEnv is used to look up environment variables. If it is nil,
+	// os.LookupEnv is used.
+	LookupEnv func(name string) (string, bool)
+
+	// Sources, if not nil, is filled in with the source of every value in
+	// the prepared document, keyed by dotted path. Objects are described
+	// by the sources of their properties.
+	Sources map[string]Source
+}
+
+// Prepare returns a copy of doc ready for use: missing values are taken from
+// the environment variables named by their env-vars keyword, then from their
+// defaults, quantities are converted by Coerce, and the result is validated.
+// The source of each value can be recorded in opts.Sources.
+func (s *Schema) Prepare(doc map[string]interface{}, opts PrepareOptions) (_ map[string]interface{}, err error) {
+	defer recoverPanic(&err)
+	lookupEnv := opts.LookupEnv
+	if lookupEnv == nil {
+		lookupEnv = os.LookupEnv
+	}
+	sources := make(map[string]Source)
+	out, _ := copyValue(doc).(map[string]interface{})
+	if out == nil {
+		out = make(map[string]interface{})
+	}
+	recordSources(out, "", SourceUser, sources)
+	if err := s.insertEnv(out, "", lookupEnv, sources); err != nil {
+		return nil, err
+	}
+	s.InsertDefaults(out)
+	s.recordDefaults(out, "", sources)
+	if err := s.Coerce(out); err != nil {
+		return nil, err
+	}
+	if err := s.Validate(out); err != nil {
+		return nil, err
+	}
+	if opts.Sources != nil {
+		for path, source := range sources {
+			opts.Sources[path] = source
+		}
+	}
+	return out, nil
+}
+
+// insertEnv sets the missing properties of obj, found at path, from the
+// environment variables named by their schemas.
+func (s *Schema) insertEnv(obj map[string]interface{}, path string, lookupEnv func(string) (string, bool), sources map[string]Source) error {
+	for _, name := range orderedProperties(s) {
+		ps := s.Properties[name]
+		if v, ok := obj[name]; ok {
+			if inner, ok := v.(map[string]interface{}); ok {
+				if err := ps.insertEnv(inner, propertyPath(path, name), lookupEnv, sources); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		for _, env := range ps.EnvVars {
+			raw, ok := lookupEnv(env)
+			if !ok {
+				continue
+			}
+			v, err := flatValue(ps, raw)
+			if err != nil {
+				return fmt.Errorf("environment variable %s: %v", env, err)
+			}
+			obj[name] = v
+			sources[propertyPath(path, name)] = SourceEnv
+			break
+		}
+	}
+	return nil
+}
+
+// recordDefaults records the source of the values in obj, found at path,
+// which haven't already been recorded, and so were inserted by
+// InsertDefaults.
+func (s *Schema) recordDefaults(obj map[string]interface{}, path string, sources map[string]Source) {
+	for name, v := range obj {
+		ps, ok := s.Properties[name]
+		if !ok {
+			continue
+		}
+		ppath := propertyPath(path, name)
+		if inner, ok := v.(map[string]interface{}); ok && len(ps.Properties) > 0 {
+			ps.recordDefaults(inner, ppath, sources)
+			continue
+		}
+		if _, ok := sources[ppath]; ok {
+			continue
+		}
+		source := SourceDefault
+		for _, d := range ps.DefaultWhen {
+			if d.When.Matches(obj) {
+				source = SourceComputed
+				break
+			}
+		}
+		sources[ppath] = source
+	}
+}
+
+// recordSources records source as the source of every value within x, found
+// at path.
+func recordSources(x interface{}, path string, source Source, sources map[string]Source) {
+	if obj, ok := x.(map[string]interface{}); ok && (len(obj) > 0 || path == "") {
+		for name, v := range obj {
+			recordSources(v, propertyPath(path, name), source, sources)
+		}
+		return
+	}
+	sources[path] = source
+}
+
+// copyValue returns a copy of x with every object and array within it
+// copied.
+func copyValue(x interface{}) interface{} {
+	switch x := x.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(x))
+		for k, v := range x {
+			out[k] = copyValue(v)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(x))
+		for i, v := range x {
+			out[i] = copyValue(v)
+		}
+		return out
+	}
+	return x
+}
@@ -0,0 +1,104 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type PrepareSuite struct{}
+
+var _ = gc.Suite(PrepareSuite{})
+
+const prepareSchema = `
+type: object
+properties:
+  name:
+    type: string
+  region:
+    type: string
+    env-vars: [JUJU_REGION, OS_REGION_NAME]
+    default: north
+  series:
+    type: string
+    default: focal
+  cgroups:
+    type: string
+    default: cgroup1
+    default-when:
+    - when: {series: jammy}
+      value: cgroup2
+  disk:
+    type: integer
+    unit: MiB
+    env-vars: [JUJU_DISK]
+  network:
+    type: object
+    properties:
+      mtu:
+        type: integer
+        default: 1500
+      fan:
+        type: boolean
+`
+
+func (PrepareSuite) TestPrepare(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(prepareSchema))
+	c.Assert(err, jc.ErrorIsNil)
+	env := map[string]string{
+		"OS_REGION_NAME": "south",
+		"JUJU_DISK":      "2G",
+	}
+	doc := map[string]interface{}{
+		"name":    "db",
+		"series":  "jammy",
+		"network": map[string]interface{}{"fan": true},
+	}
+	sources := make(map[string]Source)
+	out, err := s.Prepare(doc, PrepareOptions{
+		LookupEnv: func(name string) (string, bool) {
+			v, ok := env[name]
+			return v, ok
+		},
+		Sources: sources,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(out, jc.DeepEquals, map[string]interface{}{
+		"name":    "db",
+		"region":  "south",
+		"series":  "jammy",
+		"cgroups": "cgroup2",
+		"disk":    2048,
+		"network": map[string]interface{}{"fan": true, "mtu": float64(1500)},
+	})
+	c.Check(sources, jc.DeepEquals, map[string]Source{
+		"name":        SourceUser,
+		"region":      SourceEnv,
+		"series":      SourceUser,
+		"cgroups":     SourceComputed,
+		"disk":        SourceEnv,
+		"network.fan": SourceUser,
+		"network.mtu": SourceDefault,
+	})
+	// The original document is unchanged.
+	c.Check(doc["network"], jc.DeepEquals, map[string]interface{}{"fan": true})
+}
+
+func (PrepareSuite) TestPrepareErrors(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(prepareSchema))
+	c.Assert(err, jc.ErrorIsNil)
+	lookupEnv := func(name string) (string, bool) {
+		return "lots", name == "JUJU_DISK"
+	}
+	_, err = s.Prepare(nil, PrepareOptions{LookupEnv: lookupEnv})
+	c.Check(err, gc.ErrorMatches, `disk: expected a size such as 512M or 8G, got "lots"`)
+
+	_, err = s.Prepare(map[string]interface{}{"name": 1}, PrepareOptions{
+		LookupEnv: func(string) (string, bool) { return "", false },
+	})
+	c.Check(err, gc.ErrorMatches, `name: .*`)
+}
@@ -0,0 +1,191 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// extensionMarker is appended to the lines of String's output which hold
+// juju extensions, rather than standard json schema keywords.
+const extensionMarker = "  # extension"
+
+// unknownMarker is appended to the lines of String's output which hold
+// keywords not understood by this package.
+const unknownMarker = "  # unknown"
+
+// extensionKeywords holds the keywords of the juju-specific fields of
+// Schema, which are those declared from Immutable onwards.
+var extensionKeywords = func() map[string]bool {
+	t := reflect.TypeOf(Schema{})
+	first, _ := t.FieldByName("Immutable")
+	keywords := make(map[string]bool)
+	for i := first.Index[0]; i < t.NumField(); i++ {
+		if name := fieldKeyword(t.Field(i)); name != "" {
+			keywords[name] = true
+		}
+	}
+	return keywords
+}()
+
+// String returns s as indented YAML-like text, with juju extensions marked,
+// for use when debugging.
+func (s *Schema) String() string {
+	if s == nil {
+		return "<nil>"
+	}
+	return strings.Join(prettySchema(s, make(map[*Schema]bool)), "\n")
+}
+
+// prettySchema returns the lines describing s. Schemas in visiting are
+// currently being printed, and are not printed again if they are reached
+// through a cycle.
+func prettySchema(s *Schema, visiting map[*Schema]bool) []string {
+	if visiting[s] {
+		return []string{"<cycle>"}
+	}
+	visiting[s] = true
+	defer delete(visiting, s)
+
+	var lines []string
+	v := reflect.ValueOf(s).Elem()
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		name := fieldKeyword(t.Field(i))
+		if v.Field(i).IsZero() || name == "" {
+			continue
+		}
+		marker := ""
+		if extensionKeywords[name] {
+			marker = extensionMarker
+		}
+		lines = append(lines, prettyField(name, marker, v.Field(i).Interface(), visiting)...)
+	}
+	for _, k := range sortedObjectKeys(s.Unknown) {
+		lines = append(lines, prettyField(k, unknownMarker, s.Unknown[k], visiting)...)
+	}
+	if len(lines) == 0 {
+		return []string{"{}"}
+	}
+	return lines
+}
+
+// prettyField returns the lines describing the keyword with the given name
+// and value, with marker appended to the first.
+func prettyField(name, marker string, value interface{}, visiting map[*Schema]bool) []string {
+	nested := func(lines []string) []string {
+		return append([]string{name + ":" + marker}, indent(lines, "  ")...)
+	}
+	switch value := value.(type) {
+	case *Schema:
+		return nested(prettySchema(value, visiting))
+	case map[string]*Schema:
+		var lines []string
+		for _, k := range sortedKeys(value) {
+			lines = append(lines, prettyField(k, "", value[k], visiting)...)
+		}
+		return nested(lines)
+	case map[*regexp.Regexp]*Schema:
+		res := make([]*regexp.Regexp, 0, len(value))
+		for re := range value {
+			res = append(res, re)
+		}
+		sort.Slice(res, func(i, j int) bool { return res[i].String() < res[j].String() })
+		var lines []string
+		for _, re := range res {
+			lines = append(lines, prettyField(prettyScalar(re.String()), "", value[re], visiting)...)
+		}
+		return nested(lines)
+	case []*Schema:
+		return nested(prettySchemaList(value, visiting))
+	case *ItemSpec:
+		if !value.TupleMode && len(value.Schemas) == 1 {
+			return nested(prettySchema(value.Schemas[0], visiting))
+		}
+		return nested(prettySchemaList(value.Schemas, visiting))
+	case DependencyMap:
+		var lines []string
+		for _, k := range sortedNameKeys(value.Names) {
+			lines = append(lines, k+": "+prettyScalar(value.Names[k]))
+		}
+		for _, k := range sortedKeys(value.Schemas) {
+			lines = append(lines, prettyField(k, "", value.Schemas[k], visiting)...)
+		}
+		return nested(lines)
+	case []Type:
+		names := make([]string, len(value))
+		for i, t := range value {
+			names[i] = t.String()
+		}
+		if len(names) == 1 {
+			return []string{name + ": " + names[0] + marker}
+		}
+		return []string{name + ": [" + strings.Join(names, ", ") + "]" + marker}
+	case *regexp.Regexp:
+		return []string{name + ": " + prettyScalar(value.String()) + marker}
+	}
+	rv := reflect.ValueOf(value)
+	if rv.Kind() == reflect.Ptr {
+		value = rv.Elem().Interface()
+	}
+	return []string{name + ": " + prettyScalar(value) + marker}
+}
+
+func prettySchemaList(l []*Schema, visiting map[*Schema]bool) []string {
+	var lines []string
+	for _, s := range l {
+		for i, line := range prettySchema(s, visiting) {
+			if i == 0 {
+				lines = append(lines, "- "+line)
+			} else {
+				lines = append(lines, "  "+line)
+			}
+		}
+	}
+	return lines
+}
+
+// prettyScalar returns v in json form, which YAML also understands, leaving
+// strings unquoted where that is unambiguous.
+func prettyScalar(v interface{}) string {
+	if str, ok := v.(string); ok && plainString.MatchString(str) && !yamlKeywords[strings.ToLower(str)] {
+		return str
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(b)
+}
+
+// plainString matches the strings which can be printed without quotes,
+// unless they are one of yamlKeywords.
+var plainString = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_./-]*( [A-Za-z0-9_./-]+)*$`)
+
+var yamlKeywords = map[string]bool{
+	"true": true, "false": true, "yes": true, "no": true,
+	"on": true, "off": true, "null": true, "y": true, "n": true,
+}
+
+func indent(lines []string, prefix string) []string {
+	out := make([]string, len(lines))
+	for i, line := range lines {
+		out[i] = prefix + line
+	}
+	return out
+}
+
+func sortedNameKeys(m map[string][]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,109 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"fmt"
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type PrettySuite struct{}
+
+var _ = gc.Suite(PrettySuite{})
+
+func (PrettySuite) TestString(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(`
+type: object
+title: Config
+required: [name]
+properties:
+  name:
+    type: string
+    pattern: ^[a-z]+$
+    default: "true"
+    secret: true
+  port:
+    type: [integer, "null"]
+    minimum: 1
+  tags:
+    type: array
+    items:
+      type: string
+  auth:
+    anyOf:
+    - type: string
+    - type: object
+      properties:
+        token: {type: string}
+x-ui: {columns: 2}
+`))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.String(), gc.Equals, strings.TrimPrefix(`
+title: Config
+type: object
+required: ["name"]
+properties:
+  auth:
+    anyOf:
+      - type: string
+      - type: object
+        properties:
+          token:
+            type: string
+  name:
+    default: "true"
+    type: string
+    pattern: "^[a-z]+$"
+    secret: true  # extension
+  port:
+    type: [integer, null]
+    minimum: 1
+  tags:
+    type: array
+    items:
+      type: string
+x-ui: {"columns":2}  # unknown`, "\n"))
+}
+
+func (PrettySuite) TestStringCycle(c *gc.C) {
+	s := &Schema{Type: []Type{ObjectType}}
+	s.Properties = map[string]*Schema{"self": s}
+	c.Check(fmt.Sprint(s), gc.Equals, "type: object\nproperties:\n  self:\n    <cycle>")
+	c.Check((&Schema{}).String(), gc.Equals, "{}")
+	c.Check((*Schema)(nil).String(), gc.Equals, "<nil>")
+}
+
+func (PrettySuite) TestStringLaterDraftKeywords(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(`
+type: object
+const: {name: x}
+if:
+  required: [name]
+then:
+  propertyNames:
+    pattern: ^[a-z]+$
+examples: [{name: x}]
+order: [name]
+`))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.String(), gc.Equals, strings.TrimPrefix(`
+type: object
+if:
+  required: ["name"]
+then:
+  propertyNames:
+    pattern: "^[a-z]+$"
+const: {"name":"x"}
+examples: [{"name":"x"}]
+order: ["name"]  # extension`, "\n"))
+}
+
+func (PrettySuite) TestStringFieldsWithoutKeywords(c *gc.C) {
+	s := &Schema{Reference: "other.json", ExclusiveMinimumValue: Float(1)}
+	s.target = &Schema{Type: []Type{StringType}}
+	c.Check(s.String(), gc.Equals, "$ref: other.json\nexclusiveMinimum: 1")
+}
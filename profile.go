@@ -0,0 +1,31 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+// WithProfile returns s with the named profile applied to it and to every
+// schema nested within it: wherever a schema has a profile with that name,
+// its properties and required keywords are merged in. If nothing in s
+// declares the profile, s itself is returned.
+func (s *Schema) WithProfile(name string) *Schema {
+	if name == "" || !s.hasProfile(name) {
+		return s
+	}
+	out := cloneSchema(s)
+	walkSchema(out, func(s *Schema) {
+		if profile, ok := s.Profiles[name]; ok {
+			mergeObjectSchema(s, profile)
+		}
+	})
+	return out
+}
+
+func (s *Schema) hasProfile(name string) bool {
+	found := false
+	walkSchema(s, func(s *Schema) {
+		if _, ok := s.Profiles[name]; ok {
+			found = true
+		}
+	})
+	return found
+}
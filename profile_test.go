@@ -0,0 +1,76 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type ProfileSuite struct{}
+
+var _ = gc.Suite(ProfileSuite{})
+
+const profileSchema = `
+type: object
+properties:
+  endpoint:
+    type: string
+  region:
+    type: string
+  auth:
+    type: object
+    properties:
+      username:
+        type: string
+    profiles:
+      bootstrap:
+        required: [username]
+profiles:
+  bootstrap:
+    required: [endpoint]
+  update:
+    properties:
+      force:
+        type: boolean
+`
+
+func (ProfileSuite) TestValidateWithProfile(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(profileSchema))
+	c.Assert(err, jc.ErrorIsNil)
+
+	doc := map[string]interface{}{"region": "north"}
+	c.Check(s.Validate(doc), jc.ErrorIsNil)
+	c.Check(s.ValidateWith(ValidationOptions{Profile: "update"}, doc), jc.ErrorIsNil)
+	c.Check(s.ValidateWith(ValidationOptions{Profile: "bootstrap"}, doc), gc.ErrorMatches, ".*endpoint.*")
+
+	doc = map[string]interface{}{
+		"endpoint": "https://a",
+		"auth":     map[string]interface{}{},
+	}
+	c.Check(s.Validate(doc), jc.ErrorIsNil)
+	c.Check(s.ValidateWith(ValidationOptions{Profile: "bootstrap"}, doc), gc.ErrorMatches, ".*username.*")
+
+	// Properties added by a profile are only allowed with that profile.
+	doc = map[string]interface{}{"force": true}
+	c.Check(s.Validate(doc), gc.NotNil)
+	c.Check(s.ValidateWith(ValidationOptions{Profile: "update"}, doc), jc.ErrorIsNil)
+}
+
+func (ProfileSuite) TestWithProfile(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(profileSchema))
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(s.WithProfile(""), gc.Equals, s)
+	c.Check(s.WithProfile("unknown"), gc.Equals, s)
+
+	bootstrap := s.WithProfile("bootstrap")
+	c.Check(bootstrap.Required, jc.DeepEquals, []string{"endpoint"})
+	c.Check(bootstrap.Properties["auth"].Required, jc.DeepEquals, []string{"username"})
+	// The original is unchanged.
+	c.Check(s.Required, gc.HasLen, 0)
+	c.Check(s.Properties["auth"].Required, gc.HasLen, 0)
+}
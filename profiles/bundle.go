@@ -0,0 +1,150 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+// Package profiles provides ready-made schemas for documents that juju
+// itself defines, built from the primitives in package jsonschema.
+package profiles
+
+import "github.com/juju/jsonschema"
+
+// BundleSchema returns a schema for juju bundles and bundle overlays. Every
+// top level section is optional, so that an overlay containing only the
+// changes it makes is valid, and an application may be null, which removes it
+// from the bundle being overlaid.
+func BundleSchema() *jsonschema.Schema {
+	return &jsonschema.Schema{
+		Type:  []jsonschema.Type{jsonschema.ObjectType},
+		Title: "Bundle",
+		Properties: map[string]*jsonschema.Schema{
+			"name":         str("The name of the bundle."),
+			"description":  str("A description of the bundle."),
+			"series":       str("The default series for machines and applications."),
+			"default-base": str("The default base for machines and applications."),
+			"applications": mapOf("The applications to deploy, by name.", application()),
+			"machines":     mapOf("The machines to create, by id.", machine()),
+			"relations": {
+				Type:        []jsonschema.Type{jsonschema.ArrayType},
+				Description: "The relations between application endpoints.",
+				Items:       &jsonschema.ItemSpec{Schemas: []*jsonschema.Schema{relation()}},
+			},
+			"saas": mapOf("The offers consumed by the bundle, by name.", &jsonschema.Schema{
+				Type: []jsonschema.Type{jsonschema.ObjectType},
+				Properties: map[string]*jsonschema.Schema{
+					"url": str("The URL of the offer."),
+				},
+				Required: []string{"url"},
+			}),
+		},
+	}
+}
+
+func application() *jsonschema.Schema {
+	return &jsonschema.Schema{
+		Type: []jsonschema.Type{jsonschema.ObjectType, jsonschema.NullType},
+		Properties: map[string]*jsonschema.Schema{
+			"charm":       str("The charm to deploy."),
+			"channel":     str("The channel to deploy the charm from."),
+			"revision":    count("The revision of the charm."),
+			"series":      str("The series to deploy the application on."),
+			"base":        str("The base to deploy the application on."),
+			"num_units":   count("The number of units to deploy."),
+			"scale":       count("The number of units to deploy, for kubernetes models."),
+			"to":          list("The placement directives for the units."),
+			"constraints": constraints(),
+			"expose":      boolean("Whether the application is exposed."),
+			"trust":       boolean("Whether the application is trusted with the cloud credential."),
+			"options":     mapOf("The charm config settings.", scalar()),
+			"annotations": mapOf("Annotations on the application.", str("")),
+			"bindings":    mapOf("The spaces to bind endpoints to.", str("")),
+			"storage":     mapOf("The storage directives, by storage name.", str("")),
+			"devices":     mapOf("The device directives, by device name.", str("")),
+			"resources":   mapOf("The resources to use, by file name or revision.", scalar()),
+			"offers": mapOf("The offers to create, by name.", &jsonschema.Schema{
+				Type: []jsonschema.Type{jsonschema.ObjectType},
+				Properties: map[string]*jsonschema.Schema{
+					"endpoints": list("The endpoints to offer."),
+					"acl":       mapOf("The access level for each user.", str("")),
+				},
+			}),
+		},
+	}
+}
+
+func machine() *jsonschema.Schema {
+	return &jsonschema.Schema{
+		Type: []jsonschema.Type{jsonschema.ObjectType, jsonschema.NullType},
+		Properties: map[string]*jsonschema.Schema{
+			"series":      str("The series of the machine."),
+			"base":        str("The base of the machine."),
+			"constraints": constraints(),
+			"annotations": mapOf("Annotations on the machine.", str("")),
+		},
+	}
+}
+
+// relation returns the schema for a relation, which is a pair of endpoints
+// such as ["wordpress:db", "mysql:server"].
+func relation() *jsonschema.Schema {
+	s := list("")
+	minItems, maxItems := 2, 2
+	s.MinItems, s.MaxItems = &minItems, &maxItems
+	return s
+}
+
+func constraints() *jsonschema.Schema {
+	s := str("The constraints for the machines, such as \"mem=8G cores=4\".")
+	s.Format = jsonschema.FormatConstraints
+	return s
+}
+
+// scalar returns a schema that allows any single value, such as a charm
+// config setting.
+func scalar() *jsonschema.Schema {
+	return &jsonschema.Schema{
+		Type: []jsonschema.Type{
+			jsonschema.StringType,
+			jsonschema.IntegerType,
+			jsonschema.NumberType,
+			jsonschema.BooleanType,
+		},
+	}
+}
+
+func str(description string) *jsonschema.Schema {
+	return &jsonschema.Schema{
+		Type:        []jsonschema.Type{jsonschema.StringType},
+		Description: description,
+	}
+}
+
+func boolean(description string) *jsonschema.Schema {
+	return &jsonschema.Schema{
+		Type:        []jsonschema.Type{jsonschema.BooleanType},
+		Description: description,
+	}
+}
+
+func count(description string) *jsonschema.Schema {
+	min := 0.0
+	return &jsonschema.Schema{
+		Type:        []jsonschema.Type{jsonschema.IntegerType},
+		Description: description,
+		Minimum:     &min,
+	}
+}
+
+func list(description string) *jsonschema.Schema {
+	return &jsonschema.Schema{
+		Type:        []jsonschema.Type{jsonschema.ArrayType},
+		Description: description,
+		Items:       &jsonschema.ItemSpec{Schemas: []*jsonschema.Schema{str("")}},
+	}
+}
+
+func mapOf(description string, value *jsonschema.Schema) *jsonschema.Schema {
+	return &jsonschema.Schema{
+		Type:                 []jsonschema.Type{jsonschema.ObjectType},
+		Description:          description,
+		AdditionalProperties: value,
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package profiles_test
+
+import (
+	"testing"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/jsonschema/profiles"
+)
+
+func Test(t *testing.T) { gc.TestingT(t) }
+
+type BundleSuite struct{}
+
+var _ = gc.Suite(BundleSuite{})
+
+func (BundleSuite) TestValidBundle(c *gc.C) {
+	bundle := map[string]interface{}{
+		"default-base": "ubuntu@22.04",
+		"applications": map[string]interface{}{
+			"wordpress": map[string]interface{}{
+				"charm":       "wordpress",
+				"num_units":   2,
+				"to":          []interface{}{"0", "1"},
+				"constraints": "mem=4G",
+				"options": map[string]interface{}{
+					"blog-name": "juju",
+					"workers":   4,
+				},
+				"expose": true,
+			},
+			"mysql": map[string]interface{}{
+				"charm":    "mysql",
+				"channel":  "8.0/stable",
+				"bindings": map[string]interface{}{"db": "internal"},
+			},
+		},
+		"machines": map[string]interface{}{
+			"0": map[string]interface{}{"constraints": "cores=2"},
+			"1": map[string]interface{}{},
+		},
+		"relations": []interface{}{
+			[]interface{}{"wordpress:db", "mysql:server"},
+		},
+	}
+	c.Check(profiles.BundleSchema().Validate(bundle), jc.ErrorIsNil)
+}
+
+func (BundleSuite) TestValidOverlay(c *gc.C) {
+	overlay := map[string]interface{}{
+		"applications": map[string]interface{}{
+			"wordpress": map[string]interface{}{"num_units": 3},
+			"mysql":     nil,
+		},
+	}
+	c.Check(profiles.BundleSchema().Validate(overlay), jc.ErrorIsNil)
+}
+
+func (BundleSuite) TestInvalidBundles(c *gc.C) {
+	for _, test := range []struct {
+		about  string
+		bundle map[string]interface{}
+	}{{
+		about: "unknown top level section",
+		bundle: map[string]interface{}{
+			"services": map[string]interface{}{},
+		},
+	}, {
+		about: "negative unit count",
+		bundle: map[string]interface{}{
+			"applications": map[string]interface{}{
+				"wordpress": map[string]interface{}{"num_units": -1},
+			},
+		},
+	}, {
+		about: "bad constraints",
+		bundle: map[string]interface{}{
+			"machines": map[string]interface{}{
+				"0": map[string]interface{}{"constraints": "memory=4G"},
+			},
+		},
+	}, {
+		about: "relation with one endpoint",
+		bundle: map[string]interface{}{
+			"relations": []interface{}{[]interface{}{"wordpress:db"}},
+		},
+	}, {
+		about: "saas without url",
+		bundle: map[string]interface{}{
+			"saas": map[string]interface{}{"db": map[string]interface{}{}},
+		},
+	}} {
+		c.Logf("test %s", test.about)
+		c.Check(profiles.BundleSchema().Validate(test.bundle), gc.NotNil)
+	}
+}
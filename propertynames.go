@@ -0,0 +1,29 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import "fmt"
+
+// propertyNameErrors returns an error for each property of obj, found at
+// path and described by s, whose name is rejected by the keywords of
+// s.PropertyNames that are implemented by jsschema. References are
+// resolved within root.
+func propertyNameErrors(root, s *Schema, obj map[string]interface{}, path string) ValidationErrors {
+	if s.PropertyNames == nil {
+		return nil
+	}
+	var errs ValidationErrors
+	for _, name := range sortedObjectKeys(obj) {
+		err := validateInternal(root, s.PropertyNames, name)
+		if err == nil {
+			continue
+		}
+		errs = append(errs, &ValidationError{
+			Path:    propertyPath(path, name),
+			Keyword: "propertyNames",
+			Err:     fmt.Errorf("invalid property name: %v", explainError(root, s.PropertyNames, name, "", err)),
+		})
+	}
+	return errs
+}
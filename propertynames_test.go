@@ -0,0 +1,79 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type PropertyNamesSuite struct{}
+
+var _ = gc.Suite(PropertyNamesSuite{})
+
+const propertyNamesSchema = `
+type: object
+properties:
+  tags:
+    type: object
+    additionalProperties:
+      type: string
+    propertyNames:
+      pattern: "^[a-z][a-z0-9-]*$"
+      maxLength: 16
+  hosts:
+    type: object
+    additionalProperties: true
+    propertyNames:
+      format: hostname
+`
+
+func (PropertyNamesSuite) TestPropertyNames(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(propertyNamesSchema))
+	c.Assert(err, jc.ErrorIsNil)
+	v, err := NewValidator(s)
+	c.Assert(err, jc.ErrorIsNil)
+	for _, validate := range []func(interface{}) error{s.Validate, v.Validate} {
+		c.Check(validate(map[string]interface{}{
+			"tags":  map[string]interface{}{"team": "storage", "cost-centre": "42"},
+			"hosts": map[string]interface{}{"example.com": 1},
+		}), jc.ErrorIsNil)
+
+		err := validate(map[string]interface{}{
+			"tags": map[string]interface{}{"Team": "storage", "a-very-long-tag-name": "x", "ok": "y"},
+		})
+		c.Check(err, gc.ErrorMatches, `tags.Team: invalid property name: .*; `+
+			`tags.a-very-long-tag-name: invalid property name: .*`)
+		var verr *ValidationError
+		c.Assert(errors.As(err, &verr), jc.IsTrue)
+		c.Check(verr.Keyword, gc.Equals, "propertyNames")
+
+		// The keywords implemented by this package apply to the names
+		// too.
+		err = validate(map[string]interface{}{"hosts": map[string]interface{}{"-bad-": 1}})
+		c.Check(err, gc.ErrorMatches, `hosts.-bad-: .*`)
+
+		// Names are checked alongside other problems.
+		err = validate(map[string]interface{}{"tags": map[string]interface{}{"Team": 3}})
+		c.Check(err, gc.ErrorMatches, `tags.Team: invalid property name: .*; tags.Team: .*`)
+	}
+}
+
+func (PropertyNamesSuite) TestPropertyNamesRoundTrip(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(propertyNamesSchema))
+	c.Assert(err, jc.ErrorIsNil)
+	data, err := json.Marshal(s)
+	c.Assert(err, jc.ErrorIsNil)
+	var out Schema
+	c.Assert(json.Unmarshal(data, &out), jc.ErrorIsNil)
+	names := out.Properties["tags"].PropertyNames
+	c.Assert(names, gc.NotNil)
+	c.Check(names.Pattern.String(), gc.Equals, "^[a-z][a-z0-9-]*$")
+	c.Check(*names.MaxLength, gc.Equals, 16)
+	c.Check(out.Unknown, gc.HasLen, 0)
+}
@@ -0,0 +1,16 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+// provenanceError returns err, which was caused by a value of a property
+// with schema ps, annotated with the source of the property if known and
+// not already set.
+func provenanceError(ps *Schema, err *ValidationError) *ValidationError {
+	if ps.Provenance == "" || err.Source != "" {
+		return err
+	}
+	annotated := *err
+	annotated.Source = ps.Provenance
+	return &annotated
+}
@@ -0,0 +1,60 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"errors"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type ProvenanceSuite struct{}
+
+var _ = gc.Suite(ProvenanceSuite{})
+
+func (ProvenanceSuite) TestConcatRecordsProvenance(c *gc.C) {
+	openstack := &Schema{
+		ID:   "openstack provider",
+		Type: []Type{ObjectType},
+		Properties: map[string]*Schema{
+			"region": {Type: []Type{StringType}},
+		},
+		Required: []string{"region"},
+	}
+	s, err := Concat(controllerConfigSchema, openstack)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.Properties["api-port"].Provenance, gc.Equals, "controller config")
+	c.Check(s.Properties["region"].Provenance, gc.Equals, "openstack provider")
+	// The original schemas are unchanged.
+	c.Check(openstack.Properties["region"].Provenance, gc.Equals, "")
+
+	err = s.Validate(map[string]interface{}{"api-port": 17070, "region": 1})
+	c.Check(err, gc.ErrorMatches, `rejected by openstack provider: region: .*`)
+	err = s.Validate(map[string]interface{}{"api-port": 17070})
+	c.Check(err, gc.ErrorMatches, `rejected by openstack provider: region: property is required`)
+	err = s.Validate(map[string]interface{}{"api-port": "high", "region": "north"})
+	c.Check(err, gc.ErrorMatches, `rejected by controller config: api-port: .*`)
+}
+
+func (ProvenanceSuite) TestProvenanceOfCustomValidation(c *gc.C) {
+	RegisterValidator("test-no-north", func(_ ValidationOptions, v interface{}) error {
+		if v == "north" {
+			return errors.New("north is closed")
+		}
+		return nil
+	})
+	s := &Schema{
+		Type: []Type{ObjectType},
+		Properties: map[string]*Schema{
+			"region": {
+				Type:       []Type{StringType},
+				Validators: []string{"test-no-north"},
+				Provenance: "openstack provider",
+			},
+		},
+	}
+	err := s.Validate(map[string]interface{}{"region": "north"})
+	c.Check(err, gc.ErrorMatches, `rejected by openstack provider: region: north is closed`)
+}
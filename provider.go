@@ -0,0 +1,92 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Provider provides a schema which is only known at runtime, for example
+// one fetched from a cloud's discovery endpoint.
+type Provider interface {
+	// Schema returns the current schema.
+	Schema(ctx context.Context) (*Schema, error)
+}
+
+// ProviderFunc adapts a function to the Provider interface.
+type ProviderFunc func(ctx context.Context) (*Schema, error)
+
+// Schema implements Provider by calling f.
+func (f ProviderFunc) Schema(ctx context.Context) (*Schema, error) {
+	return f(ctx)
+}
+
+// StaticProvider returns a Provider which always provides s.
+func StaticProvider(s *Schema) Provider {
+	return ProviderFunc(func(context.Context) (*Schema, error) {
+		return s, nil
+	})
+}
+
+// CachingProvider is a Provider which caches the schema provided by another
+// Provider. Errors are not cached, so a failed fetch is retried by the next
+// call.
+type CachingProvider struct {
+	provider Provider
+	ttl      time.Duration
+
+	// now returns the current time. It is replaced by tests.
+	now func() time.Time
+
+	mu      sync.Mutex
+	schema  *Schema
+	fetched time.Time
+}
+
+// NewCachingProvider returns a Provider which caches the schema provided by
+// p for the given time to live. If ttl is zero, the schema is cached until
+// Invalidate is called.
+func NewCachingProvider(p Provider, ttl time.Duration) *CachingProvider {
+	return &CachingProvider{
+		provider: p,
+		ttl:      ttl,
+		now:      time.Now,
+	}
+}
+
+// Schema implements Provider. Concurrent calls made while the schema is
+// being fetched wait for the result rather than fetching it again.
+func (c *CachingProvider) Schema(ctx context.Context) (*Schema, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.schema != nil && (c.ttl == 0 || c.now().Sub(c.fetched) < c.ttl) {
+		return c.schema, nil
+	}
+	s, err := c.provider.Schema(ctx)
+	if err != nil {
+		return nil, err
+	}
+	c.schema, c.fetched = s, c.now()
+	return s, nil
+}
+
+// Invalidate discards the cached schema, so that the next call to Schema
+// fetches it again.
+func (c *CachingProvider) Invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.schema = nil
+}
+
+// ValidateProvided validates x against the schema provided by p, with the
+// values in opts, in the same way as Schema.ValidateWith.
+func ValidateProvided(ctx context.Context, p Provider, opts ValidationOptions, x interface{}) error {
+	s, err := p.Schema(ctx)
+	if err != nil {
+		return err
+	}
+	return s.ValidateWith(opts, x)
+}
@@ -0,0 +1,88 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type ProviderSuite struct{}
+
+var _ = gc.Suite(ProviderSuite{})
+
+// countingProvider provides a new schema on every call, failing while err
+// is set.
+type countingProvider struct {
+	calls int
+	err   error
+}
+
+func (p *countingProvider) Schema(context.Context) (*Schema, error) {
+	p.calls++
+	if p.err != nil {
+		return nil, p.err
+	}
+	return &Schema{Type: []Type{StringType}}, nil
+}
+
+func (ProviderSuite) TestCachingProvider(c *gc.C) {
+	p := &countingProvider{}
+	cp := NewCachingProvider(p, time.Minute)
+	now := time.Date(2026, 10, 14, 9, 0, 0, 0, time.UTC)
+	cp.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	s1, err := cp.Schema(ctx)
+	c.Assert(err, jc.ErrorIsNil)
+	now = now.Add(30 * time.Second)
+	s2, err := cp.Schema(ctx)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s2, gc.Equals, s1)
+	c.Check(p.calls, gc.Equals, 1)
+
+	// Once the schema has expired, errors are returned rather than the
+	// stale schema, and aren't cached.
+	now = now.Add(time.Minute)
+	p.err = errors.New("endpoint unavailable")
+	_, err = cp.Schema(ctx)
+	c.Check(err, gc.ErrorMatches, "endpoint unavailable")
+	p.err = nil
+	s3, err := cp.Schema(ctx)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s3, gc.Not(gc.Equals), s1)
+	c.Check(p.calls, gc.Equals, 3)
+
+	cp.Invalidate()
+	s4, err := cp.Schema(ctx)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s4, gc.Not(gc.Equals), s3)
+	c.Check(p.calls, gc.Equals, 4)
+}
+
+func (ProviderSuite) TestCachingProviderNoTTL(c *gc.C) {
+	p := &countingProvider{}
+	cp := NewCachingProvider(p, 0)
+	for i := 0; i < 3; i++ {
+		_, err := cp.Schema(context.Background())
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	c.Check(p.calls, gc.Equals, 1)
+}
+
+func (ProviderSuite) TestValidateProvided(c *gc.C) {
+	ctx := context.Background()
+	p := StaticProvider(&Schema{Type: []Type{StringType}})
+	c.Check(ValidateProvided(ctx, p, ValidationOptions{}, "x"), jc.ErrorIsNil)
+	c.Check(ValidateProvided(ctx, p, ValidationOptions{}, 1), gc.NotNil)
+
+	failing := ProviderFunc(func(context.Context) (*Schema, error) {
+		return nil, errors.New("no schema")
+	})
+	c.Check(ValidateProvided(ctx, failing, ValidationOptions{}, "x"), gc.ErrorMatches, "no schema")
+}
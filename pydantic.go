@@ -0,0 +1,531 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// GeneratePydantic returns a Python module declaring pydantic models for the
+// documents described by s, so that Python clients can validate documents
+// in the same way before sending them. The documents are modelled by the
+// class className. Each schema that s refers to is declared alongside it,
+// named after the last element of the reference, and objects nested within
+// the properties of a model are given classes of their own, named after the
+// model and the property.
+//
+// Models forbid properties they don't declare unless their schema allows
+// additional or pattern properties, in which case any are allowed. The
+// properties of the allOf schemas a model is composed of become fields of
+// the model itself. The type, default, description, and the bounds on
+// numbers, lengths and sizes of each property are kept, as is its pattern;
+// other keywords, such as formats and the juju extensions, are not.
+func GeneratePydantic(s *Schema, className string) ([]byte, error) {
+	if !pyIdentifier.MatchString(className) || pyKeywords[className] {
+		return nil, fmt.Errorf("cannot generate pydantic models: invalid class name %q", className)
+	}
+	g := &pyGenerator{
+		root:   s,
+		names:  map[*Schema]string{s: className},
+		used:   map[string]bool{className: true},
+		queue:  []*Schema{s},
+		typing: make(map[string]bool),
+	}
+	var decls []string
+	for len(g.queue) > 0 {
+		next := g.queue[0]
+		g.queue = g.queue[1:]
+		decl, err := g.declaration(next, g.names[next])
+		if err != nil {
+			return nil, err
+		}
+		decls = append(decls, decl)
+	}
+
+	var b strings.Builder
+	b.WriteString("# Code generated by jsonschema.GeneratePydantic; DO NOT EDIT.\n\n")
+	b.WriteString("from __future__ import annotations\n\n")
+	if len(g.typing) > 0 {
+		names := make([]string, 0, len(g.typing))
+		for name := range g.typing {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		fmt.Fprintf(&b, "from typing import %s\n\n", strings.Join(names, ", "))
+	}
+	pydantic := []string{"BaseModel", "ConfigDict"}
+	if g.usesField {
+		pydantic = append(pydantic, "Field")
+	}
+	fmt.Fprintf(&b, "from pydantic import %s\n\n\n", strings.Join(pydantic, ", "))
+	b.WriteString(strings.Join(decls, "\n\n"))
+	return []byte(b.String()), nil
+}
+
+// pyIdentifier matches the names which may be used as Python identifiers.
+var pyIdentifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// pyInvalid matches the runs of characters which may not appear in Python
+// identifiers.
+var pyInvalid = regexp.MustCompile(`[^A-Za-z0-9_]+`)
+
+// pyKeywords holds the Python keywords, which may not be used as names.
+var pyKeywords = map[string]bool{
+	"False": true, "None": true, "True": true, "and": true, "as": true,
+	"assert": true, "async": true, "await": true, "break": true,
+	"class": true, "continue": true, "def": true, "del": true, "elif": true,
+	"else": true, "except": true, "finally": true, "for": true,
+	"from": true, "global": true, "if": true, "import": true, "in": true,
+	"is": true, "lambda": true, "nonlocal": true, "not": true, "or": true,
+	"pass": true, "raise": true, "return": true, "try": true,
+	"while": true, "with": true, "yield": true,
+}
+
+type pyGenerator struct {
+	root *Schema
+
+	// names holds the name declared for each schema that's given a
+	// declaration of its own.
+	names map[*Schema]string
+	used  map[string]bool
+
+	// queue holds the schemas which have been named but not declared.
+	queue []*Schema
+
+	// typing holds the names used from the typing module, and
+	// usesField whether pydantic's Field is used.
+	typing    map[string]bool
+	usesField bool
+}
+
+// declaration returns the declaration of s as the given name: a class if s
+// describes a model, and a type alias otherwise.
+func (g *pyGenerator) declaration(s *Schema, name string) (string, error) {
+	if !g.model(s) {
+		t, err := g.typeOf(s, name)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%s = %s\n", name, t), nil
+	}
+	composed := composedSchemas(g.root, s, false)
+	var b strings.Builder
+	fmt.Fprintf(&b, "class %s(BaseModel):\n", name)
+	if s.Description != "" {
+		fmt.Fprintf(&b, "    %s\n\n", strings.ReplaceAll(pyDocstring(s.Description), "\n", "\n    "))
+	}
+	extra := "forbid"
+	for _, cs := range composed {
+		if cs.AdditionalProperties != nil || len(cs.PatternProperties) > 0 {
+			extra = "allow"
+		}
+	}
+	required := make(map[string]bool)
+	seen := make(map[string]bool)
+	for _, cs := range composed {
+		for _, pname := range cs.Required {
+			required[pname] = true
+		}
+	}
+	var fields []string
+	aliased := false
+	for _, cs := range composed {
+		for _, pname := range orderedProperties(cs) {
+			if seen[pname] {
+				continue
+			}
+			seen[pname] = true
+			line, alias, err := g.field(cs.Properties[pname], name, pname, required[pname])
+			if err != nil {
+				return "", err
+			}
+			fields = append(fields, "    "+line+"\n")
+			aliased = aliased || alias
+		}
+	}
+	config := fmt.Sprintf("extra=%q", extra)
+	if aliased {
+		// Allow the fields to be given by name as well as by alias.
+		config += ", populate_by_name=True"
+	}
+	fmt.Fprintf(&b, "    model_config = ConfigDict(%s)\n\n", config)
+	b.WriteString(strings.Join(fields, ""))
+	return b.String(), nil
+}
+
+// model reports whether s describes objects which can be modelled by a
+// class of their own.
+func (g *pyGenerator) model(s *Schema) bool {
+	if s.ref() != "" || len(s.Enum) > 0 || len(s.AnyOf) > 0 || len(s.OneOf) > 0 {
+		return false
+	}
+	if len(s.Type) > 0 && !(len(s.Type) == 1 && s.Type[0] == ObjectType) {
+		return false
+	}
+	for _, cs := range composedSchemas(g.root, s, false) {
+		if len(cs.Properties) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// field returns the declaration of the field for the property name of the
+// model class owner, described by ps, and whether the field is given the
+// name as an alias because it isn't a valid field name.
+func (g *pyGenerator) field(ps *Schema, owner, name string, required bool) (string, bool, error) {
+	t, err := g.typeOf(ps, owner+pascalName(name))
+	if err != nil {
+		return "", false, err
+	}
+	field := name
+	var args []string
+	if !pyIdentifier.MatchString(field) || pyKeywords[field] || strings.HasPrefix(field, "_") {
+		field = strings.Trim(pyInvalid.ReplaceAllString(field, "_"), "_")
+		if field == "" || field[0] >= '0' && field[0] <= '9' || pyKeywords[field] {
+			field = "field_" + field
+		}
+		args = append(args, "alias="+strconv.Quote(name))
+	}
+	aliased := len(args) > 0
+	if ps.Description != "" {
+		args = append(args, "description="+strconv.Quote(ps.Description))
+	}
+	args = append(args, pyConstraints(ps)...)
+
+	var def string
+	if !required {
+		def = "None"
+		if ps.Default != nil {
+			if def, err = pyLiteral(ps.Default); err != nil {
+				return "", false, fmt.Errorf("cannot generate pydantic models: invalid default for %q: %v", name, err)
+			}
+		} else if !strings.HasPrefix(t, "Optional[") && t != "None" && t != "Any" {
+			g.typing["Optional"] = true
+			t = "Optional[" + t + "]"
+		}
+	}
+	line := fmt.Sprintf("%s: %s", field, t)
+	switch {
+	case len(args) > 0:
+		g.usesField = true
+		if def != "" {
+			args = append([]string{def}, args...)
+		}
+		line += " = Field(" + strings.Join(args, ", ") + ")"
+	case def != "":
+		line += " = " + def
+	}
+	return line, aliased, nil
+}
+
+// pyConstraints returns the arguments to pydantic's Field which check the
+// bounds and pattern given by s.
+func pyConstraints(s *Schema) []string {
+	var args []string
+	number := func(v float64) string {
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	}
+	if lo, exclusive, ok := s.lowerBound(); ok {
+		kw := "ge"
+		if exclusive {
+			kw = "gt"
+		}
+		args = append(args, kw+"="+number(lo))
+	}
+	if hi, exclusive, ok := s.upperBound(); ok {
+		kw := "le"
+		if exclusive {
+			kw = "lt"
+		}
+		args = append(args, kw+"="+number(hi))
+	}
+	if s.MultipleOf != nil {
+		args = append(args, "multiple_of="+number(*s.MultipleOf))
+	}
+	for _, c := range []struct {
+		kw    string
+		value *int
+	}{
+		{"min_length", s.MinLength},
+		{"max_length", s.MaxLength},
+		{"min_length", s.MinItems},
+		{"max_length", s.MaxItems},
+	} {
+		if c.value != nil {
+			args = append(args, c.kw+"="+strconv.Itoa(*c.value))
+		}
+	}
+	if s.Pattern != nil {
+		args = append(args, "pattern="+strconv.Quote(s.Pattern.String()))
+	}
+	return args
+}
+
+// typeOf returns the Python type of the values described by s. Objects
+// which need a class of their own are given the name hint.
+func (g *pyGenerator) typeOf(s *Schema, hint string) (string, error) {
+	if s.ref() != "" {
+		return g.refName(s)
+	}
+	if len(s.Enum) > 0 {
+		if t, ok := g.literal(s.Enum); ok {
+			return t, nil
+		}
+	}
+	if g.model(s) {
+		return g.declare(s, hint), nil
+	}
+	for _, alts := range [][]*Schema{s.AnyOf, s.OneOf} {
+		if len(alts) == 0 {
+			continue
+		}
+		union := make([]string, len(alts))
+		for j, alt := range alts {
+			altHint := hint + strconv.Itoa(j+1)
+			if alt.Title != "" {
+				altHint = hint + pascalName(alt.Title)
+			}
+			var err error
+			if union[j], err = g.typeOf(alt, altHint); err != nil {
+				return "", err
+			}
+		}
+		return g.union(union), nil
+	}
+	own, err := g.ownType(s, hint)
+	if err != nil {
+		return "", err
+	}
+	if own == "Any" {
+		// Take the type from the first of the allOf schemas which
+		// says what it is.
+		for _, sub := range s.AllOf {
+			t, err := g.typeOf(sub, hint)
+			if err != nil {
+				return "", err
+			}
+			if t != "Any" {
+				return t, nil
+			}
+		}
+		g.typing["Any"] = true
+	}
+	return own, nil
+}
+
+// ownType returns the Python type of the values described by the type of s
+// and the keywords that describe them.
+func (g *pyGenerator) ownType(s *Schema, hint string) (string, error) {
+	types := s.Type
+	if len(types) == 0 {
+		if describesObject(s) {
+			types = append(types, ObjectType)
+		}
+		if describesArray(s) {
+			types = append(types, ArrayType)
+		}
+	}
+	var union []string
+	seen := make(map[string]bool)
+	for _, t := range types {
+		var pt string
+		switch t {
+		case StringType:
+			pt = "str"
+		case IntegerType:
+			pt = "int"
+		case NumberType:
+			pt = "float"
+		case BooleanType:
+			pt = "bool"
+		case NullType:
+			pt = "None"
+		case ObjectType:
+			value := "Any"
+			if s.AdditionalProperties != nil && len(s.PatternProperties) == 0 {
+				var err error
+				if value, err = g.typeOf(s.AdditionalProperties, hint+"Value"); err != nil {
+					return "", err
+				}
+			}
+			if value == "Any" {
+				g.typing["Any"] = true
+			}
+			g.typing["Dict"] = true
+			pt = "Dict[str, " + value + "]"
+		case ArrayType:
+			var err error
+			if pt, err = g.arrayType(s, hint); err != nil {
+				return "", err
+			}
+		default:
+			pt = "Any"
+		}
+		if !seen[pt] {
+			seen[pt] = true
+			union = append(union, pt)
+		}
+	}
+	if len(union) == 0 {
+		return "Any", nil
+	}
+	return g.union(union), nil
+}
+
+// arrayType returns the Python type of the arrays described by s.
+func (g *pyGenerator) arrayType(s *Schema, hint string) (string, error) {
+	if s.Items == nil || len(s.Items.Schemas) == 0 {
+		g.typing["Any"] = true
+		g.typing["List"] = true
+		return "List[Any]", nil
+	}
+	if !s.Items.TupleMode {
+		t, err := g.typeOf(s.Items.Schemas[0], hint+"Item")
+		if err != nil {
+			return "", err
+		}
+		g.typing["List"] = true
+		return "List[" + t + "]", nil
+	}
+	g.typing["Tuple"] = true
+	if s.AdditionalItems != nil {
+		g.typing["Any"] = true
+		return "Tuple[Any, ...]", nil
+	}
+	elems := make([]string, len(s.Items.Schemas))
+	for i, item := range s.Items.Schemas {
+		var err error
+		if elems[i], err = g.typeOf(item, hint+"Item"+strconv.Itoa(i+1)); err != nil {
+			return "", err
+		}
+	}
+	return "Tuple[" + strings.Join(elems, ", ") + "]", nil
+}
+
+// union returns the union of the given types, which are distinct.
+func (g *pyGenerator) union(types []string) string {
+	if len(types) == 1 {
+		return types[0]
+	}
+	var others []string
+	for _, t := range types {
+		if t != "None" {
+			others = append(others, t)
+		}
+	}
+	if len(others) == 1 {
+		g.typing["Optional"] = true
+		return "Optional[" + others[0] + "]"
+	}
+	g.typing["Union"] = true
+	return "Union[" + strings.Join(types, ", ") + "]"
+}
+
+// literal returns the Literal type of the values in enum, reporting whether
+// they can all be given as literals.
+func (g *pyGenerator) literal(enum []interface{}) (string, bool) {
+	values := make([]string, len(enum))
+	for i, v := range enum {
+		switch v := v.(type) {
+		case string, bool, nil:
+		case float64:
+			if v != math.Trunc(v) {
+				return "", false
+			}
+		case int, int64:
+		default:
+			return "", false
+		}
+		var err error
+		if values[i], err = pyLiteral(v); err != nil {
+			return "", false
+		}
+	}
+	g.typing["Literal"] = true
+	return "Literal[" + strings.Join(values, ", ") + "]", true
+}
+
+// declare returns the name of the class declared for s, declaring it as
+// hint, or a variation of it, if it hasn't been declared already.
+func (g *pyGenerator) declare(s *Schema, hint string) string {
+	if name, ok := g.names[s]; ok {
+		return name
+	}
+	name := hint
+	for i := 2; g.used[name]; i++ {
+		name = hint + strconv.Itoa(i)
+	}
+	g.names[s] = name
+	g.used[name] = true
+	g.queue = append(g.queue, s)
+	return name
+}
+
+// refName returns the name declared for the schema that s refers to.
+func (g *pyGenerator) refName(s *Schema) (string, error) {
+	target := refTarget(g.root, s)
+	if target == nil {
+		return "", fmt.Errorf("cannot generate pydantic models: cannot resolve reference %q", s.ref())
+	}
+	return g.declare(target, pascalName(refName(s.ref()))), nil
+}
+
+// pyLiteral returns v, a value decoded from JSON or YAML, as a Python
+// literal.
+func pyLiteral(v interface{}) (string, error) {
+	switch v := v.(type) {
+	case nil:
+		return "None", nil
+	case bool:
+		if v {
+			return "True", nil
+		}
+		return "False", nil
+	case string:
+		return strconv.Quote(v), nil
+	case float64:
+		if v == math.Trunc(v) && math.Abs(v) < 1e15 {
+			return strconv.FormatFloat(v, 'f', -1, 64), nil
+		}
+		return strconv.FormatFloat(v, 'g', -1, 64), nil
+	case int:
+		return strconv.Itoa(v), nil
+	case int64:
+		return strconv.FormatInt(v, 10), nil
+	case []interface{}:
+		elems := make([]string, len(v))
+		for i, e := range v {
+			var err error
+			if elems[i], err = pyLiteral(e); err != nil {
+				return "", err
+			}
+		}
+		return "[" + strings.Join(elems, ", ") + "]", nil
+	case map[string]interface{}:
+		var elems []string
+		for _, k := range sortedObjectKeys(v) {
+			e, err := pyLiteral(v[k])
+			if err != nil {
+				return "", err
+			}
+			elems = append(elems, strconv.Quote(k)+": "+e)
+		}
+		return "{" + strings.Join(elems, ", ") + "}", nil
+	}
+	return "", fmt.Errorf("unsupported value %v of type %T", v, v)
+}
+
+// pyDocstring returns text as a Python docstring.
+func pyDocstring(text string) string {
+	text = strings.TrimSpace(text)
+	text = strings.ReplaceAll(text, `\`, `\\`)
+	text = strings.ReplaceAll(text, `"""`, `\"\"\"`)
+	return `"""` + text + `"""`
+}
@@ -0,0 +1,146 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type PydanticSuite struct{}
+
+var _ = gc.Suite(PydanticSuite{})
+
+const pydanticSchema = `
+type: object
+description: A deployed application.
+order: [name, units]
+required: [name]
+properties:
+  name:
+    type: string
+    description: The name of the application.
+    pattern: "^[a-z][a-z0-9-]*$"
+  units:
+    type: integer
+    minimum: 0
+    default: 1
+  channel:
+    enum: [stable, candidate, edge]
+  exposed:
+    type: [boolean, "null"]
+  endpoints:
+    type: array
+    items:
+      $ref: "#/definitions/endpoint"
+  config:
+    type: object
+    additionalProperties:
+      type: [string, number]
+  storage:
+    type: object
+    required: [pool]
+    properties:
+      pool:
+        type: string
+  instance-type:
+    type: string
+definitions:
+  endpoint:
+    type: object
+    required: [space]
+    properties:
+      space:
+        type: string
+      relations:
+        type: array
+        items:
+          $ref: "#/definitions/endpoint"
+`
+
+func (PydanticSuite) TestGeneratePydantic(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(pydanticSchema))
+	c.Assert(err, jc.ErrorIsNil)
+	out, err := GeneratePydantic(s, "Application")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(out), gc.Equals, `# Code generated by jsonschema.GeneratePydantic; DO NOT EDIT.
+
+from __future__ import annotations
+
+from typing import Dict, List, Literal, Optional, Union
+
+from pydantic import BaseModel, ConfigDict, Field
+
+
+class Application(BaseModel):
+    """A deployed application."""
+
+    model_config = ConfigDict(extra="forbid", populate_by_name=True)
+
+    name: str = Field(description="The name of the application.", pattern="^[a-z][a-z0-9-]*$")
+    units: int = Field(1, ge=0)
+    channel: Optional[Literal["stable", "candidate", "edge"]] = None
+    config: Optional[Dict[str, Union[str, float]]] = None
+    endpoints: Optional[List[Endpoint]] = None
+    exposed: Optional[bool] = None
+    instance_type: Optional[str] = Field(None, alias="instance-type")
+    storage: Optional[ApplicationStorage] = None
+
+
+class Endpoint(BaseModel):
+    model_config = ConfigDict(extra="forbid")
+
+    relations: Optional[List[Endpoint]] = None
+    space: str
+
+
+class ApplicationStorage(BaseModel):
+    model_config = ConfigDict(extra="forbid")
+
+    pool: str
+`)
+}
+
+func (PydanticSuite) TestGeneratePydanticAllOf(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(allOfSchema))
+	c.Assert(err, jc.ErrorIsNil)
+	out, err := GeneratePydantic(s, "Controller")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(out), jc.Contains, `
+    region: Optional[str] = None
+    name: str
+    port: int = Field(17070, le=65535)
+    instance_type: str = Field("m5.large", alias="instance-type")
+    zone: str
+`)
+}
+
+func (PydanticSuite) TestGeneratePydanticErrors(c *gc.C) {
+	_, err := GeneratePydantic(&Schema{}, "class")
+	c.Check(err, gc.ErrorMatches, `cannot generate pydantic models: invalid class name "class"`)
+	s := &Schema{Properties: map[string]*Schema{"a": {Reference: "#/definitions/missing"}}}
+	_, err = GeneratePydantic(s, "Config")
+	c.Check(err, gc.ErrorMatches, `cannot generate pydantic models: cannot resolve reference "#/definitions/missing"`)
+}
+
+func (PydanticSuite) TestPyLiteral(c *gc.C) {
+	for _, test := range []struct {
+		value interface{}
+		want  string
+	}{
+		{nil, "None"},
+		{true, "True"},
+		{float64(3), "3"},
+		{1.5, "1.5"},
+		{"a\"b", `"a\"b"`},
+		{[]interface{}{"x", false}, `["x", False]`},
+		{map[string]interface{}{"b": 1.0, "a": nil}, `{"a": None, "b": 1}`},
+	} {
+		got, err := pyLiteral(test.value)
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(got, gc.Equals, test.want)
+	}
+}
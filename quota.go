@@ -0,0 +1,89 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// checkQuotas checks x against the max-total-size and max-keys keywords of
+// the schema s, found at the root of a document, so that a document which is
+// too big can be rejected before any other work is done on it.
+func checkQuotas(s *Schema, x interface{}) error {
+	s = derefLocal(s, s)
+	if err := checkTotalSize(s, x); err != nil {
+		return &ValidationError{Keyword: "max-total-size", Err: err}
+	}
+	if err := checkKeys(s, x); err != nil {
+		return &ValidationError{Keyword: "max-keys", Err: err}
+	}
+	return nil
+}
+
+// checkTotalSize checks that x is no bigger than s.MaxTotalSize allows.
+func checkTotalSize(s *Schema, x interface{}) error {
+	if s.MaxTotalSize <= 0 {
+		return nil
+	}
+	var w countingWriter
+	enc := json.NewEncoder(&w)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(x); err != nil {
+		return err
+	}
+	// Encode ends the value with a newline.
+	if size := w.n - 1; size > s.MaxTotalSize {
+		return fmt.Errorf("size of %d bytes exceeds the limit of %d", size, s.MaxTotalSize)
+	}
+	return nil
+}
+
+// checkKeys checks that x holds no more object properties than s.MaxKeys
+// allows.
+func checkKeys(s *Schema, x interface{}) error {
+	if s.MaxKeys <= 0 {
+		return nil
+	}
+	if n := countKeys(x, s.MaxKeys); n > s.MaxKeys {
+		return fmt.Errorf("more than %d keys", s.MaxKeys)
+	}
+	return nil
+}
+
+// countKeys returns the number of object properties in x, stopping once
+// there are more than limit.
+func countKeys(x interface{}, limit int) int {
+	n := 0
+	var count func(x interface{})
+	count = func(x interface{}) {
+		if obj, ok := asObject(x); ok {
+			for _, v := range obj {
+				if n++; n > limit {
+					return
+				}
+				count(v)
+			}
+		} else if arr, ok := asArray(x); ok {
+			for _, v := range arr {
+				if n > limit {
+					return
+				}
+				count(v)
+			}
+		}
+	}
+	count(x)
+	return n
+}
+
+// countingWriter counts the bytes written to it, discarding them.
+type countingWriter struct {
+	n int
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.n += len(p)
+	return len(p), nil
+}
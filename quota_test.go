@@ -0,0 +1,99 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"math"
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type QuotaSuite struct{}
+
+var _ = gc.Suite(QuotaSuite{})
+
+const quotaSchema = `
+type: object
+properties:
+  name:
+    type: string
+  labels:
+    type: object
+    max-keys: 3
+    additionalProperties:
+      type: string
+  blob:
+    type: object
+    max-total-size: 32
+    additionalProperties:
+      type: array
+      items:
+        type: string
+`
+
+func (QuotaSuite) TestMaxKeys(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(quotaSchema))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.Properties["labels"].MaxKeys, gc.Equals, 3)
+	c.Check(s.Validate(map[string]interface{}{
+		"labels": map[string]interface{}{"a": "1", "b": "2", "c": "3"},
+	}), jc.ErrorIsNil)
+	c.Check(s.Validate(map[string]interface{}{
+		"labels": map[string]interface{}{"a": "1", "b": "2", "c": "3", "d": "4"},
+	}), gc.ErrorMatches, `labels: more than 3 keys`)
+}
+
+func (QuotaSuite) TestMaxKeysNested(c *gc.C) {
+	s := &Schema{Type: []Type{ObjectType}, MaxKeys: 4, AdditionalProperties: &Schema{
+		Type:                 []Type{ObjectType, ArrayType},
+		AdditionalProperties: &Schema{Type: []Type{IntegerType}},
+		Items:                &ItemSpec{Schemas: []*Schema{{Type: []Type{ObjectType}, AdditionalProperties: &Schema{Type: []Type{IntegerType}}}}},
+	}}
+	c.Check(s.Validate(map[string]interface{}{
+		"a": map[string]interface{}{"x": 1},
+		"b": []interface{}{map[string]interface{}{"y": 1}},
+	}), jc.ErrorIsNil)
+	c.Check(s.Validate(map[string]interface{}{
+		"a": map[string]interface{}{"x": 1, "y": 2},
+		"b": []interface{}{map[string]interface{}{"z": 1}},
+	}), gc.ErrorMatches, `more than 4 keys`)
+}
+
+func (QuotaSuite) TestMaxTotalSize(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(quotaSchema))
+	c.Assert(err, jc.ErrorIsNil)
+	// {"keys":["a<b","c"]} is 20 bytes.
+	c.Check(s.Validate(map[string]interface{}{
+		"blob": map[string]interface{}{"keys": []interface{}{"a<b", "c"}},
+	}), jc.ErrorIsNil)
+	err = s.Validate(map[string]interface{}{
+		"blob": map[string]interface{}{"keys": []interface{}{strings.Repeat("x", 30)}},
+	})
+	c.Check(err, gc.ErrorMatches, `blob: size of 43 bytes exceeds the limit of 32`)
+	c.Check(err.(*ValidationError).Keyword, gc.Equals, "max-total-size")
+
+	data, err := s.MarshalJSON()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(data), jc.Contains, `"max-total-size":32`)
+}
+
+func (QuotaSuite) TestQuotaCheckedFirst(c *gc.C) {
+	s := &Schema{
+		Type:                 []Type{ObjectType},
+		MaxKeys:              2,
+		AdditionalProperties: &Schema{Type: []Type{StringType}},
+	}
+	// The document is too big, so neither its values nor its types are
+	// looked at.
+	doc := map[string]interface{}{"a": 1, "b": math.NaN(), "c": "x"}
+	err := s.Validate(doc)
+	c.Check(err, gc.ErrorMatches, `more than 2 keys`)
+	c.Check(err.(*ValidationError).Keyword, gc.Equals, "max-keys")
+
+	v, err := NewValidator(s)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(v.Validate(doc), gc.ErrorMatches, `more than 2 keys`)
+}
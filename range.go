@@ -0,0 +1,39 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import "fmt"
+
+// RangeOf returns the schema for a range object, such as {min: 1, max: 3},
+// whose min and max properties are both described by bound. Either may be
+// omitted, leaving that end of the range open, but if both are given min must
+// not be greater than max.
+func RangeOf(bound *Schema) *Schema {
+	return &Schema{
+		Type: []Type{ObjectType},
+		Properties: map[string]*Schema{
+			"min": bound,
+			"max": bound,
+		},
+		Order: []string{"min", "max"},
+		Range: true,
+	}
+}
+
+// checkRange checks that the min property of the range object obj is not
+// greater than its max property.
+func checkRange(obj map[string]interface{}) error {
+	min, ok := normalizeValue(obj["min"]).(float64)
+	if !ok {
+		return nil
+	}
+	max, ok := normalizeValue(obj["max"]).(float64)
+	if !ok {
+		return nil
+	}
+	if min > max {
+		return fmt.Errorf("min %v is greater than max %v", obj["min"], obj["max"])
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type RangeSuite struct{}
+
+var _ = gc.Suite(RangeSuite{})
+
+func (RangeSuite) TestRangeOf(c *gc.C) {
+	units := RangeOf(&Schema{Type: []Type{IntegerType}, Minimum: Float(0)})
+	s := &Schema{
+		Type:       []Type{ObjectType},
+		Properties: map[string]*Schema{"units": units},
+	}
+	for _, test := range []struct {
+		units map[string]interface{}
+		err   string
+	}{{
+		units: map[string]interface{}{"min": 1, "max": 3},
+	}, {
+		units: map[string]interface{}{"min": 3, "max": 3},
+	}, {
+		units: map[string]interface{}{"min": 2},
+	}, {
+		units: map[string]interface{}{},
+	}, {
+		units: map[string]interface{}{"min": 4, "max": 3},
+		err:   "units: min 4 is greater than max 3",
+	}, {
+		units: map[string]interface{}{"min": -1},
+		err:   ".*",
+	}} {
+		c.Logf("test %v", test.units)
+		err := s.Validate(map[string]interface{}{"units": test.units})
+		if test.err == "" {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, gc.ErrorMatches, test.err)
+		}
+	}
+}
+
+func (RangeSuite) TestRangeKeyword(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(`
+type: object
+range: true
+properties:
+  min: {type: number}
+  max: {type: number}
+`))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.Range, jc.IsTrue)
+	c.Check(s.Validate(map[string]interface{}{"min": 0.5, "max": 0.25}), gc.ErrorMatches, "min 0.5 is greater than max 0.25")
+}
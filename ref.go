@@ -0,0 +1,466 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/lestrrat/go-jsschema"
+	"github.com/lestrrat/go-jsval"
+	"github.com/lestrrat/go-jsval/builder"
+)
+
+// resolveLocalRef returns the schema within root that ref refers to, or nil
+// if ref isn't a fragment which refers to a schema within root: either a
+// json pointer, such as "#/definitions/address", "#/$defs/address" or
+// "#/properties/home", or the name of an anchor, such as "#address".
+func resolveLocalRef(root *Schema, ref string) *Schema {
+	if !strings.HasPrefix(ref, "#") {
+		return nil
+	}
+	fragment, err := url.PathUnescape(ref[1:])
+	if err != nil {
+		return nil
+	}
+	if fragment == "" {
+		return root
+	}
+	if !strings.HasPrefix(fragment, "/") {
+		return findAnchor(root, fragment)
+	}
+	s := root
+	tokens := strings.Split(fragment[1:], "/")
+	for i := 0; s != nil && i < len(tokens); i++ {
+		next := func() (string, bool) {
+			if i+1 >= len(tokens) {
+				return "", false
+			}
+			i++
+			return unescapePointer(tokens[i]), true
+		}
+		s = pointerChild(s, unescapePointer(tokens[i]), next)
+	}
+	return s
+}
+
+// findAnchor returns the schema within root named name by the $anchor or
+// $dynamicAnchor keywords, or by an id holding only a fragment, as in earlier
+// drafts.
+func findAnchor(root *Schema, name string) *Schema {
+	return findInResource(root, func(s *Schema) bool {
+		return s.Anchor == name || s.DynamicAnchor == name || s.id() == "#"+name
+	})
+}
+
+// findInResource returns the first schema within root for which match
+// returns true. Schemas within root that have their own id are separate
+// resources, with their own anchors, so they aren't searched.
+func findInResource(root *Schema, match func(s *Schema) bool) *Schema {
+	seen := make(map[*Schema]bool)
+	var find func(s *Schema) *Schema
+	find = func(s *Schema) *Schema {
+		if s == nil || seen[s] {
+			return nil
+		}
+		seen[s] = true
+		if s != root && s.id() != "" && !isAnchorID(s.id()) {
+			return nil
+		}
+		if match(s) {
+			return s
+		}
+		for _, sub := range subschemas(s) {
+			if found := find(sub); found != nil {
+				return found
+			}
+		}
+		return nil
+	}
+	return find(root)
+}
+
+// isAnchorID reports whether id holds only a fragment, naming its schema
+// rather than establishing a new base uri.
+func isAnchorID(id string) bool {
+	return strings.HasPrefix(id, "#")
+}
+
+// pointerChild returns the schema within s named by the json pointer token
+// keyword, calling next to obtain the following token for keywords which
+// hold several schemas.
+func pointerChild(s *Schema, keyword string, next func() (string, bool)) *Schema {
+	named := func(m map[string]*Schema) *Schema {
+		if name, ok := next(); ok {
+			return m[name]
+		}
+		return nil
+	}
+	indexed := func(l []*Schema) *Schema {
+		token, ok := next()
+		if !ok {
+			return nil
+		}
+		i, err := strconv.Atoi(token)
+		if err != nil || i < 0 || i >= len(l) {
+			return nil
+		}
+		return l[i]
+	}
+	switch keyword {
+	case "definitions":
+		return named(s.Definitions)
+	case "$defs":
+		return named(s.Defs)
+	case "properties":
+		return named(s.Properties)
+	case "patternProperties":
+		pattern, ok := next()
+		if !ok {
+			return nil
+		}
+		for re, ps := range s.PatternProperties {
+			if re.String() == pattern {
+				return ps
+			}
+		}
+	case "additionalProperties":
+		return s.AdditionalProperties
+	case "dependencies":
+		return named(s.Dependencies.Schemas)
+	case "dependentSchemas":
+		return named(s.DependentSchemas)
+	case "items":
+		if s.Items == nil {
+			return nil
+		}
+		if s.Items.TupleMode {
+			return indexed(s.Items.Schemas)
+		}
+		if len(s.Items.Schemas) == 1 {
+			return s.Items.Schemas[0]
+		}
+	case "prefixItems":
+		return indexed(s.PrefixItems)
+	case "additionalItems":
+		return s.AdditionalItems
+	case "contains":
+		return s.Contains
+	case "allOf":
+		return indexed(s.AllOf)
+	case "anyOf":
+		return indexed(s.AnyOf)
+	case "oneOf":
+		return indexed(s.OneOf)
+	case "not":
+		return s.Not
+	case "propertyNames":
+		return s.PropertyNames
+	case "if":
+		return s.If
+	case "then":
+		return s.Then
+	case "else":
+		return s.Else
+	case "unevaluatedProperties":
+		return s.UnevaluatedProperties
+	case "unevaluatedItems":
+		return s.UnevaluatedItems
+	case "variants":
+		return named(s.Variants)
+	case "profiles":
+		return named(s.Profiles)
+	}
+	return nil
+}
+
+// compileInternal builds a jsschema validator for s, which is found within
+// root, resolving any references in s against root.
+//
+// jsschema only follows references to root itself and its definitions, so
+// any other reference, including those resolved by a Loader, is replaced by a
+// reference to a definition added to the internal form of root for the
+// purpose. Schemas with positional items are adjusted as described by
+// positionalInternal, those with exclusive bounds as described by
+// boundsInternal, those with a const as described by constInternal, those
+// with a multipleOf as described by multipleOfInternal, those without a
+// type as described by openUntyped, those with
+// unevaluatedProperties or unevaluatedItems as described by leftUnevaluated,
+// and those composed with allOf, anyOf, oneOf and not, or with a
+// conditional, as described by composeSchemas.
+func compileInternal(root, s *Schema) (*jsval.JSVal, error) {
+	if err := checkRefCycles(root); err != nil {
+		return nil, err
+	}
+	cache := make(map[*Schema]*schema.Schema)
+	internalRoot, err := toInternal(root, cache)
+	if err != nil {
+		return nil, err
+	}
+	internal, err := toInternal(s, cache)
+	if err != nil {
+		return nil, err
+	}
+	var refs []*Schema
+	collect := func(sub *Schema) {
+		if sub.ref() != "" && (sub.target != nil || !isDefinitionRef(sub.Reference)) {
+			refs = append(refs, sub)
+		}
+	}
+	walkWithTargets(root, collect)
+	walkWithTargets(s, collect)
+	names := make(map[*Schema]string)
+	for _, sub := range refs {
+		target := refTarget(root, sub)
+		if target == nil {
+			continue
+		}
+		name, ok := names[target]
+		if !ok {
+			name = fmt.Sprintf("$ref %d", len(names))
+			names[target] = name
+			internalTarget, err := toInternal(target, cache)
+			if err != nil {
+				return nil, err
+			}
+			internalRoot.Definitions[name] = internalTarget
+		}
+		internalSub, err := toInternal(sub, cache)
+		if err != nil {
+			return nil, err
+		}
+		internalSub.Reference = "#/definitions/" + escapePointer(name)
+	}
+	var positionalErr error
+	openAll := func(sub *Schema) {
+		if in, ok := cache[sub]; ok {
+			if err := positionalInternal(sub, in, cache); err != nil && positionalErr == nil {
+				positionalErr = err
+			}
+			boundsInternal(sub, in)
+			constInternal(sub, in)
+			multipleOfInternal(sub, in)
+			openUntyped(sub, in)
+			leftUnevaluated{}.add(sub).open(sub, in)
+		}
+	}
+	walkWithTargets(root, openAll)
+	walkWithTargets(s, openAll)
+	if positionalErr != nil {
+		return nil, positionalErr
+	}
+	var composed []*Schema
+	collectComposed := func(sub *Schema) {
+		if len(sub.AllOf) > 0 || len(sub.AnyOf) > 0 || len(sub.OneOf) > 0 || sub.Not != nil || sub.hasConditional() ||
+			len(sub.dependentSchemas()) > 0 {
+			composed = append(composed, sub)
+		}
+	}
+	walkWithTargets(root, collectComposed)
+	walkWithTargets(s, collectComposed)
+	for _, sub := range composed {
+		if err := composeSchemas(root, sub, cache); err != nil {
+			return nil, err
+		}
+	}
+	v, err := builder.New().BuildWithCtx(internal, internalRoot)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build validator: %w", err)
+	}
+	return v, nil
+}
+
+// isDefinitionRef reports whether ref refers to the root schema or directly
+// to one of its definitions, and so can be followed by jsschema.
+func isDefinitionRef(ref string) bool {
+	const prefix = "#/definitions/"
+	return ref == "#" || (strings.HasPrefix(ref, prefix) && !strings.Contains(ref[len(prefix):], "/"))
+}
+
+// escapePointer escapes s for use as an element of a json pointer.
+func escapePointer(s string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(s)
+}
+
+// refResolver resolves references relative to the base uris established by
+// the id keywords of the schemas holding them, loading any other documents
+// they refer to.
+type refResolver struct {
+	// loader is used to load other documents. If it is nil, references
+	// to other documents, and any that can't be resolved, are left
+	// unresolved rather than reported.
+	loader Loader
+
+	// docs holds the documents loaded so far, keyed by uri.
+	docs map[string]*Schema
+
+	// ids holds the schemas identified by id keywords, and the documents
+	// loaded so far, keyed by absolute uri without a fragment.
+	ids map[string]*Schema
+}
+
+func newRefResolver(loader Loader) *refResolver {
+	return &refResolver{
+		loader: loader,
+		docs:   make(map[string]*Schema),
+		ids:    make(map[string]*Schema),
+	}
+}
+
+// scopedRef holds a schema with a reference, and the base uri and
+// resource (the innermost schema with an id, or the document itself) that
+// the reference is resolved against.
+type scopedRef struct {
+	schema   *Schema
+	base     string
+	resource *Schema
+}
+
+// resolve resolves the references in doc, which has the given base uri.
+// If isRoot is set, local references within doc that aren't within a
+// schema with its own id are left to be resolved when they are followed.
+func (r *refResolver) resolve(doc *Schema, base string, isRoot bool) error {
+	if base != "" {
+		r.ids[base] = doc
+	}
+	var refs []scopedRef
+	seen := make(map[*Schema]bool)
+	var walk func(s *Schema, base string, resource *Schema)
+	walk = func(s *Schema, base string, resource *Schema) {
+		if s == nil || seen[s] {
+			return
+		}
+		seen[s] = true
+		if id := s.id(); id != "" && !isAnchorID(id) {
+			if u, err := resolveURI(base, id); err == nil {
+				base, resource = u, s
+				r.ids[stripFragment(base)] = s
+			}
+		}
+		if s.ref() != "" && s.target == nil {
+			refs = append(refs, scopedRef{schema: s, base: base, resource: resource})
+		}
+		for _, sub := range subschemas(s) {
+			walk(sub, base, resource)
+		}
+	}
+	walk(doc, base, doc)
+	for _, ref := range refs {
+		if isRoot && ref.resource == doc && strings.HasPrefix(ref.schema.ref(), "#") {
+			continue
+		}
+		target, err := r.lookup(ref)
+		if err != nil {
+			if r.loader == nil {
+				continue
+			}
+			return fmt.Errorf("cannot resolve $ref %q: %v", ref.schema.ref(), err)
+		}
+		ref.schema.target = target
+	}
+	return nil
+}
+
+// lookup returns the schema referred to by ref.
+func (r *refResolver) lookup(ref scopedRef) (*Schema, error) {
+	if strings.HasPrefix(ref.schema.ref(), "#") {
+		return pointerTarget(ref.resource, ref.schema.ref())
+	}
+	abs, err := resolveURI(ref.base, ref.schema.ref())
+	if err != nil {
+		return nil, err
+	}
+	uri, fragment := stripFragment(abs), "#"
+	if i := strings.Index(abs, "#"); i >= 0 {
+		fragment = abs[i:]
+	}
+	doc, ok := r.ids[uri]
+	if !ok {
+		if r.loader == nil {
+			return nil, errors.New("no loader")
+		}
+		if doc, err = r.load(uri); err != nil {
+			return nil, err
+		}
+	}
+	return pointerTarget(doc, fragment)
+}
+
+// load returns the document at uri, with its references resolved.
+func (r *refResolver) load(uri string) (*Schema, error) {
+	if doc, ok := r.docs[uri]; ok {
+		return doc, nil
+	}
+	doc, err := r.loader.Load(uri)
+	if err != nil {
+		return nil, err
+	}
+	if doc == nil {
+		return nil, fmt.Errorf("no schema found at %q", uri)
+	}
+	// The loader may share the schema with other callers, so resolve the
+	// references in a copy.
+	doc = cloneSchema(doc)
+	r.docs[uri] = doc
+	if err := r.resolve(doc, uri, false); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// pointerTarget returns the schema within doc that the fragment refers to.
+func pointerTarget(doc *Schema, fragment string) (*Schema, error) {
+	target := resolveLocalRef(doc, fragment)
+	if target == nil {
+		return nil, fmt.Errorf("no schema at %q", fragment)
+	}
+	return target, nil
+}
+
+// resolveURI returns ref resolved relative to base, if it is set.
+func resolveURI(base, ref string) (string, error) {
+	u, err := url.Parse(ref)
+	if err != nil {
+		return "", err
+	}
+	if base == "" {
+		return u.String(), nil
+	}
+	b, err := url.Parse(base)
+	if err != nil {
+		return "", err
+	}
+	return b.ResolveReference(u).String(), nil
+}
+
+// stripFragment returns uri without any fragment.
+func stripFragment(uri string) string {
+	if i := strings.Index(uri, "#"); i >= 0 {
+		return uri[:i]
+	}
+	return uri
+}
+
+// ref returns the reference held by s, given by its $ref, $dynamicRef or
+// $recursiveRef keyword, if any.
+func (s *Schema) ref() string {
+	switch {
+	case s.Reference != "":
+		return s.Reference
+	case s.DynamicRef != "":
+		return s.DynamicRef
+	}
+	return s.RecursiveRef
+}
+
+// id returns the id of s given by its $id or id keyword, if any.
+func (s *Schema) id() string {
+	if s.SchemaID != "" {
+		return s.SchemaID
+	}
+	return s.ID
+}
@@ -0,0 +1,290 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"fmt"
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type RefSuite struct{}
+
+var _ = gc.Suite(RefSuite{})
+
+const refSchema = `
+type: object
+definitions:
+  address:
+    type: object
+    properties:
+      host:
+        type: string
+        format: hostname
+      port:
+        type: integer
+        maximum: 65535
+        default: 80
+  tree:
+    type: object
+    properties:
+      name:
+        type: string
+      children:
+        type: array
+        items:
+          $ref: "#/definitions/tree"
+properties:
+  home:
+    $ref: "#/definitions/address"
+  work:
+    $ref: "#/properties/home"
+  backup:
+    $ref: "#/definitions/address/properties/port"
+  tree:
+    $ref: "#/definitions/tree"
+`
+
+func (RefSuite) TestValidateFollowsRefs(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(refSchema))
+	c.Assert(err, jc.ErrorIsNil)
+
+	for i, test := range []struct {
+		doc    map[string]interface{}
+		expect string
+	}{{
+		doc: map[string]interface{}{
+			"home":   map[string]interface{}{"host": "example.com", "port": 8080},
+			"work":   map[string]interface{}{"port": 443},
+			"backup": 22,
+			"tree": map[string]interface{}{
+				"name": "root",
+				"children": []interface{}{
+					map[string]interface{}{"name": "leaf"},
+				},
+			},
+		},
+	}, {
+		doc:    map[string]interface{}{"home": map[string]interface{}{"port": 70000}},
+		expect: `home.port: numeric value is greater than maximum`,
+	}, {
+		doc:    map[string]interface{}{"work": map[string]interface{}{"port": 70000}},
+		expect: `work.port: numeric value is greater than maximum`,
+	}, {
+		doc:    map[string]interface{}{"backup": "ssh"},
+		expect: `backup: .*`,
+	}, {
+		doc:    map[string]interface{}{"home": map[string]interface{}{"host": "not a host"}},
+		expect: `home.host: .*`,
+	}, {
+		doc: map[string]interface{}{
+			"tree": map[string]interface{}{
+				"children": []interface{}{
+					map[string]interface{}{"name": 1},
+				},
+			},
+		},
+		expect: `tree.children\[0\].name: .*`,
+	}} {
+		c.Logf("test %d", i)
+		err := s.Validate(test.doc)
+		if test.expect == "" {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, gc.ErrorMatches, test.expect)
+		}
+	}
+}
+
+func (RefSuite) TestValidatorFollowsRefs(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(refSchema))
+	c.Assert(err, jc.ErrorIsNil)
+	v, err := NewValidator(s)
+	c.Assert(err, jc.ErrorIsNil)
+	err = v.Validate(map[string]interface{}{"work": map[string]interface{}{"port": 70000}})
+	c.Assert(err, gc.ErrorMatches, `work.port: numeric value is greater than maximum`)
+}
+
+func (RefSuite) TestInsertDefaultsFollowsRefs(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(refSchema))
+	c.Assert(err, jc.ErrorIsNil)
+	doc := map[string]interface{}{
+		"work": map[string]interface{}{"host": "example.com"},
+	}
+	s.InsertDefaults(doc)
+	c.Assert(doc, jc.DeepEquals, map[string]interface{}{
+		"home":   map[string]interface{}{"port": float64(80)},
+		"backup": float64(80),
+		"work":   map[string]interface{}{"host": "example.com", "port": float64(80)},
+	})
+}
+
+func (RefSuite) TestResolveLocalRef(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(refSchema))
+	c.Assert(err, jc.ErrorIsNil)
+	address := s.Definitions["address"]
+	for ref, expect := range map[string]*Schema{
+		"#":                                     s,
+		"#/definitions/address":                 address,
+		"#/definitions/address/properties/port": address.Properties["port"],
+		"#/properties/home":                     s.Properties["home"],
+		"#/definitions/tree/properties/children/items": s.Definitions["tree"].Properties["children"].Items.Schemas[0],
+		"#/definitions/missing":                        nil,
+		"#/properties":                                 nil,
+		"other.json#/definitions/address":              nil,
+	} {
+		c.Check(resolveLocalRef(s, ref), gc.Equals, expect, gc.Commentf("%s", ref))
+	}
+}
+
+func (RefSuite) TestDefs(c *gc.C) {
+	s, err := FromJSON(strings.NewReader(`{
+		"type": "object",
+		"$defs": {"port": {"type": "integer", "maximum": 65535, "default": 80}},
+		"definitions": {"host": {"type": "string", "format": "hostname"}},
+		"properties": {
+			"host": {"$ref": "#/definitions/host"},
+			"port": {"$ref": "#/$defs/port"}
+		}
+	}`))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.Defs["port"], gc.NotNil)
+	c.Assert(s.Definitions["host"], gc.NotNil)
+	c.Check(s.Unknown, gc.HasLen, 0)
+
+	data, err := s.MarshalJSON()
+	c.Assert(err, jc.ErrorIsNil)
+	s2, err := FromJSON(strings.NewReader(string(data)))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s2.Defs["port"].Maximum, jc.DeepEquals, s.Defs["port"].Maximum)
+	c.Check(s2.Definitions["host"].Format, gc.Equals, FormatHostname)
+	c.Check(s2.Properties["port"].Reference, gc.Equals, "#/$defs/port")
+
+	c.Check(s.Validate(map[string]interface{}{"host": "example.com", "port": 8080}), jc.ErrorIsNil)
+	c.Check(s.Validate(map[string]interface{}{"port": 70000}), gc.ErrorMatches, `port: numeric value is greater than maximum`)
+	doc := map[string]interface{}{}
+	s.InsertDefaults(doc)
+	c.Check(doc, jc.DeepEquals, map[string]interface{}{"port": float64(80)})
+}
+
+const idSchema = `{
+	"$id": "https://example.com/schemas/service.json",
+	"type": "object",
+	"definitions": {
+		"address": {
+			"$id": "common/address.json",
+			"type": "object",
+			"properties": {
+				"port": {"$ref": "#/definitions/port"}
+			},
+			"definitions": {
+				"port": {"type": "integer", "maximum": 10}
+			}
+		},
+		"limits": {
+			"id": "https://example.com/limits/",
+			"definitions": {
+				"retries": {"$ref": "../schemas/common/address.json#/definitions/port"}
+			}
+		}
+	},
+	"properties": {
+		"home": {"$ref": "common/address.json"},
+		"port": {"$ref": "https://example.com/schemas/common/address.json#/definitions/port"},
+		"retries": {"$ref": "#/definitions/limits/definitions/retries"},
+		"remote": {"$ref": "https://elsewhere.example.com/remote.json"}
+	}
+}`
+
+func (RefSuite) TestIDs(c *gc.C) {
+	s, err := FromJSON(strings.NewReader(idSchema))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.SchemaID, gc.Equals, "https://example.com/schemas/service.json")
+	c.Check(s.Definitions["limits"].ID, gc.Equals, "https://example.com/limits/")
+	port := s.Definitions["address"].Definitions["port"]
+	c.Check(derefLocal(s, s.Properties["home"]), gc.Equals, s.Definitions["address"])
+	c.Check(derefLocal(s, s.Properties["port"]), gc.Equals, port)
+	c.Check(derefLocal(s, s.Properties["retries"]), gc.Equals, port)
+	c.Check(hasExternalRefs(s), jc.IsTrue)
+
+	for _, name := range []string{"port", "retries"} {
+		err := s.ValidateWith(ValidationOptions{Loader: remoteLoader}, map[string]interface{}{name: 20})
+		c.Check(err, gc.ErrorMatches, name+`: numeric value is greater than maximum`)
+	}
+	err = s.ValidateWith(ValidationOptions{Loader: remoteLoader}, map[string]interface{}{
+		"home": map[string]interface{}{"port": 20},
+	})
+	c.Check(err, gc.ErrorMatches, `home.port: numeric value is greater than maximum`)
+	err = s.ValidateWith(ValidationOptions{Loader: remoteLoader}, map[string]interface{}{"remote": 1})
+	c.Check(err, gc.ErrorMatches, `remote: .*`)
+
+	// A copy refers to its own schemas.
+	clone := cloneSchema(s)
+	c.Check(derefLocal(clone, clone.Properties["port"]), gc.Equals, clone.Definitions["address"].Definitions["port"])
+
+	data, err := s.MarshalJSON()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(data), jc.Contains, `"$id":"common/address.json"`)
+	c.Check(string(data), jc.Contains, `"id":"https://example.com/limits/"`)
+}
+
+// remoteLoader loads https://elsewhere.example.com/remote.json.
+var remoteLoader = LoaderFunc(func(uri string) (*Schema, error) {
+	if uri != "https://elsewhere.example.com/remote.json" {
+		return nil, fmt.Errorf("unexpected uri %q", uri)
+	}
+	return &Schema{Type: []Type{StringType}}, nil
+})
+
+const anchorSchema = `{
+	"$id": "https://example.com/service.json",
+	"type": "object",
+	"$defs": {
+		"address": {
+			"$anchor": "address",
+			"type": "object",
+			"properties": {"port": {"$ref": "#port"}}
+		},
+		"port": {"$anchor": "port", "type": "integer", "maximum": 10},
+		"legacy": {"id": "#legacy", "type": "string", "maxLength": 3},
+		"other": {
+			"$id": "other.json",
+			"$defs": {
+				"port": {"$anchor": "port", "type": "integer", "maximum": 20}
+			}
+		}
+	},
+	"properties": {
+		"home": {"$ref": "#address"},
+		"legacy": {"$ref": "#legacy"},
+		"other": {"$ref": "other.json#port"}
+	}
+}`
+
+func (RefSuite) TestAnchors(c *gc.C) {
+	s, err := FromJSON(strings.NewReader(anchorSchema))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.Defs["address"].Anchor, gc.Equals, "address")
+	c.Check(resolveLocalRef(s, "#address"), gc.Equals, s.Defs["address"])
+	c.Check(resolveLocalRef(s, "#port"), gc.Equals, s.Defs["port"])
+	c.Check(resolveLocalRef(s, "#missing"), gc.IsNil)
+	c.Check(resolveLocalRef(s.Defs["other"], "#port"), gc.Equals, s.Defs["other"].Defs["port"])
+
+	c.Check(s.Validate(map[string]interface{}{
+		"home":   map[string]interface{}{"port": 5},
+		"legacy": "abc",
+		"other":  15,
+	}), jc.ErrorIsNil)
+	c.Check(s.Validate(map[string]interface{}{"home": map[string]interface{}{"port": 15}}),
+		gc.ErrorMatches, `home.port: numeric value is greater than maximum`)
+	c.Check(s.Validate(map[string]interface{}{"legacy": "abcd"}), gc.ErrorMatches, `legacy: .*`)
+	c.Check(s.Validate(map[string]interface{}{"other": 25}),
+		gc.ErrorMatches, `other: numeric value is greater than maximum`)
+
+	data, err := s.MarshalJSON()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(data), jc.Contains, `"$anchor":"address"`)
+}
@@ -0,0 +1,301 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// DefaultMaxSchemaSize holds the size of the largest response accepted when
+// fetching a schema, unless another limit is set.
+const DefaultMaxSchemaSize = 10 << 20
+
+// RemoteClient fetches schemas by name and version from a controller, and
+// caches them on disk so that documents can still be validated when the
+// controller can't be reached.
+//
+// The schema named name at version is fetched from BaseURL/name/version,
+// which must respond with a json object holding the name, version,
+// fingerprint (as given by ExportCatalog) and schema:
+//
+//	{"name": "aws", "version": "3.1", "fingerprint": "9f86d0...", "schema": {...}}
+type RemoteClient struct {
+	// BaseURL holds the URL of the controller's schema endpoint.
+	BaseURL string
+
+	// HTTPClient holds the client used to make requests. If it is nil, a
+	// client using Proxy is used.
+	HTTPClient *http.Client
+
+	// Proxy returns the proxy to use for a request, as described by
+	// http.Transport.Proxy, when HTTPClient is nil. If it is nil, the proxy
+	// is taken from the environment.
+	Proxy func(*http.Request) (*url.URL, error)
+
+	// Timeout limits the time taken by each request, including reading
+	// the response. If it is zero, there is no limit beyond any imposed by
+	// HTTPClient.
+	Timeout time.Duration
+
+	// MaxSize holds the size of the largest response accepted, or zero to
+	// use DefaultMaxSchemaSize.
+	MaxSize int64
+
+	// CacheDir holds the directory fetched schemas are cached in. If it is
+	// empty, schemas are not cached.
+	CacheDir string
+
+	// TTL holds how long a cached schema is used before it is fetched
+	// again. If it is zero, cached schemas are used until they are
+	// removed from CacheDir.
+	TTL time.Duration
+
+	// now returns the current time, or is nil to use time.Now. It is
+	// replaced by tests.
+	now func() time.Time
+}
+
+// remoteSchema holds a schema as returned by the controller, and as cached
+// on disk.
+type remoteSchema struct {
+	Name        string          `json:"name"`
+	Version     string          `json:"version"`
+	Fingerprint string          `json:"fingerprint"`
+	Schema      json.RawMessage `json:"schema"`
+
+	// Fetched holds the time the schema was fetched, and ETag the entity
+	// tag it was served with. They are only set in the cache.
+	Fetched time.Time `json:"fetched,omitempty"`
+	ETag    string    `json:"etag,omitempty"`
+}
+
+// Fetch returns the schema with the given name and version. A cached copy
+// younger than c.TTL is returned without contacting the controller;
+// otherwise the schema is fetched and cached. An older cached copy is
+// revalidated with a conditional request, and is returned if the controller
+// reports that it hasn't changed or can't be reached.
+//
+// The schema's fingerprint is verified against the one returned with it,
+// and against fingerprint too if it isn't empty, so that a schema which has
+// been corrupted or changed without its version being bumped is rejected.
+func (c *RemoteClient) Fetch(ctx context.Context, name, version, fingerprint string) (*Schema, error) {
+	cached, cachedErr := c.readCache(name, version, fingerprint)
+	if cachedErr == nil && (c.TTL == 0 || c.timeNow().Sub(cached.fetched) < c.TTL) {
+		return cached.schema, nil
+	}
+	etag := ""
+	if cachedErr == nil {
+		etag = cached.rs.ETag
+	}
+	resp, err := c.get(ctx, name, version, etag)
+	if errors.Is(err, errNotModified) {
+		cached.rs.Fetched = c.timeNow()
+		if err := c.writeCache(cached.rs); err != nil {
+			return nil, fmt.Errorf("cannot cache schema %s@%s: %v", name, version, err)
+		}
+		return cached.schema, nil
+	}
+	if err != nil {
+		if cachedErr == nil {
+			return cached.schema, nil
+		}
+		return nil, fmt.Errorf("cannot fetch schema %s@%s: %v", name, version, err)
+	}
+	s, err := resp.verify(name, version, fingerprint)
+	if err != nil {
+		return nil, fmt.Errorf("cannot fetch schema %s@%s: %v", name, version, err)
+	}
+	resp.Fetched = c.timeNow()
+	if err := c.writeCache(resp); err != nil {
+		return nil, fmt.Errorf("cannot cache schema %s@%s: %v", name, version, err)
+	}
+	return s, nil
+}
+
+// Provider returns a Provider which provides the schema with the given name
+// and version, fetched by c. See Fetch.
+func (c *RemoteClient) Provider(name, version, fingerprint string) Provider {
+	return ProviderFunc(func(ctx context.Context) (*Schema, error) {
+		return c.Fetch(ctx, name, version, fingerprint)
+	})
+}
+
+// get fetches the named schema from the controller. If etag is set, the
+// schema is only returned if it no longer matches; otherwise errNotModified
+// is returned.
+func (c *RemoteClient) get(ctx context.Context, name, version, etag string) (*remoteSchema, error) {
+	u := strings.TrimSuffix(c.BaseURL, "/") + "/" + url.PathEscape(name) + "/" + url.PathEscape(version)
+	client := c.HTTPClient
+	if client == nil {
+		client = proxyClient(c.Proxy)
+	}
+	data, etag, err := getHTTP(ctx, client, u, etag, c.Timeout, c.MaxSize)
+	if err != nil {
+		return nil, err
+	}
+	var rs remoteSchema
+	if err := json.Unmarshal(data, &rs); err != nil {
+		return nil, fmt.Errorf("cannot parse response: %v", err)
+	}
+	rs.Fetched, rs.ETag = time.Time{}, etag
+	return &rs, nil
+}
+
+// errNotModified is returned by getHTTP when the document hasn't changed
+// since it was last fetched.
+var errNotModified = errors.New("not modified")
+
+// getHTTP fetches the document at u using client, returning its contents and
+// entity tag. If etag is set, errNotModified is returned if the document
+// still has that tag. The request is abandoned if it takes longer than
+// timeout, if set, or the document is larger than maxSize, or
+// DefaultMaxSchemaSize if that is zero.
+func getHTTP(ctx context.Context, client *http.Client, u, etag string, timeout time.Duration, maxSize int64) ([]byte, string, error) {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	if maxSize <= 0 {
+		maxSize = DefaultMaxSchemaSize
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, "", err
+	}
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, "", err
+	}
+	defer resp.Body.Close()
+	switch {
+	case resp.StatusCode == http.StatusNotModified && etag != "":
+		return nil, "", errNotModified
+	case resp.StatusCode != http.StatusOK:
+		return nil, "", fmt.Errorf("%s", resp.Status)
+	case resp.ContentLength > maxSize:
+		return nil, "", fmt.Errorf("response exceeds %d bytes", maxSize)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxSize+1))
+	if err != nil {
+		return nil, "", err
+	}
+	if int64(len(data)) > maxSize {
+		return nil, "", fmt.Errorf("response exceeds %d bytes", maxSize)
+	}
+	return data, resp.Header.Get("ETag"), nil
+}
+
+// proxyClient returns an http client which uses the given proxy function,
+// or the proxy given by the environment if it is nil.
+func proxyClient(proxy func(*http.Request) (*url.URL, error)) *http.Client {
+	if proxy == nil {
+		return http.DefaultClient
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = proxy
+	return &http.Client{Transport: transport}
+}
+
+// verify parses the schema held by rs, checking that it is the named
+// schema and that its fingerprint matches the one held by rs and the given
+// one, if set.
+func (rs *remoteSchema) verify(name, version, fingerprint string) (*Schema, error) {
+	if rs.Name != name || rs.Version != version {
+		return nil, fmt.Errorf("got schema %s@%s", rs.Name, rs.Version)
+	}
+	s, err := FromJSON(bytes.NewReader(rs.Schema))
+	if err != nil {
+		return nil, err
+	}
+	got := hashJSON(s)
+	if got != rs.Fingerprint || (fingerprint != "" && got != fingerprint) {
+		want := fingerprint
+		if want == "" {
+			want = rs.Fingerprint
+		}
+		return nil, fmt.Errorf("fingerprint %s does not match %s", got, want)
+	}
+	return s, nil
+}
+
+type cachedSchema struct {
+	rs      *remoteSchema
+	schema  *Schema
+	fetched time.Time
+}
+
+// readCache returns the cached copy of the named schema.
+func (c *RemoteClient) readCache(name, version, fingerprint string) (cachedSchema, error) {
+	if c.CacheDir == "" {
+		return cachedSchema{}, fmt.Errorf("no cache")
+	}
+	data, err := os.ReadFile(c.cachePath(name, version))
+	if err != nil {
+		return cachedSchema{}, err
+	}
+	var rs remoteSchema
+	if err := json.Unmarshal(data, &rs); err != nil {
+		return cachedSchema{}, err
+	}
+	s, err := rs.verify(name, version, fingerprint)
+	if err != nil {
+		return cachedSchema{}, err
+	}
+	return cachedSchema{rs: &rs, schema: s, fetched: rs.Fetched}, nil
+}
+
+// writeCache caches rs in c.CacheDir, if set. The file is replaced
+// atomically, so that concurrent readers never see a partial schema.
+func (c *RemoteClient) writeCache(rs *remoteSchema) error {
+	if c.CacheDir == "" {
+		return nil
+	}
+	data, err := json.Marshal(rs)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(c.CacheDir, 0700); err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(c.CacheDir, ".schema-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), c.cachePath(rs.Name, rs.Version))
+}
+
+// cachePath returns the path of the file caching the named schema.
+func (c *RemoteClient) cachePath(name, version string) string {
+	return filepath.Join(c.CacheDir, url.PathEscape(name)+"@"+url.PathEscape(version)+".json")
+}
+
+func (c *RemoteClient) timeNow() time.Time {
+	if c.now == nil {
+		return time.Now()
+	}
+	return c.now()
+}
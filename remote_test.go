@@ -0,0 +1,210 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type RemoteSuite struct{}
+
+var _ = gc.Suite(RemoteSuite{})
+
+// schemaServer serves the given schemas, keyed by "name/version", in the
+// form expected by RemoteClient, tagged with their fingerprints. It counts
+// the requests it receives, and those answered with "304 Not Modified".
+type schemaServer struct {
+	schemas     map[string]*Schema
+	fingerprint string
+	delay       time.Duration
+	requests    int
+	notModified int
+}
+
+func (s *schemaServer) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	s.requests++
+	time.Sleep(s.delay)
+	name, version := filepath.Split(req.URL.Path)
+	name = filepath.Base(name)
+	schema, ok := s.schemas[name+"/"+version]
+	if !ok {
+		http.NotFound(w, req)
+		return
+	}
+	data, _ := json.Marshal(schema)
+	fingerprint := s.fingerprint
+	if fingerprint == "" {
+		fingerprint = hashJSON(schema)
+	}
+	etag := `"` + hashJSON(schema) + `"`
+	if req.Header.Get("If-None-Match") == etag {
+		s.notModified++
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.Header().Set("ETag", etag)
+	json.NewEncoder(w).Encode(remoteSchema{
+		Name:        name,
+		Version:     version,
+		Fingerprint: fingerprint,
+		Schema:      data,
+	})
+}
+
+func newRemoteTest(c *gc.C) (*schemaServer, *httptest.Server, *RemoteClient, *time.Time) {
+	ss := &schemaServer{schemas: map[string]*Schema{
+		"aws/3.1": {
+			Type:       []Type{ObjectType},
+			Properties: map[string]*Schema{"region": {Type: []Type{StringType}}},
+		},
+	}}
+	srv := httptest.NewServer(ss)
+	now := time.Date(2026, 10, 14, 9, 0, 0, 0, time.UTC)
+	client := &RemoteClient{
+		BaseURL:  srv.URL + "/schemas",
+		CacheDir: filepath.Join(c.MkDir(), "cache"),
+		TTL:      time.Hour,
+		now:      func() time.Time { return now },
+	}
+	return ss, srv, client, &now
+}
+
+func (RemoteSuite) TestFetchCaches(c *gc.C) {
+	ss, srv, client, now := newRemoteTest(c)
+	defer srv.Close()
+	ctx := context.Background()
+
+	s, err := client.Fetch(ctx, "aws", "3.1", "")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.Properties["region"].Type, jc.DeepEquals, []Type{StringType})
+	c.Check(ss.requests, gc.Equals, 1)
+
+	// Within the TTL, the cached copy is used.
+	*now = now.Add(30 * time.Minute)
+	_, err = client.Fetch(ctx, "aws", "3.1", hashJSON(s))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(ss.requests, gc.Equals, 1)
+
+	// After it, the schema is revalidated, and used for another TTL if it
+	// hasn't changed.
+	*now = now.Add(time.Hour)
+	_, err = client.Fetch(ctx, "aws", "3.1", "")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(ss.requests, gc.Equals, 2)
+	c.Check(ss.notModified, gc.Equals, 1)
+	*now = now.Add(30 * time.Minute)
+	_, err = client.Fetch(ctx, "aws", "3.1", "")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(ss.requests, gc.Equals, 2)
+
+	// A changed schema is fetched in full.
+	ss.schemas["aws/3.1"].Properties["zone"] = &Schema{Type: []Type{StringType}}
+	*now = now.Add(time.Hour)
+	s, err = client.Fetch(ctx, "aws", "3.1", "")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.Properties["zone"], gc.NotNil)
+	c.Check(ss.requests, gc.Equals, 3)
+	c.Check(ss.notModified, gc.Equals, 1)
+}
+
+func (RemoteSuite) TestFetchOffline(c *gc.C) {
+	_, srv, client, now := newRemoteTest(c)
+	ctx := context.Background()
+	_, err := client.Fetch(ctx, "aws", "3.1", "")
+	c.Assert(err, jc.ErrorIsNil)
+	srv.Close()
+
+	// A stale copy is used when the controller can't be reached.
+	*now = now.Add(2 * time.Hour)
+	s, err := client.Fetch(ctx, "aws", "3.1", "")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.Properties["region"], gc.NotNil)
+
+	_, err = client.Fetch(ctx, "aws", "3.2", "")
+	c.Check(err, gc.ErrorMatches, `cannot fetch schema aws@3.2: .*connection refused`)
+}
+
+func (RemoteSuite) TestFetchVerifiesFingerprint(c *gc.C) {
+	ss, srv, client, _ := newRemoteTest(c)
+	defer srv.Close()
+	ctx := context.Background()
+
+	_, err := client.Fetch(ctx, "aws", "3.1", "0123")
+	c.Check(err, gc.ErrorMatches, `cannot fetch schema aws@3.1: fingerprint [0-9a-f]+ does not match 0123`)
+
+	ss.fingerprint = "4567"
+	_, err = client.Fetch(ctx, "aws", "3.1", "")
+	c.Check(err, gc.ErrorMatches, `cannot fetch schema aws@3.1: fingerprint [0-9a-f]+ does not match 4567`)
+
+	_, err = os.Stat(client.CacheDir)
+	c.Check(os.IsNotExist(err), jc.IsTrue)
+}
+
+func (RemoteSuite) TestFetchRejectsCorruptCache(c *gc.C) {
+	ss, srv, client, _ := newRemoteTest(c)
+	defer srv.Close()
+	ctx := context.Background()
+	_, err := client.Fetch(ctx, "aws", "3.1", "")
+	c.Assert(err, jc.ErrorIsNil)
+
+	path := filepath.Join(client.CacheDir, "aws@3.1.json")
+	data, err := os.ReadFile(path)
+	c.Assert(err, jc.ErrorIsNil)
+	var rs remoteSchema
+	c.Assert(json.Unmarshal(data, &rs), jc.ErrorIsNil)
+	rs.Schema = json.RawMessage(`{"type": "string"}`)
+	data, err = json.Marshal(rs)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(os.WriteFile(path, data, 0600), jc.ErrorIsNil)
+
+	s, err := client.Fetch(ctx, "aws", "3.1", "")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.Type, jc.DeepEquals, []Type{ObjectType})
+	c.Check(ss.requests, gc.Equals, 2)
+}
+
+func (RemoteSuite) TestFetchNotFound(c *gc.C) {
+	_, srv, client, _ := newRemoteTest(c)
+	defer srv.Close()
+	_, err := client.Provider("gce", "1.0", "").Schema(context.Background())
+	c.Check(err, gc.ErrorMatches, `cannot fetch schema gce@1.0: 404 Not Found`)
+}
+
+func (RemoteSuite) TestFetchLimits(c *gc.C) {
+	ss, srv, client, _ := newRemoteTest(c)
+	defer srv.Close()
+	ctx := context.Background()
+
+	client.MaxSize = 10
+	_, err := client.Fetch(ctx, "aws", "3.1", "")
+	c.Check(err, gc.ErrorMatches, `cannot fetch schema aws@3.1: response exceeds 10 bytes`)
+
+	client.MaxSize = 0
+	client.Timeout = 10 * time.Millisecond
+	ss.delay = 200 * time.Millisecond
+	_, err = client.Fetch(ctx, "aws", "3.1", "")
+	c.Check(err, gc.ErrorMatches, `cannot fetch schema aws@3.1: .*context deadline exceeded.*`)
+}
+
+func (RemoteSuite) TestFetchProxy(c *gc.C) {
+	ss, srv, client, _ := newRemoteTest(c)
+	defer srv.Close()
+	proxy, err := url.Parse(srv.URL)
+	c.Assert(err, jc.ErrorIsNil)
+	client.BaseURL = "http://controller.invalid/schemas"
+	client.Proxy = http.ProxyURL(proxy)
+	_, err = client.Fetch(context.Background(), "aws", "3.1", "")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(ss.requests, gc.Equals, 1)
+}
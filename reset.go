@@ -0,0 +1,160 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// ResetToDefault resets the values at the given dotted paths in doc, such as
+// "network.mtu" or "nodes[0].port", as for juju config --reset. A value whose
+// property has a default, including one chosen by default-when, is replaced
+// by it. Otherwise the value is removed, unless the property is required, in
+// which case an error is returned. Paths which aren't set in doc are reset
+// too, so that defaults are inserted for them.
+func (s *Schema) ResetToDefault(doc map[string]interface{}, paths ...string) (err error) {
+	defer recoverPanic(&err)
+	for _, path := range paths {
+		if err := s.resetToDefault(doc, path); err != nil {
+			return fmt.Errorf("cannot reset %q: %v", path, err)
+		}
+	}
+	return nil
+}
+
+func (s *Schema) resetToDefault(doc map[string]interface{}, path string) error {
+	p, err := s.locateProperty(doc, path, "reset")
+	if err != nil {
+		return err
+	}
+	if v := p.defaultValue(); v != nil {
+		p.obj[p.name] = copyValue(v)
+		return nil
+	}
+	if p.required() {
+		return fmt.Errorf("property is required and has no default")
+	}
+	delete(p.obj, p.name)
+	return nil
+}
+
+// Unset removes the value at the given dotted path in doc, as for a single
+// key passed to juju config --reset, and returns the default that applies
+// to the property in its place, if any. Unlike ResetToDefault, the default
+// isn't inserted into doc. An error is returned if the property is
+// immutable, or is part of an immutable value, or if it is required and has
+// no default. Unsetting a path which isn't set in doc is not an error.
+func (s *Schema) Unset(doc map[string]interface{}, path string) (_ interface{}, err error) {
+	defer recoverPanic(&err)
+	p, err := s.locateProperty(doc, path, "unset")
+	if err != nil {
+		return nil, fmt.Errorf("cannot unset %q: %v", path, err)
+	}
+	if p.immutable {
+		return nil, fmt.Errorf("cannot unset %q: property is immutable", path)
+	}
+	v := p.defaultValue()
+	if v == nil && p.required() {
+		return nil, fmt.Errorf("cannot unset %q: property is required and has no default", path)
+	}
+	delete(p.obj, p.name)
+	return copyValue(v), nil
+}
+
+// propertyLocation identifies the property of an object within a document.
+type propertyLocation struct {
+	// obj holds the object, and name the name of the property.
+	obj  map[string]interface{}
+	name string
+
+	// schema holds the schema of the property, and effective that of
+	// the object, with any variants applied.
+	schema    *Schema
+	effective *Schema
+
+	// immutable records whether the property, or any value that it is
+	// part of, is immutable.
+	immutable bool
+}
+
+// defaultValue returns the default of the property, given the values of
+// the other properties of its object.
+func (p *propertyLocation) defaultValue() interface{} {
+	rest := make(map[string]interface{}, len(p.obj))
+	for k, v := range p.obj {
+		if k != p.name {
+			rest[k] = v
+		}
+	}
+	return p.schema.defaultFor(rest)
+}
+
+// required reports whether the property is required.
+func (p *propertyLocation) required() bool {
+	for _, r := range p.effective.Required {
+		if r == p.name {
+			return true
+		}
+	}
+	return false
+}
+
+// locateProperty returns the location of the property at the given dotted
+// path in doc, which is described by s. The objects on the way to it must
+// be set, but the property itself need not be. Action names the operation
+// for which it is located, in errors.
+func (s *Schema) locateProperty(doc map[string]interface{}, path, action string) (*propertyLocation, error) {
+	elems, ok := parseFlatKey(path)
+	if !ok {
+		return nil, fmt.Errorf("invalid path")
+	}
+	if last := elems[len(elems)-1]; last[2] == "" {
+		return nil, fmt.Errorf("cannot %s an array item", action)
+	}
+	current := s
+	immutable := false
+	var container interface{} = doc
+	for i, m := range elems {
+		current = derefLocal(s, current)
+		immutable = immutable || current.Immutable
+		if m[1] != "" {
+			arr, ok := container.([]interface{})
+			index, _ := strconv.Atoi(m[1])
+			if !ok || index >= len(arr) {
+				return nil, fmt.Errorf("no value at %s", flatPrefix(elems[:i+1]))
+			}
+			current = itemSchema(current, index)
+			container = arr[index]
+			continue
+		}
+		obj, ok := container.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("no value at %s", flatPrefix(elems[:i]))
+		}
+		name := m[2]
+		effective := current.WithVariants(obj)
+		schemas := propertySchemas(effective, name)
+		if len(schemas) == 0 {
+			return nil, fmt.Errorf("unknown property")
+		}
+		if i < len(elems)-1 {
+			v, ok := obj[name]
+			if !ok {
+				return nil, fmt.Errorf("no value at %s", flatPrefix(elems[:i+1]))
+			}
+			current, container = schemas[0], v
+			continue
+		}
+		ps := derefLocal(s, schemas[0])
+		return &propertyLocation{
+			obj:       obj,
+			name:      name,
+			schema:    ps,
+			effective: effective,
+			immutable: immutable || ps.Immutable,
+		}, nil
+	}
+	return nil, fmt.Errorf("invalid path")
+}
@@ -0,0 +1,210 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type ResetSuite struct{}
+
+var _ = gc.Suite(ResetSuite{})
+
+const resetSchema = `
+type: object
+required: [name]
+properties:
+  name:
+    type: string
+  series:
+    type: string
+    default: focal
+  cgroups:
+    type: string
+    default: cgroup1
+    default-when:
+    - when: {series: jammy}
+      value: cgroup2
+  comment:
+    type: string
+  network:
+    type: object
+    properties:
+      mtu:
+        type: integer
+        default: 1500
+  nodes:
+    type: array
+    items:
+      type: object
+      properties:
+        port:
+          type: integer
+          default: 22
+`
+
+func (ResetSuite) TestResetToDefault(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(resetSchema))
+	c.Assert(err, jc.ErrorIsNil)
+	doc := map[string]interface{}{
+		"name":    "db",
+		"series":  "jammy",
+		"cgroups": "none",
+		"comment": "hello",
+		"network": map[string]interface{}{"mtu": 9000},
+		"nodes": []interface{}{
+			map[string]interface{}{"port": 2222},
+		},
+	}
+	err = s.ResetToDefault(doc, "cgroups", "comment", "network.mtu", "nodes[0].port")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(doc, jc.DeepEquals, map[string]interface{}{
+		"name":    "db",
+		"series":  "jammy",
+		"cgroups": "cgroup2",
+		"network": map[string]interface{}{"mtu": float64(1500)},
+		"nodes": []interface{}{
+			map[string]interface{}{"port": float64(22)},
+		},
+	})
+
+	// Resetting series changes its default, but not cgroups, which was
+	// reset before it.
+	err = s.ResetToDefault(doc, "series")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(doc["series"], gc.Equals, "focal")
+	c.Check(doc["cgroups"], gc.Equals, "cgroup2")
+}
+
+func (ResetSuite) TestResetToDefaultErrors(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(resetSchema))
+	c.Assert(err, jc.ErrorIsNil)
+	for _, test := range []struct {
+		path   string
+		expect string
+	}{{
+		path:   "name",
+		expect: `cannot reset "name": property is required and has no default`,
+	}, {
+		path:   "colour",
+		expect: `cannot reset "colour": unknown property`,
+	}, {
+		path:   "network.speed",
+		expect: `cannot reset "network.speed": no value at network`,
+	}, {
+		path:   "nodes[3].port",
+		expect: `cannot reset "nodes\[3\].port": no value at nodes\[3\]`,
+	}, {
+		path:   "nodes[0]",
+		expect: `cannot reset "nodes\[0\]": cannot reset an array item`,
+	}, {
+		path:   "network..mtu",
+		expect: `cannot reset "network..mtu": invalid path`,
+	}} {
+		c.Logf("path %q", test.path)
+		doc := map[string]interface{}{
+			"name":  "db",
+			"nodes": []interface{}{map[string]interface{}{}},
+		}
+		err := s.ResetToDefault(doc, test.path)
+		c.Check(err, gc.ErrorMatches, test.expect)
+		c.Check(doc["name"], gc.Equals, "db")
+	}
+}
+
+const unsetSchema = `
+type: object
+required: [name, series]
+properties:
+  name:
+    type: string
+  series:
+    type: string
+    default: focal
+  cgroups:
+    type: string
+    default: cgroup1
+    default-when:
+    - when: {series: jammy}
+      value: cgroup2
+  comment:
+    type: string
+  uuid:
+    type: string
+    immutable: true
+  storage:
+    type: object
+    immutable: true
+    properties:
+      pool:
+        type: string
+`
+
+func (ResetSuite) TestUnset(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(unsetSchema))
+	c.Assert(err, jc.ErrorIsNil)
+	doc := map[string]interface{}{
+		"name":    "db",
+		"series":  "jammy",
+		"cgroups": "none",
+		"comment": "hello",
+	}
+	def, err := s.Unset(doc, "cgroups")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(def, gc.Equals, "cgroup2")
+
+	def, err = s.Unset(doc, "comment")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(def, gc.IsNil)
+
+	// A required property may be unset if it has a default.
+	def, err = s.Unset(doc, "series")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(def, gc.Equals, "focal")
+
+	// Defaults aren't inserted.
+	c.Check(doc, jc.DeepEquals, map[string]interface{}{"name": "db"})
+
+	// Unsetting a property that isn't set is not an error.
+	def, err = s.Unset(doc, "cgroups")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(def, gc.Equals, "cgroup1")
+}
+
+func (ResetSuite) TestUnsetErrors(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(unsetSchema))
+	c.Assert(err, jc.ErrorIsNil)
+	for _, test := range []struct {
+		path   string
+		expect string
+	}{{
+		path:   "name",
+		expect: `cannot unset "name": property is required and has no default`,
+	}, {
+		path:   "uuid",
+		expect: `cannot unset "uuid": property is immutable`,
+	}, {
+		path:   "storage.pool",
+		expect: `cannot unset "storage.pool": property is immutable`,
+	}, {
+		path:   "colour",
+		expect: `cannot unset "colour": unknown property`,
+	}, {
+		path:   "name[0]",
+		expect: `cannot unset "name\[0\]": cannot unset an array item`,
+	}} {
+		c.Logf("path %q", test.path)
+		doc := map[string]interface{}{
+			"name":    "db",
+			"uuid":    "deadbeef",
+			"storage": map[string]interface{}{"pool": "ebs"},
+		}
+		_, err := s.Unset(doc, test.path)
+		c.Check(err, gc.ErrorMatches, test.expect)
+		c.Check(doc, gc.HasLen, 3)
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// The functions in this package which take documents supplied by users must
+// never panic, whatever the document holds: nil maps, channels, functions,
+// NaN and so on. Values that can't be checked are reported as invalid.
+// As a backstop, the functions that return an error recover from any panic
+// and return it as an error instead; the fuzz tests check that no panic
+// reaches them.
+
+// recoverPanic is deferred by functions which take documents from users, so
+// that a panic caused by an unexpected value is returned as an error rather
+// than crashing the caller.
+func recoverPanic(err *error) {
+	if r := recover(); r != nil {
+		*err = fmt.Errorf("internal error: %v", r)
+	}
+}
+
+// fillNilMaps returns x with any nil maps within it replaced by empty ones.
+// The jsschema validator inserts defaults into the objects it validates,
+// which fails for nil maps.
+func fillNilMaps(x interface{}) interface{} {
+	if isNilMap(x) {
+		return reflect.MakeMap(reflect.TypeOf(x)).Interface()
+	}
+	switch x := x.(type) {
+	case map[string]interface{}:
+		for k, v := range x {
+			x[k] = fillNilMaps(v)
+		}
+	case []interface{}:
+		for i, v := range x {
+			x[i] = fillNilMaps(v)
+		}
+	}
+	return x
+}
+
+func isNilMap(x interface{}) bool {
+	rv := reflect.ValueOf(x)
+	return rv.Kind() == reflect.Map && rv.IsNil()
+}
+
+// checkRefCycles returns an error if s holds a reference which refers,
+// directly or through other references, to itself, which would otherwise
+// cause validation to recurse forever.
+func checkRefCycles(s *Schema) error {
+	var err error
+	walkWithTargets(s, func(sub *Schema) {
+		seen := make(map[*Schema]bool)
+		for cur := sub; err == nil && cur != nil && cur.ref() != ""; {
+			seen[cur] = true
+			next := refTarget(s, cur)
+			if seen[next] {
+				err = fmt.Errorf("circular reference %q", cur.ref())
+			}
+			cur = next
+		}
+	})
+	return err
+}
@@ -0,0 +1,150 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"strings"
+	"testing"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type SafetySuite struct{}
+
+var _ = gc.Suite(SafetySuite{})
+
+func fuzzSchema() *Schema {
+	s, err := FromYAML(strings.NewReader(prepareSchema))
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+func (SafetySuite) TestUnusualValues(c *gc.C) {
+	s := fuzzSchema()
+	var nilMap map[string]interface{}
+	for i, x := range []interface{}{
+		nil,
+		nilMap,
+		make(chan int),
+		func() {},
+		math.NaN(),
+		math.Inf(-1),
+		(*int)(nil),
+		struct{}{},
+		map[int]interface{}{1: 2},
+		[]interface{}{nil},
+		map[string]interface{}{"name": make(chan int)},
+		map[string]interface{}{"name": (*string)(nil)},
+		map[string]interface{}{"disk": math.NaN()},
+		map[string]interface{}{"network": nil},
+		map[string]interface{}{"network": nilMap},
+		map[string]interface{}{"network": []interface{}{nil}},
+		map[string]interface{}{"network": map[string]string(nil)},
+	} {
+		c.Logf("test %d: %#v", i, x)
+		s.Validate(x)
+		if m, ok := x.(map[string]interface{}); ok {
+			s.InsertDefaults(m)
+			s.Coerce(m)
+			FlattenDoc(s, m)
+			CompareDocs(s, m, nil)
+		}
+	}
+}
+
+func (SafetySuite) TestValidateNilMap(c *gc.C) {
+	s := fuzzSchema()
+	var nilMap map[string]interface{}
+	c.Check(s.Validate(nilMap), jc.ErrorIsNil)
+	c.Check(s.Validate(map[string]interface{}{"network": nilMap}), jc.ErrorIsNil)
+}
+
+func (SafetySuite) TestRecoverPanic(c *gc.C) {
+	err := func() (err error) {
+		defer recoverPanic(&err)
+		panic("oops")
+	}()
+	c.Check(err, gc.ErrorMatches, "internal error: oops")
+}
+
+var fuzzDocs = []string{
+	`{}`,
+	`null`,
+	`{"name": "db", "disk": "2G", "network": {"mtu": 9000}}`,
+	`{"series": "jammy", "cgroups": null}`,
+	`{"network": [1, {"mtu": "x"}]}`,
+	`{"disk": 1e309}`,
+	`[{"name": {}}]`,
+}
+
+func FuzzValidate(f *testing.F) {
+	for _, doc := range fuzzDocs {
+		f.Add([]byte(doc))
+	}
+	s := fuzzSchema()
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var x interface{}
+		if err := json.Unmarshal(data, &x); err != nil {
+			return
+		}
+		s.Validate(x)
+		if m, ok := x.(map[string]interface{}); ok {
+			FlattenDoc(s, m)
+			CompareDocs(s, m, nil)
+			s.ResetToDefault(m, "network.mtu")
+			s.InsertDefaults(m)
+			s.Coerce(m)
+			s.Prepare(m, PrepareOptions{LookupEnv: func(string) (string, bool) { return "", false }})
+		}
+	})
+}
+
+func FuzzExpandFlat(f *testing.F) {
+	f.Add("network.mtu", "1500")
+	f.Add("disk", "2G")
+	f.Add("nodes[0].port", "22")
+	f.Add("a..b[", "")
+	s := fuzzSchema()
+	f.Fuzz(func(t *testing.T, key, value string) {
+		s.ExpandFlat(map[string]string{key: value})
+	})
+}
+
+func FuzzFromJSON(f *testing.F) {
+	f.Add([]byte(prepareSchemaJSON))
+	f.Add([]byte(`{"type": "string", "pattern": "["}`))
+	f.Add([]byte(`{"$ref": "#/definitions/x"}`))
+	f.Fuzz(func(t *testing.T, data []byte) {
+		s, err := FromJSON(bytes.NewReader(data))
+		if err != nil {
+			return
+		}
+		s.Validate(map[string]interface{}{})
+	})
+}
+
+var prepareSchemaJSON = func() string {
+	data, err := json.Marshal(fuzzSchema())
+	if err != nil {
+		panic(err)
+	}
+	return string(data)
+}()
+
+func (SafetySuite) TestCircularReference(c *gc.C) {
+	s, err := FromJSON(strings.NewReader(`{
+		"definitions": {"a": {"$ref": "#/definitions/b"}, "b": {"$ref": "#/definitions/a"}},
+		"$ref": "#/definitions/a"
+	}`))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.Validate(map[string]interface{}{}), gc.ErrorMatches, `circular reference "#/definitions/.*"`)
+	_, err = NewValidator(s)
+	c.Check(err, gc.ErrorMatches, `circular reference "#/definitions/.*"`)
+}
@@ -0,0 +1,138 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"unicode/utf8"
+)
+
+// Limits on the size of the values rendered by SanitizeForLog.
+const (
+	// logMaxString holds the length in bytes of the longest string
+	// rendered in full.
+	logMaxString = 256
+
+	// logMaxItems holds the length of the longest array rendered in full.
+	logMaxItems = 20
+)
+
+// SanitizeForLog returns doc, which is described by s, rendered as json that
+// is safe to log. Values of secret properties are redacted, as by FlattenDoc;
+// strings longer than 256 bytes and arrays of more than 20 items are
+// truncated, noting how much was left out; and object keys are ordered as
+// described by s (see Schema.Order), and sets sorted (see Schema.SetOf), so
+// that renderings of the same document can be compared.
+func SanitizeForLog(s *Schema, doc interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := writeLogValue(&buf, sanitizeValue(s, s, normalizeValue(doc))); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// writeLogValue writes x, as returned by sanitizeValue, to buf as json.
+// Unlike json.Marshal, characters such as '<' are not escaped, so that the
+// result reads naturally in logs.
+func writeLogValue(buf *bytes.Buffer, x interface{}) error {
+	switch x := x.(type) {
+	case orderedMap:
+		buf.WriteByte('{')
+		for i, k := range x.keys {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := writeLogValue(buf, k); err != nil {
+				return err
+			}
+			buf.WriteByte(':')
+			if err := writeLogValue(buf, x.values[k]); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+		return nil
+	case []interface{}:
+		buf.WriteByte('[')
+		for i, v := range x {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := writeLogValue(buf, v); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+		return nil
+	}
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(x); err != nil {
+		return err
+	}
+	// Encode terminates the value with a newline.
+	buf.Truncate(buf.Len() - 1)
+	return nil
+}
+
+func sanitizeValue(root, s *Schema, x interface{}) interface{} {
+	if s != nil {
+		s = derefLocal(root, s)
+		if s.Secret {
+			return redacted
+		}
+	}
+	switch x := x.(type) {
+	case map[string]interface{}:
+		if s != nil {
+			s = s.WithVariants(x)
+		}
+		out := orderedMap{values: make(map[string]interface{}, len(x))}
+		if s != nil {
+			out.keys = objectKeysInOrder(s, x)
+		} else {
+			out.keys = sortedObjectKeys(x)
+		}
+		for _, name := range out.keys {
+			var ps *Schema
+			if s != nil {
+				if schemas := propertySchemas(s, name); len(schemas) > 0 {
+					ps = schemas[0]
+				}
+			}
+			out.values[name] = sanitizeValue(root, ps, x[name])
+		}
+		return out
+	case []interface{}:
+		x = canonicalItems(s, x)
+		n := len(x)
+		if n > logMaxItems {
+			n = logMaxItems
+		}
+		out := make([]interface{}, n, n+1)
+		for i := range out {
+			var item *Schema
+			if s != nil {
+				item = itemSchema(s, i)
+			}
+			out[i] = sanitizeValue(root, item, x[i])
+		}
+		if len(x) > n {
+			out = append(out, fmt.Sprintf("...(%d more items)", len(x)-n))
+		}
+		return out
+	case string:
+		if len(x) <= logMaxString {
+			return x
+		}
+		n := logMaxString
+		for n > 0 && !utf8.RuneStart(x[n]) {
+			n--
+		}
+		return fmt.Sprintf("%s...(%d more bytes)", x[:n], len(x)-n)
+	}
+	return x
+}
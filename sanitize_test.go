@@ -0,0 +1,71 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"math"
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type SanitizeSuite struct{}
+
+var _ = gc.Suite(SanitizeSuite{})
+
+const sanitizeSchema = `
+type: object
+order: [name, credentials, nodes]
+definitions:
+  credentials:
+    type: object
+    properties:
+      user:
+        type: string
+      password:
+        type: string
+        secret: true
+properties:
+  name:
+    type: string
+  credentials:
+    $ref: "#/definitions/credentials"
+  token:
+    type: string
+    secret: true
+  nodes:
+    type: array
+    items:
+      type: integer
+`
+
+func (SanitizeSuite) TestSanitizeForLog(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(sanitizeSchema))
+	c.Assert(err, jc.ErrorIsNil)
+	nodes := make([]interface{}, 25)
+	for i := range nodes {
+		nodes[i] = i
+	}
+	out, err := SanitizeForLog(s, map[string]interface{}{
+		"zone":        "eu-west-1",
+		"token":       "hunter2",
+		"nodes":       nodes,
+		"credentials": map[string]interface{}{"user": "admin", "password": "hunter2"},
+		"name":        strings.Repeat("é", 200),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(out), gc.Equals, `{`+
+		`"name":"`+strings.Repeat("é", 128)+`...(144 more bytes)",`+
+		`"credentials":{"password":"<redacted>","user":"admin"},`+
+		`"nodes":[0,1,2,3,4,5,6,7,8,9,10,11,12,13,14,15,16,17,18,19,"...(5 more items)"],`+
+		`"token":"<redacted>",`+
+		`"zone":"eu-west-1"`+
+		`}`)
+}
+
+func (SanitizeSuite) TestSanitizeForLogUnencodable(c *gc.C) {
+	_, err := SanitizeForLog(&Schema{}, map[string]interface{}{"x": math.NaN()})
+	c.Check(err, gc.ErrorMatches, `json: unsupported value: NaN`)
+}
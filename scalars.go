@@ -0,0 +1,104 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// normalizeScalarForms returns the json schema data with the keywords that
+// are written elsewhere in more than one form rewritten in the form that
+// jsschema and this package expect, so that such schemas load without
+// preprocessing:
+//
+//   - a type given as an array of one type is given as that type, so that
+//     type aliases apply to it;
+//   - a single example is given as an array of examples;
+//   - a single required property is given as an array of them;
+//   - a property with a required of true, as in draft 3, is added to the
+//     required properties of the schema holding it;
+//   - any of type, items, examples or required given as null is removed;
+//   - a numeric exclusiveMinimum or exclusiveMaximum, as in draft 6 and
+//     later, is held under another key (see normalizeExclusiveBounds).
+func normalizeScalarForms(data []byte) ([]byte, error) {
+	if !mayHoldScalarForms(data) {
+		return data, nil
+	}
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, err
+	}
+	v, changed := rewriteRawSchema(v, normalizeScalarSchema)
+	if !changed {
+		return data, nil
+	}
+	return json.Marshal(v)
+}
+
+// normalizeScalarSchema rewrites the keywords of the raw schema m as
+// described by normalizeScalarForms, reporting whether it changed any.
+func normalizeScalarSchema(m map[string]interface{}) (map[string]interface{}, bool) {
+	changed := false
+	for _, k := range []string{"type", "items", "examples", "required"} {
+		if v, ok := m[k]; ok && v == nil {
+			delete(m, k)
+			changed = true
+		}
+	}
+	if types, ok := m["type"].([]interface{}); ok && len(types) == 1 {
+		if _, ok := types[0].(string); ok {
+			m["type"] = types[0]
+			changed = true
+		}
+	}
+	if examples, ok := m["examples"]; ok {
+		if _, ok := examples.([]interface{}); !ok {
+			m["examples"] = []interface{}{examples}
+			changed = true
+		}
+	}
+	if name, ok := m["required"].(string); ok {
+		m["required"] = []interface{}{name}
+		changed = true
+	}
+	if _, ok := m["required"].(bool); ok {
+		// Draft 3 gives required in the schema of each property, which
+		// is seen when the schema holding it is normalized.
+		delete(m, "required")
+		changed = true
+	}
+	if normalizeExclusiveBounds(m) {
+		changed = true
+	}
+	properties, _ := m["properties"].(map[string]interface{})
+	for _, name := range sortedObjectKeys(properties) {
+		ps, _ := properties[name].(map[string]interface{})
+		required, ok := ps["required"].(bool)
+		if !ok {
+			continue
+		}
+		delete(ps, "required")
+		changed = true
+		if !required {
+			continue
+		}
+		names, _ := m["required"].([]interface{})
+		m["required"] = append(names, name)
+	}
+	return m, changed
+}
+
+// mayHoldScalarForms reports whether the json schema data may hold any of
+// the forms rewritten by normalizeScalarForms. It doesn't rely on package
+// variables, as schemas are decoded into some of them while the package is
+// initialized.
+func mayHoldScalarForms(data []byte) bool {
+	for _, marker := range []string{`"examples"`, `"required"`, `null`, `"type":[`, `"type": [`, `"exclusiveM`} {
+		if bytes.Contains(data, []byte(marker)) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,80 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type ScalarsSuite struct{}
+
+var _ = gc.Suite(ScalarsSuite{})
+
+func (ScalarsSuite) TestScalarForms(c *gc.C) {
+	for i, test := range []struct {
+		scalar string
+		array  string
+	}{{
+		scalar: `{"type": "string"}`,
+		array:  `{"type": ["string"]}`,
+	}, {
+		scalar: `{"type": "array", "items": {"type": "string"}}`,
+		array:  `{"type": "array", "items": {"type": "string"}, "examples": null}`,
+	}, {
+		scalar: `{"type": "string", "examples": "us-east-1"}`,
+		array:  `{"type": "string", "examples": ["us-east-1"]}`,
+	}, {
+		scalar: `{"type": "array", "examples": {"a": 1}}`,
+		array:  `{"type": "array", "examples": [{"a": 1}]}`,
+	}, {
+		scalar: `{"type": "object", "properties": {"name": {"type": "string"}}, "required": "name"}`,
+		array:  `{"type": "object", "properties": {"name": {"type": "string"}}, "required": ["name"]}`,
+	}, {
+		scalar: `{"type": "object", "properties": {"name": {"type": "string"}}, "required": null}`,
+		array:  `{"type": "object", "properties": {"name": {"type": "string"}}}`,
+	}, {
+		scalar: `{"type": "object", "properties": {"a": {"type": "string", "required": true}, "b": {"type": "string", "required": true}, "c": {"type": "string", "required": false}}}`,
+		array:  `{"type": "object", "properties": {"a": {"type": "string"}, "b": {"type": "string"}, "c": {"type": "string"}}, "required": ["a", "b"]}`,
+	}, {
+		scalar: `{"type": "object", "properties": {"a": {"type": "string", "required": true}}, "required": ["b"]}`,
+		array:  `{"type": "object", "properties": {"a": {"type": "string"}}, "required": ["b", "a"]}`,
+	}} {
+		c.Logf("test %d: %s", i, test.scalar)
+		s, err := FromJSON(strings.NewReader(test.scalar))
+		c.Assert(err, jc.ErrorIsNil)
+		want, err := FromJSON(strings.NewReader(test.array))
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(s, jc.DeepEquals, want)
+	}
+}
+
+func (ScalarsSuite) TestScalarFormsYAML(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(`
+type: object
+properties:
+  region:
+    type: [string]
+    examples: us-east-1
+    required: true
+  zones:
+    type: array
+    items: {type: string}
+`))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.Required, jc.DeepEquals, []string{"region"})
+	c.Check(s.Properties["region"].Type, jc.DeepEquals, []Type{StringType})
+	c.Check(s.Properties["region"].Examples, jc.DeepEquals, []interface{}{"us-east-1"})
+	c.Check(s.Validate(map[string]interface{}{}), gc.ErrorMatches, `.*region.*`)
+}
+
+func (ScalarsSuite) TestScalarFormsTypeAlias(c *gc.C) {
+	s, err := FromJSON(strings.NewReader(`{"type": ["test-storage-size"]}`))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.Type, jc.DeepEquals, []Type{StringType})
+	c.Check(s.Validate("8G"), jc.ErrorIsNil)
+	c.Check(s.Validate("8"), gc.NotNil)
+}
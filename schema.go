@@ -5,7 +5,9 @@
 package jsonschema
 
 import (
+	"bytes"
 	"encoding/json"
+	"fmt"
 	"io"
 	"io/ioutil"
 	"regexp"
@@ -15,7 +17,6 @@ import (
 
 	// Schema *is* the actual package name, this just makes it clearer.
 	schema "github.com/lestrrat/go-jsschema"
-	"github.com/lestrrat/go-jsschema/validator"
 )
 
 // FromJSON returns a schema created from the json value in r.
@@ -27,8 +28,19 @@ func FromJSON(r io.Reader) (*Schema, error) {
 	return s, nil
 }
 
-// FromYAML returns a schema created from the yaml value in r.
+// FromYAML returns a schema created from the yaml value in r. Duplicate keys
+// are always rejected; see FromYAMLWithOptions for other options.
 func FromYAML(r io.Reader) (*Schema, error) {
+	val, err := readYAML(r)
+	if err != nil {
+		return nil, err
+	}
+	return FromGo(val)
+}
+
+// readYAML returns the yaml value in r, with its maps converted to
+// map[string]interface{}.
+func readYAML(r io.Reader) (interface{}, error) {
 	b, err := ioutil.ReadAll(r)
 	if err != nil {
 		return nil, err
@@ -40,11 +52,7 @@ func FromYAML(r io.Reader) (*Schema, error) {
 
 	// yaml serialization outputs map[interface{}]interface{} instead of
 	// map[string]interface{} for some reason, so we have to fix that.
-	val, err := utils.ConformYAML(v)
-	if err != nil {
-		return nil, err
-	}
-	return FromGo(val)
+	return utils.ConformYAML(v)
 }
 
 // FromGo extracts the jsonschema represented by v.
@@ -77,11 +85,21 @@ type Schema struct {
 	Format      Format             `json:"format,omitempty"`
 
 	// NumericValidations
-	MultipleOf       *float64 `json:"multipleOf,omitempty"`
-	Minimum          *float64 `json:"minimum,omitempty"`
-	Maximum          *float64 `json:"maximum,omitempty"`
-	ExclusiveMinimum *bool    `json:"exclusiveMinimum,omitempty"`
-	ExclusiveMaximum *bool    `json:"exclusiveMaximum,omitempty"`
+	MultipleOf *float64 `json:"multipleOf,omitempty"`
+	Minimum    *float64 `json:"minimum,omitempty"`
+	Maximum    *float64 `json:"maximum,omitempty"`
+
+	ExclusiveMinimum *bool `json:"exclusiveMinimum,omitempty"`
+	ExclusiveMaximum *bool `json:"exclusiveMaximum,omitempty"`
+
+	// ExclusiveMinimumValue and ExclusiveMaximumValue hold the numeric form
+	// of exclusiveMinimum and exclusiveMaximum used by draft 6 and later:
+	// bounds which, unlike Minimum and Maximum, the value may not equal.
+	// Only one form of each may be set. Schemas using the numeric form are
+	// marshaled with a min-reader-version of at least 2, as earlier readers
+	// only understand the boolean form.
+	ExclusiveMinimumValue *float64 `json:"-" keyword:"exclusiveMinimum"`
+	ExclusiveMaximumValue *float64 `json:"-" keyword:"exclusiveMaximum"`
 
 	// StringValidation
 	MaxLength *int           `json:"maxLength,omitempty"`
@@ -96,6 +114,12 @@ type Schema struct {
 	UniqueItems     *bool     `json:"uniqueItems,omitempty"`
 
 	// ObjectValidations
+	//
+	// AdditionalProperties holds the schema for properties of an object
+	// that aren't declared by Properties or PatternProperties. If it is
+	// nil, as when additionalProperties is false or not given, no such
+	// properties are allowed; the empty schema, which additionalProperties
+	// true is decoded as, allows any.
 	MaxProperties        *int                       `json:"maxProperties,omitempty"`
 	MinProperties        *int                       `json:"minProperties,omitempty"`
 	Required             []string                   `json:"required,omitempty"`
@@ -110,6 +134,124 @@ type Schema struct {
 	OneOf []*Schema     `json:"oneOf,omitempty"`
 	Not   *Schema       `json:"not,omitempty"`
 
+	// Keywords from later drafts of JSON Schema, which jsschema doesn't
+	// know. As with the juju-specific properties below, they are held in
+	// Extras, so if you add properties to this list, you *must* add
+	// conversion logic in toExtras.
+
+	// SchemaID holds the $id keyword used by later drafts of JSON Schema in
+	// place of id. Either establishes a base uri which references within
+	// the schema are resolved against, and identifies the schema to
+	// references elsewhere in the document.
+	SchemaID string `json:"$id,omitempty"`
+
+	// Anchor holds the $anchor keyword, which names the schema so that
+	// references may refer to it by a plain-name fragment, such as
+	// "#address", rather than by a json pointer.
+	Anchor string `json:"$anchor,omitempty"`
+
+	// DynamicRef holds the $dynamicRef keyword, a reference to a
+	// plain-name fragment, such as "#node", which is resolved in the same
+	// way as Reference unless the schema it refers to has a matching
+	// DynamicAnchor. In that case it refers instead to the schema with
+	// that DynamicAnchor in the schema being validated against, if there
+	// is one, so that recursive schemas can be extended by the schemas
+	// that refer to them.
+	DynamicRef string `json:"$dynamicRef,omitempty"`
+
+	// DynamicAnchor holds the $dynamicAnchor keyword, which names the
+	// schema in the same way as Anchor, and marks it as a target that
+	// DynamicRef may be redirected from and to.
+	DynamicAnchor string `json:"$dynamicAnchor,omitempty"`
+
+	// RecursiveRef holds the $recursiveRef keyword used by draft 2019-09
+	// in place of DynamicRef. It may only be "#", and refers to the root
+	// of the schema being validated against rather than of its own
+	// resource when both have RecursiveAnchor set.
+	RecursiveRef string `json:"$recursiveRef,omitempty"`
+
+	// RecursiveAnchor holds the $recursiveAnchor keyword used by draft
+	// 2019-09 in place of DynamicAnchor.
+	RecursiveAnchor bool `json:"$recursiveAnchor,omitempty"`
+
+	// Defs holds schemas for use by references, in the same way as
+	// Definitions, under the $defs keyword used by later drafts of JSON
+	// Schema. They may be referred to as "#/$defs/name".
+	Defs map[string]*Schema `json:"$defs,omitempty"`
+
+	// PropertyNames holds a schema that the name of each property of an
+	// object must satisfy, such as a pattern or length limit for the keys
+	// of a map of user-supplied tags.
+	PropertyNames *Schema `json:"propertyNames,omitempty"`
+
+	// DependentRequired maps the names of properties to the names of
+	// other properties that an object holding the property must also
+	// hold, in the same way as the names in Dependencies.
+	DependentRequired map[string][]string `json:"dependentRequired,omitempty"`
+
+	// DependentSchemas maps the names of properties to schemas that an
+	// object holding the property must also satisfy, in the same way as
+	// the schemas in Dependencies. The properties they declare are
+	// allowed in the object described by this schema.
+	DependentSchemas map[string]*Schema `json:"dependentSchemas,omitempty"`
+
+	// If holds a schema which decides whether the value must also satisfy
+	// Then or Else: Then if the value matches If, and Else otherwise.
+	// Either may be left unset. The properties they declare are allowed in
+	// the object described by this schema whichever applies.
+	If   *Schema `json:"if,omitempty"`
+	Then *Schema `json:"then,omitempty"`
+	Else *Schema `json:"else,omitempty"`
+
+	// PrefixItems holds the schemas for the items at the start of an
+	// array, by position, as in later drafts of JSON Schema. When it is
+	// set, Items holds a single schema for the items after them, or is nil
+	// to allow any; items of false allows none. Shorter arrays are allowed.
+	PrefixItems []*Schema `json:"prefixItems,omitempty"`
+
+	// UnevaluatedProperties holds the schema for the properties of an
+	// object that aren't evaluated by this schema or by the schemas it is
+	// composed of which apply to the object: the allOf schemas, the anyOf
+	// and oneOf alternatives it matches, the then or else schema chosen for
+	// it and the schemas depending on the properties it holds. Unlike
+	// AdditionalProperties, it can close an object described by several
+	// schemas against unknown properties. When it is set, the schemas that
+	// don't give additionalProperties allow any properties, leaving those
+	// they don't declare to UnevaluatedProperties. False is decoded as a
+	// schema which allows no value.
+	UnevaluatedProperties *Schema `json:"unevaluatedProperties,omitempty"`
+
+	// UnevaluatedItems holds the schema for the items of an array that
+	// aren't evaluated by this schema or by the schemas it is composed of
+	// which apply to the array, in the same way as UnevaluatedProperties.
+	// The items evaluated by a schema are those its items apply to, and any
+	// its additionalItems apply to. When it is set, the schemas that don't
+	// give additionalItems allow any items. False is decoded as a schema
+	// which allows no value.
+	UnevaluatedItems *Schema `json:"unevaluatedItems,omitempty"`
+
+	// Contains holds a schema which items of an array must match, such as
+	// one requiring a disk to be bootable. At least MinContains of the
+	// items must match it, or one if MinContains is nil, and no more than
+	// MaxContains if it is set. Items are matched in the same way as by If,
+	// and the items that match are evaluated as far as UnevaluatedItems is
+	// concerned.
+	Contains    *Schema `json:"contains,omitempty"`
+	MinContains *int    `json:"minContains,omitempty"`
+	MaxContains *int    `json:"maxContains,omitempty"`
+
+	// Const holds the only value allowed, such as "v1" for an api-version
+	// property. Objects and arrays are compared in depth, and numbers by
+	// value however they are represented. As with Default, a const of null
+	// can't be told from none.
+	Const interface{} `json:"const,omitempty"`
+
+	// Examples holds sample values for the attribute, which show users the
+	// format expected of it. Unlike Example, they are never used as values;
+	// they are shown as hints when prompting and in the documentation
+	// generated by ToMarkdown.
+	Examples []interface{} `json:"examples,omitempty"`
+
 	// Juju-specific properties.  If you add properties to this list, you0
 	// *must* add conversion logic in toExtras.
 
@@ -158,6 +300,143 @@ type Schema struct {
 	// contents of that filepath are used as the value of the given property.
 	// This is useful for properties with large values, such as encryption keys.
 	PathFor string `json:"path-for,omitempty"`
+
+	// XML describes how this property is mapped from an XML document by
+	// DecodeXML.
+	XML *XMLMapping `json:"xml,omitempty"`
+
+	// Titles holds translations of Title, keyed by language tag (for example
+	// "fr" or "pt-BR").
+	Titles map[string]string `json:"titles,omitempty"`
+
+	// Descriptions holds translations of Description, keyed by language tag.
+	Descriptions map[string]string `json:"descriptions,omitempty"`
+
+	// PatternDescription describes the strings matched by Pattern to users.
+	// It is given as the error for a string which doesn't match, such as
+	// "must look like an AWS access key (AKIA...)", in place of the pattern
+	// itself. See PatternError.
+	PatternDescription string `json:"pattern-description,omitempty"`
+
+	// PatternDescriptions holds translations of PatternDescription, keyed
+	// by language tag. See ValidationOptions.Language.
+	PatternDescriptions map[string]string `json:"pattern-descriptions,omitempty"`
+
+	// EnumLabels holds human-friendly names for the values in Enum, in the
+	// same order, for showing to users in place of the values themselves,
+	// such as "Amazon Web Services" for "aws". See EnumOptions.
+	EnumLabels []string `json:"enum-labels,omitempty"`
+
+	// EnumDescriptions holds longer explanations of the values in Enum, in
+	// the same order.
+	EnumDescriptions []string `json:"enum-descriptions,omitempty"`
+
+	// Group holds the name of the group this property belongs to when the
+	// properties of an object are presented to the user in several steps.
+	Group string `json:"group,omitempty"`
+
+	// GroupSpecs declares the groups used by the properties of this object,
+	// in the order they should be presented. See Groups.
+	GroupSpecs []GroupSpec `json:"groups,omitempty"`
+
+	// VisibleWhen holds the condition under which this property is relevant,
+	// in terms of the values of other properties of the same object. A
+	// property with no condition is always visible. See VisibleProperties.
+	VisibleWhen Condition `json:"visible-when,omitempty"`
+
+	// DefaultWhen holds defaults which depend on the values of other
+	// properties of the same object. When inserting defaults, the value of
+	// the first entry whose condition matches is used in preference to
+	// Default.
+	DefaultWhen []ConditionalDefault `json:"default-when,omitempty"`
+
+	// Unit holds the unit of a quantity, such as UnitMiB or UnitSeconds.
+	// Coerce converts quantities given with other units into it.
+	Unit Unit `json:"unit,omitempty"`
+
+	// Range indicates that this object describes a range, with numeric min
+	// and max properties, and that min must not be greater than max. See
+	// RangeOf.
+	Range bool `json:"range,omitempty"`
+
+	// Variants maps values of this property to schemas that further
+	// describe the object holding it. When the property has one of the
+	// values, the properties and required keywords of the corresponding
+	// schema are merged into the object's schema. See WithVariants.
+	Variants map[string]*Schema `json:"variants,omitempty"`
+
+	// Profiles maps the names of validation profiles, such as "bootstrap"
+	// or "update", to schemas whose properties and required keywords are
+	// merged into this schema when validating with that profile. See
+	// ValidationOptions.Profile.
+	Profiles map[string]*Schema `json:"profiles,omitempty"`
+
+	// Validators holds the names of custom validators, registered with
+	// RegisterValidator, which the value must also satisfy.
+	Validators []string `json:"validators,omitempty"`
+
+	// FeatureFlag holds the name of a feature flag which must be enabled for
+	// this property to be set. See ValidationOptions.FeatureFlags.
+	FeatureFlag string `json:"feature-flag,omitempty"`
+
+	// Provenance holds the id or title of the schema this property was
+	// taken from, as recorded by Concat. Validation errors for the property
+	// name it, so that failures can be attributed to their source.
+	Provenance string `json:"provenance,omitempty"`
+
+	// Access holds the role that a user must have to see or set this
+	// property, such as "admin". Properties without an access keyword are
+	// available to everyone. See FilterByAccess and ValidationOptions.Role.
+	Access string `json:"access,omitempty"`
+
+	// KeyOf holds the dotted path of an object in another document
+	// validated by the same call to ValidateBatch, starting with the name
+	// of the document, such as "bundle.machines". The value must be the
+	// name of one of the object's properties. See ValidateBatch.
+	KeyOf string `json:"key-of,omitempty"`
+
+	// ItemKey holds the name of a property identifying each item of this
+	// array of objects, such as "name" for a list of endpoints. Items must
+	// all have the property, with distinct simple values, and are matched
+	// by it rather than by position when documents are compared or
+	// merged. See CompareDocs and MergeDocs.
+	ItemKey string `json:"item-key,omitempty"`
+
+	// SetOf specifies that this array is a set: the order of its items is
+	// insignificant, and no two items may be equal, or have the same key if
+	// ItemKey is set. Sets are sorted when documents are flattened or
+	// rendered for logging, and compared regardless of order.
+	SetOf bool `json:"set-of,omitempty"`
+
+	// MaxTotalSize limits the size in bytes of the value, including
+	// everything nested within it, when serialized as compact json. Zero
+	// sets no limit.
+	MaxTotalSize int `json:"max-total-size,omitempty"`
+
+	// MaxKeys limits the number of object properties in the value,
+	// counting those of every object nested within it. Zero sets no limit.
+	//
+	// Both limits are checked on the root of a document before anything
+	// else, so that an oversized document is rejected cheaply.
+	MaxKeys int `json:"max-keys,omitempty"`
+
+	// MinReaderVersion holds the lowest ReaderVersion of this package that
+	// understands every keyword used by the schema. Older readers still load
+	// the schema, but report it in CompatibilityWarnings. The schema is
+	// always marshaled with at least the version required by the keywords
+	// it uses itself, so MinReaderVersion need only be set to require a
+	// higher one, and is left unset when decoding a schema that doesn't.
+	MinReaderVersion int `json:"min-reader-version,omitempty"`
+
+	// Unknown holds any keywords in the schema that are not understood by
+	// this package, so that they are preserved when the schema is marshaled
+	// again.
+	Unknown map[string]interface{} `json:"-"`
+
+	// target holds the schema referred to by Reference, when it has been
+	// resolved ahead of time, against the base uri given by an id or by a
+	// Loader. See DecodeOptions.Loader.
+	target *Schema `json:"-"`
 }
 
 // toExtras converts the juju-specific metadata fields on Schema into values to
@@ -165,6 +444,30 @@ type Schema struct {
 // in sync with the json keys listed in the Schema struct.
 func toExtras(s *Schema) map[string]interface{} {
 	extras := make(map[string]interface{})
+	for k, v := range s.Unknown {
+		extras[k] = v
+	}
+	if s.SchemaID != "" {
+		extras["$id"] = s.SchemaID
+	}
+	if s.Anchor != "" {
+		extras["$anchor"] = s.Anchor
+	}
+	if s.DynamicRef != "" {
+		extras["$dynamicRef"] = s.DynamicRef
+	}
+	if s.DynamicAnchor != "" {
+		extras["$dynamicAnchor"] = s.DynamicAnchor
+	}
+	if s.RecursiveRef != "" {
+		extras["$recursiveRef"] = s.RecursiveRef
+	}
+	if s.RecursiveAnchor {
+		extras["$recursiveAnchor"] = s.RecursiveAnchor
+	}
+	if len(s.Defs) > 0 {
+		extras["$defs"] = s.Defs
+	}
 	if s.Immutable {
 		extras["immutable"] = s.Immutable
 	}
@@ -193,20 +496,162 @@ func toExtras(s *Schema) map[string]interface{} {
 	if s.PathFor != "" {
 		extras["path-for"] = s.PathFor
 	}
+	if s.XML != nil {
+		extras["xml"] = s.XML
+	}
+	if len(s.Titles) > 0 {
+		extras["titles"] = s.Titles
+	}
+	if len(s.Descriptions) > 0 {
+		extras["descriptions"] = s.Descriptions
+	}
+	if s.PatternDescription != "" {
+		extras["pattern-description"] = s.PatternDescription
+	}
+	if len(s.PatternDescriptions) > 0 {
+		extras["pattern-descriptions"] = s.PatternDescriptions
+	}
+	if len(s.EnumLabels) > 0 {
+		extras["enum-labels"] = s.EnumLabels
+	}
+	if len(s.EnumDescriptions) > 0 {
+		extras["enum-descriptions"] = s.EnumDescriptions
+	}
+	if s.Group != "" {
+		extras["group"] = s.Group
+	}
+	if len(s.GroupSpecs) > 0 {
+		extras["groups"] = s.GroupSpecs
+	}
+	if len(s.VisibleWhen) > 0 {
+		extras["visible-when"] = s.VisibleWhen
+	}
+	if len(s.DefaultWhen) > 0 {
+		extras["default-when"] = s.DefaultWhen
+	}
+	if s.Unit != "" {
+		extras["unit"] = s.Unit
+	}
+	if s.Range {
+		extras["range"] = s.Range
+	}
+	if len(s.Variants) > 0 {
+		extras["variants"] = s.Variants
+	}
+	if len(s.Profiles) > 0 {
+		extras["profiles"] = s.Profiles
+	}
+	if len(s.Validators) > 0 {
+		extras["validators"] = s.Validators
+	}
+	if s.FeatureFlag != "" {
+		extras["feature-flag"] = s.FeatureFlag
+	}
+	if s.Provenance != "" {
+		extras["provenance"] = s.Provenance
+	}
+	if s.Access != "" {
+		extras["access"] = s.Access
+	}
+	if s.KeyOf != "" {
+		extras["key-of"] = s.KeyOf
+	}
+	if s.ItemKey != "" {
+		extras["item-key"] = s.ItemKey
+	}
+	if s.SetOf {
+		extras["set-of"] = s.SetOf
+	}
+	if s.PropertyNames != nil {
+		extras["propertyNames"] = s.PropertyNames
+	}
+	if len(s.DependentRequired) > 0 {
+		extras["dependentRequired"] = s.DependentRequired
+	}
+	if len(s.DependentSchemas) > 0 {
+		extras["dependentSchemas"] = s.DependentSchemas
+	}
+	if s.If != nil {
+		extras["if"] = s.If
+	}
+	if s.Then != nil {
+		extras["then"] = s.Then
+	}
+	if s.Else != nil {
+		extras["else"] = s.Else
+	}
+	if len(s.PrefixItems) > 0 {
+		extras["prefixItems"] = s.PrefixItems
+	}
+	if s.UnevaluatedProperties != nil {
+		extras["unevaluatedProperties"] = s.UnevaluatedProperties
+	}
+	if s.UnevaluatedItems != nil {
+		extras["unevaluatedItems"] = s.UnevaluatedItems
+	}
+	if s.Contains != nil {
+		extras["contains"] = s.Contains
+	}
+	if s.MinContains != nil {
+		extras["minContains"] = *s.MinContains
+	}
+	if s.MaxContains != nil {
+		extras["maxContains"] = *s.MaxContains
+	}
+	if s.Const != nil {
+		extras["const"] = s.Const
+	}
+	if s.ExclusiveMinimumValue != nil {
+		extras["exclusiveMinimum"] = *s.ExclusiveMinimumValue
+	}
+	if s.ExclusiveMaximumValue != nil {
+		extras["exclusiveMaximum"] = *s.ExclusiveMaximumValue
+	}
+	if len(s.Examples) > 0 {
+		extras["examples"] = s.Examples
+	}
+	if s.MaxTotalSize > 0 {
+		extras["max-total-size"] = s.MaxTotalSize
+	}
+	if s.MaxKeys > 0 {
+		extras["max-keys"] = s.MaxKeys
+	}
+	if v := s.readerVersion(); v > 1 || s.MinReaderVersion != 0 {
+		if s.MinReaderVersion > v {
+			v = s.MinReaderVersion
+		}
+		extras["min-reader-version"] = v
+	}
 	return extras
 }
 
-// MarshalJSON implements the json.Marshaler.
+// MarshalJSON implements the json.Marshaler. A single type is marshaled as
+// a string and a single items schema as an object, as they are usually
+// written, rather than as arrays.
 func (s *Schema) MarshalJSON() ([]byte, error) {
 	internal, err := toInternal(s, make(map[*Schema]*schema.Schema))
 	if err != nil {
 		return nil, err
 	}
-	return internal.MarshalJSON()
+	data, err := internal.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return trimAdditionalItems(data)
 }
 
 // UnmarshalJSON implements the json.Marshaler.
 func (s *Schema) UnmarshalJSON(data []byte) error {
+	data, err := normalizeScalarForms(data)
+	if err != nil {
+		return err
+	}
+	if data, err = expandTypeAliases(data); err != nil {
+		return err
+	}
+	if data, err = expandBooleanSchemas(data); err != nil {
+		return err
+	}
 	internal := schema.New()
 	if err := internal.UnmarshalJSON(data); err != nil {
 		return err
@@ -216,7 +661,104 @@ func (s *Schema) UnmarshalJSON(data []byte) error {
 		return err
 	}
 	*s = *ext
-	return nil
+	// References relative to ids within the schema can only be resolved
+	// once the whole schema has been decoded; any to other documents are
+	// left for a Loader.
+	return newRefResolver(nil).resolve(s, "", true)
+}
+
+// booleanSchemaKeywords holds the keywords that this package allows to be
+// given as true or false, in place of a schema.
+var booleanSchemaKeywords = []string{"contains", "items", "unevaluatedItems", "unevaluatedProperties"}
+
+// expandBooleanSchemas returns the json schema data with each
+// additionalProperties or additionalItems of true replaced by the empty
+// schema, which allows the same properties or items. jsschema decodes true
+// in the same way as an absent keyword, which this package takes to allow
+// none. Each of
+// booleanSchemaKeywords given as true or false is replaced in the same way,
+// by the empty schema or by one which allows nothing.
+func expandBooleanSchemas(data []byte) ([]byte, error) {
+	found := bytes.Contains(data, []byte(`"additionalProperties"`)) || bytes.Contains(data, []byte(`"additionalItems"`))
+	for _, k := range booleanSchemaKeywords {
+		found = found || bytes.Contains(data, []byte(`"`+k+`"`))
+	}
+	if !found {
+		return data, nil
+	}
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, err
+	}
+	v, changed := rewriteRawSchema(v, func(m map[string]interface{}) (map[string]interface{}, bool) {
+		changed := false
+		for _, k := range []string{"additionalProperties", "additionalItems"} {
+			if b, ok := m[k].(bool); ok && b {
+				m[k] = map[string]interface{}{}
+				changed = true
+			}
+		}
+		for _, k := range booleanSchemaKeywords {
+			if b, ok := m[k].(bool); ok {
+				m[k] = booleanSchema(b)
+				changed = true
+			}
+		}
+		return m, changed
+	})
+	if !changed {
+		return data, nil
+	}
+	return json.Marshal(v)
+}
+
+// trimAdditionalItems returns the json schema data without the
+// additionalItems of false that jsschema gives every schema which may
+// describe an array, except where it has positional items for it to apply
+// to. Elsewhere it means nothing, and an absent additionalItems is decoded
+// in the same way.
+func trimAdditionalItems(data []byte) ([]byte, error) {
+	if !bytes.Contains(data, []byte(`"additionalItems":false`)) {
+		return data, nil
+	}
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, err
+	}
+	v, changed := rewriteRawSchema(v, func(m map[string]interface{}) (map[string]interface{}, bool) {
+		if b, ok := m["additionalItems"].(bool); !ok || b {
+			return m, false
+		}
+		if _, ok := m["items"].([]interface{}); ok {
+			return m, false
+		}
+		delete(m, "additionalItems")
+		return m, true
+	})
+	if !changed {
+		return data, nil
+	}
+	return json.Marshal(v)
+}
+
+// booleanSchema returns the raw form of the schema which allows any value if
+// allow is set, and none otherwise.
+func booleanSchema(allow bool) map[string]interface{} {
+	if allow {
+		return map[string]interface{}{}
+	}
+	return map[string]interface{}{"not": map[string]interface{}{}}
+}
+
+// GobEncode implements gob.GobEncoder. The schema is encoded in its json form,
+// so that encoded schemas remain readable across changes to the Schema struct.
+func (s *Schema) GobEncode() ([]byte, error) {
+	return s.MarshalJSON()
+}
+
+// GobDecode implements gob.GobDecoder.
+func (s *Schema) GobDecode(data []byte) error {
+	return s.UnmarshalJSON(data)
 }
 
 // Validate validates the given value based on the jsonschema in s.  Values are
@@ -224,45 +766,174 @@ func (s *Schema) UnmarshalJSON(data []byte) error {
 // type, int for integer type, float64 or integer for number type, or an array
 // of one of the previous types.
 func (s *Schema) Validate(x interface{}) error {
-	internal, err := toInternal(s, make(map[*Schema]*schema.Schema))
+	return s.ValidateWith(ValidationOptions{}, x)
+}
+
+// ValidateWith validates x in the same way as Validate, making the values
+// in opts available to any custom validators used by the schema, applying the
+// profile it selects, returning any result in its cache, and recording any
+// failure in its audit sink.
+func (s *Schema) ValidateWith(opts ValidationOptions, x interface{}) error {
+	if err := checkQuotas(s, x); err != nil {
+		return opts.audit(s, x, err)
+	}
+	var fingerprint string
+	if opts.Cache != nil {
+		fingerprint = hashJSON(s)
+	}
+	return opts.audit(s, x, opts.cached(fingerprint, x, func() error {
+		return validateSchema(opts, s, nil, x)
+	}))
+}
+
+// validateSchema validates x against s as described by opts. The compiled
+// form of s is obtained from compiled, if given, when neither external
+// references, a profile nor variants apply to x, and is otherwise compiled
+// for this call only.
+func validateSchema(opts ValidationOptions, s *Schema, compiled func() (interface{ Validate(interface{}) error }, error), x interface{}) (err error) {
+	defer recoverPanic(&err)
+	x = fillNilMaps(x)
+	if !opts.AllowNonFinite {
+		if errs := nonFiniteErrors(x, ""); len(errs) > 0 {
+			return errs.err()
+		}
+	}
+	resolved, err := withExternalRefs(s, opts.Loader)
+	if err != nil {
+		return err
+	}
+	effective := resolved.WithProfile(opts.Profile).WithVariants(x)
+	var v interface{ Validate(interface{}) error }
+	if effective == s && compiled != nil {
+		v, err = compiled()
+	} else {
+		v, err = compileInternal(effective, effective)
+	}
+	if err != nil {
+		return err
+	}
+	e := newExplanation(effective)
+	e.compiled[effective] = compiledSchema{v: v}
+	if err := v.Validate(x); err != nil {
+		return localizeErrors(e.explain(effective, x, "", err), opts.Language).err()
+	}
+	val := &validation{opts: opts, root: effective, explanation: e}
+	val.validate(effective, x, "")
+	val.errs.Sort(effective)
+	return localizeErrors(val.errs, opts.Language).err()
+}
+
+// validateInternal validates x against the keywords in s that are
+// implemented by jsschema. Any references in s are resolved against root,
+// the schema that s is found within.
+func validateInternal(root, s *Schema, x interface{}) error {
+	v, err := compileInternal(root, s)
 	if err != nil {
 		return err
 	}
-	v := validator.New(internal)
 	return v.Validate(x)
 }
 
 // InsertDefaults takes a target map and inserts any missing default values
-// as specified in the properties map, according to JSON-Schema.
+// as specified in the properties map, according to JSON-Schema. Conditional
+// defaults are evaluated once the unconditional ones have been inserted, so
+// their conditions may refer to defaulted values. Local references, such as
+// "#/definitions/address", are followed to find the defaults they give.
+// Defaults are inserted into the values of existing map entries, but no
+// entries are added. The defaults given by allOf schemas are inserted too.
 func (s *Schema) InsertDefaults(into map[string]interface{}) {
+	insertDefaults(s, s, into, make(map[*Schema]bool))
+}
+
+// insertDefaults inserts the defaults given by s, found within root, into
+// the object into. Property schemas which refer to others are followed
+// within root; active holds the schemas whose defaults are being inserted,
+// so that recursive schemas don't create objects forever.
+func insertDefaults(root, s *Schema, into map[string]interface{}, active map[*Schema]bool) {
 	if into == nil {
 		return
 	}
+	s = derefLocal(root, s)
+	active[s] = true
+	defer delete(active, s)
 	for property, schema := range s.Properties {
+		schema = derefLocal(root, schema)
 		if v, ok := into[property]; ok {
 			// If there is already a value in the target map for this key, don't
 			// overwrite it.
 			// If it's a map, set defaults on it.
 			if innerMap, ok := v.(map[string]interface{}); ok {
-				schema.InsertDefaults(innerMap)
+				insertDefaults(root, schema, innerMap, active)
 			}
 			continue
 		}
 
+		if len(schema.DefaultWhen) > 0 {
+			// Conditional defaults are dealt with below.
+			continue
+		}
+
 		if schema.Default != nil {
 			// Most basic case: we have a default value. Done for this key.
 			into[property] = schema.Default
 			continue
 		}
 
-		if len(schema.Properties) > 0 {
+		if hasProperties(root, schema) && !active[schema] {
 			m := make(map[string]interface{})
-			schema.InsertDefaults(m)
+			insertDefaults(root, schema, m, active)
 			if len(m) > 0 {
 				into[property] = m
 			}
 		}
 	}
+
+	// The defaults given by the schemas s is composed of apply too, unless
+	// s gives its own.
+	for _, sub := range s.AllOf {
+		if sub = derefLocal(root, sub); !active[sub] {
+			insertDefaults(root, sub, into, active)
+		}
+	}
+
+	// The values of the entries of maps (see MapOf) are given their
+	// defaults too, but no entries are added.
+	for key, v := range into {
+		innerMap, ok := v.(map[string]interface{})
+		if _, isProperty := s.Properties[key]; isProperty || !ok {
+			continue
+		}
+		for _, schema := range propertySchemas(s, key) {
+			insertDefaults(root, schema, innerMap, active)
+		}
+	}
+
+	// Decide all the conditional defaults before inserting any of them, so
+	// that the result doesn't depend on the order they're visited in.
+	conditional := make(map[string]interface{})
+	for property, schema := range s.Properties {
+		schema = derefLocal(root, schema)
+		if _, ok := into[property]; ok || len(schema.DefaultWhen) == 0 {
+			continue
+		}
+		if v := schema.defaultFor(into); v != nil {
+			conditional[property] = v
+		}
+	}
+	for property, v := range conditional {
+		into[property] = v
+	}
+}
+
+// hasProperties reports whether s, found within root, or any of the allOf
+// schemas it is composed of, declares properties.
+func hasProperties(root, s *Schema) bool {
+	for _, cs := range composedSchemas(root, s, false) {
+		if len(cs.Properties) > 0 {
+			return true
+		}
+	}
+	return false
 }
 
 // Type defines the standard jsonschema value types.IntegerType
@@ -280,11 +951,38 @@ const (
 	NumberType
 )
 
+// String returns the jsonschema name of the type.
+func (t Type) String() string {
+	return schema.PrimitiveType(t).String()
+}
+
+// MarshalJSON implements json.Marshaler, giving the jsonschema name of the
+// type.
+func (t Type) MarshalJSON() ([]byte, error) {
+	return schema.PrimitiveType(t).MarshalJSON()
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (t *Type) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err != nil {
+		return err
+	}
+	for tt := NullType; tt <= NumberType; tt++ {
+		if tt.String() == name {
+			*t = tt
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown type %q", name)
+}
+
 // Format defines well-known jsonschema formats for strings.
 type Format string
 
 // Standard jsonschema formats.
 const (
+	FormatDate     Format = "date"
 	FormatDateTime Format = "date-time"
 	FormatEmail    Format = "email"
 	FormatHostname Format = "hostname"
@@ -293,6 +991,17 @@ const (
 	FormatURI      Format = "uri"
 )
 
+// Juju-specific formats.
+const (
+	// FormatConstraints is the format of juju machine constraints, such as
+	// "mem=8G cores=4".
+	FormatConstraints Format = "constraints"
+
+	// FormatSecretURI is the format of the URI of a juju secret, as held
+	// by charm config options of type secret.
+	FormatSecretURI Format = "secret-uri"
+)
+
 // DependencyMap contains the dependencies defined within this schema.
 // for a given dependency name, you can have either a schema or a
 // list of property names
@@ -307,6 +1016,39 @@ type ItemSpec struct {
 	Schemas   []*Schema
 }
 
+// MarshalJSON implements json.Marshaler. Positional items are marshaled as
+// an array of schemas, and otherwise the single schema for every item is
+// marshaled as an object.
+func (is ItemSpec) MarshalJSON() ([]byte, error) {
+	if !is.TupleMode && len(is.Schemas) == 1 {
+		return json.Marshal(is.Schemas[0])
+	}
+	schemas := is.Schemas
+	if schemas == nil {
+		schemas = []*Schema{}
+	}
+	return json.Marshal(schemas)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, accepting either form written
+// by MarshalJSON.
+func (is *ItemSpec) UnmarshalJSON(data []byte) error {
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '[' {
+		var schemas []*Schema
+		if err := json.Unmarshal(data, &schemas); err != nil {
+			return err
+		}
+		*is = ItemSpec{TupleMode: true, Schemas: schemas}
+		return nil
+	}
+	s := &Schema{}
+	if err := json.Unmarshal(data, s); err != nil {
+		return err
+	}
+	*is = ItemSpec{Schemas: []*Schema{s}}
+	return nil
+}
+
 // Float is a helper function for use in struct literals.
 func Float(f float64) *float64 {
 	return &f
@@ -322,6 +1064,37 @@ func Bool(b bool) *bool {
 	return &b
 }
 
+// itemSchema returns the schema for the i'th item of the array described by
+// s.
+func itemSchema(s *Schema, i int) *Schema {
+	if i < len(s.PrefixItems) {
+		return s.PrefixItems[i]
+	}
+	if s.Items == nil || (len(s.Items.Schemas) == 0 && !s.Items.TupleMode) {
+		return &Schema{}
+	}
+	if s.Items.TupleMode {
+		if i < len(s.Items.Schemas) {
+			return s.Items.Schemas[i]
+		}
+		if s.AdditionalItems != nil {
+			return s.AdditionalItems
+		}
+		return &Schema{}
+	}
+	return s.Items.Schemas[0]
+}
+
+// hasType reports whether t is one of the types allowed by s.
+func hasType(s *Schema, t Type) bool {
+	for _, typ := range s.Type {
+		if typ == t {
+			return true
+		}
+	}
+	return false
+}
+
 func fromInternalSchemaList(in schema.SchemaList, cache map[*schema.Schema]*Schema) ([]*Schema, error) {
 	if in == nil {
 		return nil, nil
@@ -499,20 +1272,35 @@ func fromInternal(in *schema.Schema, cache map[*schema.Schema]*Schema) (*Schema,
 	// all our custom propreties, so the struct definition is the single source
 	// of truth.
 
-	b, err := json.Marshal(in.Extras)
+	extras := takeExclusiveBounds(in.Extras, out)
+	b, err := json.Marshal(extras)
 	if err != nil {
 		return nil, err
 	}
-	if err := json.Unmarshal(b, noCustomUnmarshal(out)); err != nil {
+	if err := json.Unmarshal(b, (*noCustomUnmarshal)(out)); err != nil {
 		return nil, err
 	}
+	for k, v := range extras {
+		if knownExtras[k] {
+			continue
+		}
+		if out.Unknown == nil {
+			out.Unknown = make(map[string]interface{})
+		}
+		out.Unknown[k] = v
+	}
+	// The version required by the keywords used is added again when the
+	// schema is marshaled, so only a higher one needs to be kept.
+	if out.MinReaderVersion <= out.readerVersion() {
+		out.MinReaderVersion = 0
+	}
 
 	return out, nil
 }
 
 // noCustomUnmarshal strips off the custom json Unmarshal function so we can use
 // json.Unmarshal to populate our juju metadata fields from schema.Extras.
-type noCustomUnmarshal *Schema
+type noCustomUnmarshal Schema
 
 func fromPrimitiveTypes(p schema.PrimitiveTypes) []Type {
 	if p == nil {
@@ -609,6 +1397,9 @@ func toInternal(in *Schema, cache map[*Schema]*schema.Schema) (*schema.Schema, e
 	if out, ok := cache[in]; ok {
 		return out, nil
 	}
+	if (in.ExclusiveMinimum != nil && in.ExclusiveMinimumValue != nil) || (in.ExclusiveMaximum != nil && in.ExclusiveMaximumValue != nil) {
+		return nil, fmt.Errorf("exclusive bound given in both its boolean and numeric forms")
+	}
 	out := schema.New()
 	cache[in] = out
 
@@ -4,6 +4,8 @@
 package jsonschema
 
 import (
+	"bytes"
+	"encoding/gob"
 	"encoding/json"
 	"strings"
 	"testing"
@@ -93,6 +95,104 @@ func (Suite) TestJSONRoundTrip(c *gc.C) {
 	c.Check(s, gc.DeepEquals, s2)
 }
 
+func (Suite) TestJSONMarshalSingular(c *gc.C) {
+	s := &Schema{
+		Type: []Type{ArrayType},
+		Items: &ItemSpec{
+			Schemas: []*Schema{{Type: []Type{StringType}}},
+		},
+	}
+	b, err := json.Marshal(s)
+	c.Assert(err, gc.IsNil)
+	c.Check(string(b), jc.JSONEquals, map[string]interface{}{
+		"type": "array",
+		"items": map[string]interface{}{
+			"type": "string",
+		},
+	})
+}
+
+func (Suite) TestJSONMarshalTuple(c *gc.C) {
+	s := &Schema{
+		Type: []Type{ArrayType},
+		Items: &ItemSpec{
+			TupleMode: true,
+			Schemas:   []*Schema{{Type: []Type{StringType}}},
+		},
+	}
+	b, err := json.Marshal(s)
+	c.Assert(err, gc.IsNil)
+	c.Check(string(b), jc.JSONEquals, map[string]interface{}{
+		"type": "array",
+		"items": []interface{}{
+			map[string]interface{}{"type": "string"},
+		},
+		"additionalItems": false,
+	})
+	s2 := &Schema{}
+	err = json.Unmarshal(b, s2)
+	c.Assert(err, gc.IsNil)
+	c.Check(s2.Items, jc.DeepEquals, s.Items)
+}
+
+func (Suite) TestTypeJSON(c *gc.C) {
+	b, err := json.Marshal([]Type{StringType, IntegerType})
+	c.Assert(err, gc.IsNil)
+	c.Check(string(b), gc.Equals, `["string","integer"]`)
+
+	var types []Type
+	err = json.Unmarshal(b, &types)
+	c.Assert(err, gc.IsNil)
+	c.Check(types, jc.DeepEquals, []Type{StringType, IntegerType})
+
+	var t Type
+	err = json.Unmarshal([]byte(`"bogus"`), &t)
+	c.Check(err, gc.NotNil)
+}
+
+func (Suite) TestItemSpecJSON(c *gc.C) {
+	for i, test := range []struct {
+		spec ItemSpec
+		json string
+	}{{
+		spec: ItemSpec{Schemas: []*Schema{{Type: []Type{StringType}}}},
+		json: `{"type":"string"}`,
+	}, {
+		spec: ItemSpec{TupleMode: true, Schemas: []*Schema{{Type: []Type{StringType}}, {Type: []Type{IntegerType}}}},
+		json: `[{"type":"string"},{"type":"integer"}]`,
+	}, {
+		spec: ItemSpec{TupleMode: true, Schemas: []*Schema{}},
+		json: `[]`,
+	}} {
+		c.Logf("test %d: %s", i, test.json)
+		b, err := json.Marshal(test.spec)
+		c.Assert(err, gc.IsNil)
+		c.Check(string(b), jc.JSONEquals, jsonValue(c, test.json))
+		var spec ItemSpec
+		err = json.Unmarshal(b, &spec)
+		c.Assert(err, gc.IsNil)
+		c.Check(spec, jc.DeepEquals, test.spec)
+	}
+}
+
+func jsonValue(c *gc.C, data string) interface{} {
+	var v interface{}
+	err := json.Unmarshal([]byte(data), &v)
+	c.Assert(err, gc.IsNil)
+	return v
+}
+
+func (Suite) TestGobRoundTrip(c *gc.C) {
+	var buf bytes.Buffer
+	err := gob.NewEncoder(&buf).Encode(objExample)
+	c.Assert(err, gc.IsNil)
+
+	s := &Schema{}
+	err = gob.NewDecoder(&buf).Decode(s)
+	c.Assert(err, gc.IsNil)
+	c.Check(s, jc.DeepEquals, objExample)
+}
+
 func (Suite) TestFromJSON(c *gc.C) {
 	s, err := FromJSON(strings.NewReader(jsonExample))
 	c.Assert(err, gc.IsNil)
@@ -148,6 +248,53 @@ func (Suite) TestValidateNonMap(c *gc.C) {
 	c.Check(err, gc.IsNil)
 }
 
+func (Suite) TestAdditionalProperties(c *gc.C) {
+	for i, test := range []struct {
+		schema string
+		valid  []map[string]interface{}
+		errors map[string]string
+	}{{
+		schema: `{"type": "object", "properties": {"name": {"type": "string"}}}`,
+		valid:  []map[string]interface{}{{"name": "juju"}},
+		errors: map[string]string{"nmae": `additional properties are not allowed`},
+	}, {
+		schema: `{"type": "object", "properties": {"name": {"type": "string"}}, "additionalProperties": false}`,
+		valid:  []map[string]interface{}{{"name": "juju"}},
+		errors: map[string]string{"nmae": `additional properties are not allowed`},
+	}, {
+		schema: `{"type": "object", "properties": {"name": {"type": "string"}}, "additionalProperties": true}`,
+		valid:  []map[string]interface{}{{"name": "juju", "nmae": "juju", "tags": []interface{}{1}}},
+	}, {
+		schema: `{"type": "object", "properties": {"name": {"type": "string"}}, "additionalProperties": {"type": "integer"}}`,
+		valid:  []map[string]interface{}{{"name": "juju", "count": 3}},
+		errors: map[string]string{"nmae": `nmae: value is not of type integer.*|nmae: .*`},
+	}, {
+		schema: `{"type": "object", "properties": {"config": {"type": "object", "additionalProperties": true}}}`,
+		valid:  []map[string]interface{}{{"config": map[string]interface{}{"anything": "goes"}}},
+	}} {
+		c.Logf("test %d: %s", i, test.schema)
+		s, err := FromJSON(strings.NewReader(test.schema))
+		c.Assert(err, jc.ErrorIsNil)
+		data, err := json.Marshal(s)
+		c.Assert(err, jc.ErrorIsNil)
+		var out Schema
+		c.Assert(json.Unmarshal(data, &out), jc.ErrorIsNil)
+		for _, s := range []*Schema{s, &out} {
+			for _, doc := range test.valid {
+				c.Check(s.Validate(doc), jc.ErrorIsNil)
+			}
+			for name, msg := range test.errors {
+				c.Check(s.Validate(map[string]interface{}{name: "juju"}), gc.ErrorMatches, msg)
+			}
+		}
+	}
+
+	s := &Schema{Type: []Type{ObjectType}}
+	c.Check(s.Validate(map[string]interface{}{"name": "juju"}), gc.ErrorMatches, `additional properties are not allowed`)
+	s.AdditionalProperties = &Schema{}
+	c.Check(s.Validate(map[string]interface{}{"name": "juju", "count": 3}), jc.ErrorIsNil)
+}
+
 func (Suite) TestInsertDefaults(c *gc.C) {
 	s := &Schema{
 		Type: []Type{ObjectType},
@@ -216,3 +363,40 @@ func (Suite) TestInsertDefaults(c *gc.C) {
 		},
 	})
 }
+
+func (Suite) TestInsertConditionalDefaults(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(`
+type: object
+properties:
+  series:
+    type: string
+    default: focal
+  cgroups:
+    type: string
+    default: cgroup1
+    default-when:
+    - when: {series: jammy}
+      value: cgroup2
+    - when: {series: noble}
+      value: cgroup2
+`))
+	c.Assert(err, jc.ErrorIsNil)
+
+	m := map[string]interface{}{}
+	s.InsertDefaults(m)
+	c.Check(m, gc.DeepEquals, map[string]interface{}{
+		"series":  "focal",
+		"cgroups": "cgroup1",
+	})
+
+	m = map[string]interface{}{"series": "jammy"}
+	s.InsertDefaults(m)
+	c.Check(m, gc.DeepEquals, map[string]interface{}{
+		"series":  "jammy",
+		"cgroups": "cgroup2",
+	})
+
+	m = map[string]interface{}{"series": "jammy", "cgroups": "cgroup1"}
+	s.InsertDefaults(m)
+	c.Check(m["cgroups"], gc.Equals, "cgroup1")
+}
@@ -0,0 +1,67 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// checkSetItems returns an error if any two items of arr, which is a set as
+// described by s, are equal. Items of a set with an item key are checked by
+// checkItemKeys instead.
+func checkSetItems(s *Schema, arr []interface{}) error {
+	if s.ItemKey != "" {
+		return nil
+	}
+	seen := make(map[string]int)
+	for i, item := range arr {
+		key := setItemKey(s, item)
+		if j, ok := seen[key]; ok {
+			return fmt.Errorf("items %d and %d are equal", j, i)
+		}
+		seen[key] = i
+	}
+	return nil
+}
+
+// canonicalItems returns the items of arr, described by s, in canonical
+// order: sorted if s describes a set, and as they are otherwise.
+func canonicalItems(s *Schema, arr []interface{}) []interface{} {
+	if s == nil || !s.SetOf {
+		return arr
+	}
+	sorted := make([]interface{}, len(arr))
+	copy(sorted, arr)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return setItemKey(s, sorted[i]) < setItemKey(s, sorted[j])
+	})
+	return sorted
+}
+
+// setItemKey returns the value that item, in the set described by s, is
+// identified and ordered by: its key if s has an item key, or its canonical
+// json encoding otherwise.
+func setItemKey(s *Schema, item interface{}) string {
+	if s.ItemKey != "" {
+		if key, ok := itemKey(s, item); ok {
+			return key
+		}
+	}
+	data, err := json.Marshal(normalizeValue(item))
+	if err != nil {
+		return fmt.Sprint(item)
+	}
+	return string(data)
+}
+
+// setsEqual reports whether the sets a and b, described by s, hold equal
+// items.
+func setsEqual(s *Schema, a, b []interface{}) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	return valuesEqual(canonicalItems(s, a), canonicalItems(s, b))
+}
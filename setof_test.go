@@ -0,0 +1,103 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type SetOfSuite struct{}
+
+var _ = gc.Suite(SetOfSuite{})
+
+const setOfSchema = `
+type: object
+properties:
+  zones:
+    type: array
+    set-of: true
+    items:
+      type: string
+  spaces:
+    type: array
+    set-of: true
+    item-key: name
+    items:
+      type: object
+      properties:
+        name:
+          type: string
+        cidr:
+          type: string
+  rules:
+    type: array
+    set-of: true
+    items:
+      type: object
+      additionalProperties: {type: integer}
+`
+
+func (SetOfSuite) TestValidate(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(setOfSchema))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.Properties["zones"].SetOf, jc.IsTrue)
+
+	c.Check(s.Validate(map[string]interface{}{
+		"zones": []interface{}{"b", "a"},
+		"rules": []interface{}{map[string]interface{}{"port": 22}, map[string]interface{}{"port": 80}},
+	}), jc.ErrorIsNil)
+	c.Check(s.Validate(map[string]interface{}{
+		"zones": []interface{}{"a", "b", "a"},
+	}), gc.ErrorMatches, `zones: items 0 and 2 are equal`)
+	c.Check(s.Validate(map[string]interface{}{
+		"rules": []interface{}{map[string]interface{}{"port": 22}, map[string]interface{}{"port": 22.0}},
+	}), gc.ErrorMatches, `rules: items 0 and 1 are equal`)
+	c.Check(s.Validate(map[string]interface{}{
+		"spaces": []interface{}{
+			map[string]interface{}{"name": "db", "cidr": "10.0.0.0/24"},
+			map[string]interface{}{"name": "db", "cidr": "10.0.1.0/24"},
+		},
+	}), gc.ErrorMatches, `spaces: items 0 and 1 have the same name "db"`)
+}
+
+func (SetOfSuite) TestCanonicalOrder(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(setOfSchema))
+	c.Assert(err, jc.ErrorIsNil)
+	doc := map[string]interface{}{
+		"zones": []interface{}{"c", "a", "b"},
+		"spaces": []interface{}{
+			map[string]interface{}{"name": "web", "cidr": "10.0.1.0/24"},
+			map[string]interface{}{"name": "db", "cidr": "10.0.0.0/24"},
+		},
+	}
+	c.Check(FlattenDoc(s, doc), jc.DeepEquals, map[string]string{
+		"zones":          "a,b,c",
+		"spaces[0].name": "db",
+		"spaces[0].cidr": "10.0.0.0/24",
+		"spaces[1].name": "web",
+		"spaces[1].cidr": "10.0.1.0/24",
+	})
+	out, err := SanitizeForLog(s, doc)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(out), gc.Equals, `{`+
+		`"spaces":[{"cidr":"10.0.0.0/24","name":"db"},{"cidr":"10.0.1.0/24","name":"web"}],`+
+		`"zones":["a","b","c"]}`)
+}
+
+func (SetOfSuite) TestCompareDocs(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(setOfSchema))
+	c.Assert(err, jc.ErrorIsNil)
+	before := map[string]interface{}{"zones": []interface{}{"b", "a"}}
+	c.Check(CompareDocs(s, before, map[string]interface{}{"zones": []interface{}{"a", "b"}}), gc.HasLen, 0)
+	c.Check(CompareDocs(s, before, map[string]interface{}{"zones": []interface{}{"c", "a"}}), jc.DeepEquals, []DocChange{{
+		Path: "zones",
+		Kind: ChangeModified,
+		Type: ArrayType,
+		Old:  `["a","b"]`,
+		New:  `["a","c"]`,
+	}})
+}
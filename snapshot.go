@@ -0,0 +1,162 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+// snapshotVersion is the version of the format written by Snapshot. Version
+// 2 added the documents that external references resolve to.
+const snapshotVersion = 2
+
+// Validator validates documents against a schema that is only compiled once,
+// for callers that validate many documents against the same schema.
+type Validator struct {
+	schema   *Schema
+	snapshot []byte
+
+	// fingerprint holds the hash of the json form of the schema, which
+	// identifies its results in a ResultCache.
+	fingerprint string
+
+	once     sync.Once
+	compiled interface{ Validate(interface{}) error }
+	err      error
+}
+
+type snapshot struct {
+	Version int             `json:"version"`
+	Schema  json.RawMessage `json:"schema"`
+
+	// Documents holds the documents loaded to resolve the schema's
+	// external references, keyed by uri.
+	Documents map[string]json.RawMessage `json:"documents,omitempty"`
+}
+
+// NewValidator compiles s and returns a Validator for it. The schema must not
+// be modified while the Validator is in use.
+func NewValidator(s *Schema) (*Validator, error) {
+	return newValidator(s, s, nil)
+}
+
+// NewValidatorWithLoader returns a Validator for s in the same way as
+// NewValidator, with the external references in s resolved using loader.
+// The documents loaded are included in its Snapshot, so that a Validator
+// restored with LoadSnapshot doesn't need to load them again.
+func NewValidatorWithLoader(s *Schema, loader Loader) (*Validator, error) {
+	docs := make(map[string]*Schema)
+	recorder := LoaderFunc(func(uri string) (*Schema, error) {
+		doc, err := loader.Load(uri)
+		if err == nil && doc != nil {
+			docs[uri] = doc
+		}
+		return doc, err
+	})
+	resolved, err := withExternalRefs(s, recorder)
+	if err != nil {
+		return nil, err
+	}
+	return newValidator(s, resolved, docs)
+}
+
+// newValidator returns a Validator for resolved, which is s with its external
+// references resolved by loading docs.
+func newValidator(s, resolved *Schema, docs map[string]*Schema) (*Validator, error) {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return nil, err
+	}
+	snap := snapshot{Version: snapshotVersion, Schema: data}
+	for uri, doc := range docs {
+		if snap.Documents == nil {
+			snap.Documents = make(map[string]json.RawMessage)
+		}
+		if snap.Documents[uri], err = json.Marshal(doc); err != nil {
+			return nil, err
+		}
+	}
+	snapData, err := json.Marshal(snap)
+	if err != nil {
+		return nil, err
+	}
+	v := &Validator{schema: resolved, snapshot: snapData, fingerprint: hashBytes(data)}
+	if _, err := v.compile(); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// LoadSnapshot returns the Validator saved by Snapshot. External references
+// are resolved using the documents held by the snapshot, without loading
+// them again. Compilation is deferred until the first document is validated,
+// so that agents with tight startup budgets only pay for it when validation
+// is actually needed.
+func LoadSnapshot(data []byte) (*Validator, error) {
+	var snap snapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return nil, fmt.Errorf("cannot load validator snapshot: %v", err)
+	}
+	if snap.Version < 1 || snap.Version > snapshotVersion {
+		return nil, fmt.Errorf("cannot load validator snapshot: unsupported version %d", snap.Version)
+	}
+	s := &Schema{}
+	if err := json.Unmarshal(snap.Schema, s); err != nil {
+		return nil, fmt.Errorf("cannot load validator snapshot: %v", err)
+	}
+	if len(snap.Documents) > 0 {
+		loader := LoaderFunc(func(uri string) (*Schema, error) {
+			data, ok := snap.Documents[uri]
+			if !ok {
+				return nil, fmt.Errorf("document %q not in snapshot", uri)
+			}
+			doc := &Schema{}
+			return doc, json.Unmarshal(data, doc)
+		})
+		if err := resolveExternalRefs(s, loader); err != nil {
+			return nil, fmt.Errorf("cannot load validator snapshot: %v", err)
+		}
+	}
+	return &Validator{schema: s, snapshot: data, fingerprint: hashBytes(snap.Schema)}, nil
+}
+
+// Snapshot returns a representation of the validator which can be persisted
+// and later restored with LoadSnapshot. It holds the json form of the schema
+// and of the documents its external references were resolved to, if it was
+// created with NewValidatorWithLoader. The compiled validator can't be
+// persisted, and is rebuilt when the restored Validator is first used.
+func (v *Validator) Snapshot() []byte {
+	return append([]byte(nil), v.snapshot...)
+}
+
+// Schema returns the schema that v validates against.
+func (v *Validator) Schema() *Schema {
+	return v.schema
+}
+
+// Validate validates x in the same way as Schema.Validate.
+func (v *Validator) Validate(x interface{}) error {
+	return v.ValidateWith(ValidationOptions{}, x)
+}
+
+// ValidateWith validates x in the same way as Schema.ValidateWith.
+// If a profile or variants apply to x, the schema they produce is compiled
+// for this call only.
+func (v *Validator) ValidateWith(opts ValidationOptions, x interface{}) error {
+	if err := checkQuotas(v.schema, x); err != nil {
+		return opts.audit(v.schema, x, err)
+	}
+	return opts.audit(v.schema, x, opts.cached(v.fingerprint, x, func() error {
+		return validateSchema(opts, v.schema, v.compile, x)
+	}))
+}
+
+func (v *Validator) compile() (interface{ Validate(interface{}) error }, error) {
+	v.once.Do(func() {
+		v.compiled, v.err = compileInternal(v.schema, v.schema)
+	})
+	return v.compiled, v.err
+}
@@ -0,0 +1,107 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type SnapshotSuite struct{}
+
+var _ = gc.Suite(SnapshotSuite{})
+
+const snapshotSchema = `
+type: object
+definitions:
+  port:
+    type: integer
+    minimum: 1
+    maximum: 65535
+properties:
+  name:
+    type: string
+    pattern: ^[a-z]+$
+  port:
+    $ref: "#/definitions/port"
+  kind:
+    type: string
+    variants:
+      tls:
+        properties:
+          cert:
+            type: string
+        required: [cert]
+`
+
+func (SnapshotSuite) TestValidator(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(snapshotSchema))
+	c.Assert(err, jc.ErrorIsNil)
+	v, err := NewValidator(s)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(v.Schema(), gc.Equals, s)
+	checkSnapshotValidator(c, v)
+}
+
+func (SnapshotSuite) TestSnapshotRoundTrip(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(snapshotSchema))
+	c.Assert(err, jc.ErrorIsNil)
+	v, err := NewValidator(s)
+	c.Assert(err, jc.ErrorIsNil)
+
+	loaded, err := LoadSnapshot(v.Snapshot())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(loaded.Schema(), jc.DeepEquals, s)
+	c.Check(loaded.Snapshot(), jc.DeepEquals, v.Snapshot())
+	checkSnapshotValidator(c, loaded)
+}
+
+func (SnapshotSuite) TestLoadSnapshotErrors(c *gc.C) {
+	_, err := LoadSnapshot([]byte("nope"))
+	c.Check(err, gc.ErrorMatches, "cannot load validator snapshot: .*")
+	_, err = LoadSnapshot([]byte(`{"version": 99, "schema": {}}`))
+	c.Check(err, gc.ErrorMatches, "cannot load validator snapshot: unsupported version 99")
+	_, err = LoadSnapshot([]byte(`{"version": 2, "schema": {"$ref": "https://example.com/a.json"}, "documents": {"https://example.com/b.json": {}}}`))
+	c.Check(err, gc.ErrorMatches, `cannot load validator snapshot: cannot resolve \$ref "https://example.com/a.json": document "https://example.com/a.json" not in snapshot`)
+}
+
+func (SnapshotSuite) TestSnapshotExternalRefs(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(`
+type: object
+properties:
+  port:
+    $ref: https://example.com/common.json#/definitions/port
+`))
+	c.Assert(err, jc.ErrorIsNil)
+	loads := 0
+	loader := LoaderFunc(func(uri string) (*Schema, error) {
+		loads++
+		c.Check(uri, gc.Equals, "https://example.com/common.json")
+		return FromYAML(strings.NewReader(`
+definitions:
+  port: {type: integer, minimum: 1}
+`))
+	})
+	v, err := NewValidatorWithLoader(s, loader)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(loads, gc.Equals, 1)
+	c.Check(v.Validate(map[string]interface{}{"port": 0}), gc.ErrorMatches, "port: .*")
+
+	loaded, err := LoadSnapshot(v.Snapshot())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(loads, gc.Equals, 1)
+	c.Check(loaded.Validate(map[string]interface{}{"port": 80}), jc.ErrorIsNil)
+	c.Check(loaded.Validate(map[string]interface{}{"port": 0}), gc.ErrorMatches, "port: .*")
+	c.Check(loaded.Snapshot(), jc.DeepEquals, v.Snapshot())
+}
+
+func checkSnapshotValidator(c *gc.C, v *Validator) {
+	c.Check(v.Validate(map[string]interface{}{"name": "web", "port": 80}), jc.ErrorIsNil)
+	c.Check(v.Validate(map[string]interface{}{"name": "Web"}), gc.NotNil)
+	c.Check(v.Validate(map[string]interface{}{"port": 0}), gc.NotNil)
+	c.Check(v.Validate(map[string]interface{}{"kind": "tls", "cert": "x"}), jc.ErrorIsNil)
+	c.Check(v.Validate(map[string]interface{}{"kind": "tls"}), gc.NotNil)
+}
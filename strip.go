@@ -0,0 +1,48 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+// annotations holds the keywords which only exist to describe a schema to
+// humans, and which therefore have no effect on validation or defaults.
+var annotations = map[string]func(*Schema){
+	"title":                func(s *Schema) { s.Title = "" },
+	"description":          func(s *Schema) { s.Description = "" },
+	"titles":               func(s *Schema) { s.Titles = nil },
+	"descriptions":         func(s *Schema) { s.Descriptions = nil },
+	"pattern-description":  func(s *Schema) { s.PatternDescription = "" },
+	"pattern-descriptions": func(s *Schema) { s.PatternDescriptions = nil },
+	"enum-labels":          func(s *Schema) { s.EnumLabels = nil },
+	"enum-descriptions":    func(s *Schema) { s.EnumDescriptions = nil },
+	"example":              func(s *Schema) { s.Example = nil },
+	"examples":             func(s *Schema) { s.Examples = nil },
+	"order":                func(s *Schema) { s.Order = nil },
+	"singular":             func(s *Schema) { s.Singular = "" },
+	"plural":               func(s *Schema) { s.Plural = "" },
+	"prompt-default":       func(s *Schema) { s.PromptDefault = nil },
+	"group":                func(s *Schema) { s.Group = "" },
+	"groups":               func(s *Schema) { s.GroupSpecs = nil },
+	"visible-when":         func(s *Schema) { s.VisibleWhen = nil },
+	"provenance":           func(s *Schema) { s.Provenance = "" },
+}
+
+// StripAnnotations returns a copy of s with all annotation keywords, such as
+// descriptions and examples, removed from it and every nested schema. The
+// keywords named in keep are left in place. The result validates exactly the
+// same documents as s, but is cheaper to send to agents which only need to
+// validate.
+func StripAnnotations(s *Schema, keep ...string) *Schema {
+	kept := make(map[string]bool)
+	for _, k := range keep {
+		kept[k] = true
+	}
+	stripped := cloneSchema(s)
+	walkSchema(stripped, func(s *Schema) {
+		for keyword, strip := range annotations {
+			if !kept[keyword] {
+				strip(s)
+			}
+		}
+	})
+	return stripped
+}
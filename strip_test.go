@@ -0,0 +1,81 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type StripSuite struct{}
+
+var _ = gc.Suite(StripSuite{})
+
+func annotatedSchema() *Schema {
+	return &Schema{
+		Type:        []Type{ObjectType},
+		Title:       "Endpoint",
+		Description: "An endpoint to connect to.",
+		Order:       []string{"url", "region"},
+		Properties: map[string]*Schema{
+			"url": {
+				Type:        []Type{StringType},
+				Description: "The URL of the endpoint.",
+				Example:     "https://example.com",
+				Format:      FormatURI,
+			},
+			"region": {
+				Type:          []Type{StringType},
+				Title:         "Region",
+				Singular:      "region",
+				Plural:        "regions",
+				PromptDefault: "north",
+				Default:       "north",
+				Secret:        true,
+			},
+		},
+		Required: []string{"url"},
+	}
+}
+
+func (StripSuite) TestStripAnnotations(c *gc.C) {
+	s := annotatedSchema()
+	stripped := StripAnnotations(s)
+	c.Check(stripped, jc.DeepEquals, &Schema{
+		Type: []Type{ObjectType},
+		Properties: map[string]*Schema{
+			"url": {
+				Type:   []Type{StringType},
+				Format: FormatURI,
+			},
+			"region": {
+				Type:    []Type{StringType},
+				Default: "north",
+				Secret:  true,
+			},
+		},
+		Required: []string{"url"},
+	})
+
+	// The original is untouched.
+	c.Check(s, jc.DeepEquals, annotatedSchema())
+}
+
+func (StripSuite) TestStripAnnotationsKeep(c *gc.C) {
+	stripped := StripAnnotations(annotatedSchema(), "title", "order")
+	c.Check(stripped.Title, gc.Equals, "Endpoint")
+	c.Check(stripped.Description, gc.Equals, "")
+	c.Check(stripped.Order, jc.DeepEquals, []string{"url", "region"})
+	c.Check(stripped.Properties["region"].Title, gc.Equals, "Region")
+	c.Check(stripped.Properties["region"].Singular, gc.Equals, "")
+}
+
+func (StripSuite) TestStripAnnotationsRecursive(c *gc.C) {
+	s := &Schema{Description: "node"}
+	s.Properties = map[string]*Schema{"child": s}
+	stripped := StripAnnotations(s)
+	c.Check(stripped.Description, gc.Equals, "")
+	c.Check(stripped.Properties["child"], gc.Equals, stripped)
+	c.Check(s.Description, gc.Equals, "node")
+}
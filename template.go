@@ -0,0 +1,69 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+)
+
+// numericKeywords holds the keywords whose values may be given by template
+// parameters.
+var numericKeywords = []string{
+	"multipleOf", "minimum", "maximum", "exclusiveMinimum", "exclusiveMaximum",
+	"minLength", "maxLength",
+	"minItems", "maxItems",
+	"minProperties", "maxProperties",
+}
+
+// templateParam matches a template parameter, such as ${maxNodes}.
+var templateParam = regexp.MustCompile(`^\$\{([A-Za-z_][A-Za-z0-9_-]*)\}$`)
+
+// LoadTemplate returns a schema created from the json or yaml value in r,
+// in which the values of numeric constraints such as maximum and maxItems may
+// be given by parameters, as in "maxItems: ${maxNodes}". Each parameter is
+// replaced by its value in params, which must be a number, so that one
+// schema file can serve several tiers or regions. Using a parameter that
+// isn't in params is an error.
+func LoadTemplate(r io.Reader, params map[string]interface{}) (*Schema, error) {
+	val, err := readYAML(r)
+	if err != nil {
+		return nil, err
+	}
+	var substErr error
+	val, _ = rewriteRawSchema(val, func(m map[string]interface{}) (map[string]interface{}, bool) {
+		changed := false
+		for _, k := range numericKeywords {
+			str, ok := m[k].(string)
+			if !ok {
+				continue
+			}
+			match := templateParam.FindStringSubmatch(str)
+			if match == nil {
+				continue
+			}
+			param, ok := params[match[1]]
+			if !ok {
+				if substErr == nil {
+					substErr = fmt.Errorf("%s: unknown template parameter %q", k, match[1])
+				}
+				continue
+			}
+			n, ok := normalizeValue(param).(float64)
+			if !ok {
+				if substErr == nil {
+					substErr = fmt.Errorf("%s: template parameter %q must be a number, not %v", k, match[1], param)
+				}
+				continue
+			}
+			m[k], changed = n, true
+		}
+		return m, changed
+	})
+	if substErr != nil {
+		return nil, fmt.Errorf("cannot load schema template: %v", substErr)
+	}
+	return FromGo(val)
+}
@@ -0,0 +1,68 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type TemplateSuite struct{}
+
+var _ = gc.Suite(TemplateSuite{})
+
+const templateSchema = `
+type: object
+properties:
+  nodes:
+    type: array
+    maxItems: ${maxNodes}
+    items:
+      type: object
+      properties:
+        memory:
+          type: integer
+          minimum: 512
+          maximum: ${maxMemory}
+  note:
+    type: string
+    default: ${maxNodes}
+`
+
+func (TemplateSuite) TestLoadTemplate(c *gc.C) {
+	s, err := LoadTemplate(strings.NewReader(templateSchema), map[string]interface{}{
+		"maxNodes":  3,
+		"maxMemory": 16384.0,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	nodes := s.Properties["nodes"]
+	c.Check(nodes.MaxItems, jc.DeepEquals, Int(3))
+	c.Check(nodes.Items.Schemas[0].Properties["memory"].Maximum, jc.DeepEquals, Float(16384))
+
+	// Only numeric constraints are substituted.
+	c.Check(s.Properties["note"].Default, gc.Equals, "${maxNodes}")
+
+	node := map[string]interface{}{"memory": 1024}
+	c.Check(s.Validate(map[string]interface{}{
+		"nodes": []interface{}{node, node, node},
+	}), jc.ErrorIsNil)
+	c.Check(s.Validate(map[string]interface{}{
+		"nodes": []interface{}{node, node, node, node},
+	}), gc.NotNil)
+}
+
+func (TemplateSuite) TestLoadTemplateErrors(c *gc.C) {
+	_, err := LoadTemplate(strings.NewReader(templateSchema), map[string]interface{}{
+		"maxNodes": 3,
+	})
+	c.Check(err, gc.ErrorMatches, `cannot load schema template: maximum: unknown template parameter "maxMemory"`)
+
+	_, err = LoadTemplate(strings.NewReader(templateSchema), map[string]interface{}{
+		"maxNodes":  "three",
+		"maxMemory": 1024,
+	})
+	c.Check(err, gc.ErrorMatches, `cannot load schema template: maxItems: template parameter "maxNodes" must be a number, not three`)
+}
@@ -0,0 +1,331 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// GenerateTypeScript returns TypeScript declarations for the documents
+// described by s, for use by typed API clients. The documents are declared
+// as typeName: an interface if s describes objects with properties, and a
+// type alias otherwise. Each schema that s refers to is declared alongside
+// it, named after the last element of the reference, so that definitions
+// shared between properties are declared once. Properties are optional
+// unless they are required, and descriptions are kept as doc comments.
+//
+// Properties allowed alongside those a schema declares are given an index
+// signature; as TypeScript requires the declared properties to conform to
+// it, its values are unknown unless there are no declared properties.
+func GenerateTypeScript(s *Schema, typeName string) ([]byte, error) {
+	if !tsIdentifier.MatchString(typeName) {
+		return nil, fmt.Errorf("cannot generate TypeScript: invalid type name %q", typeName)
+	}
+	g := &tsGenerator{
+		root:  s,
+		names: map[*Schema]string{s: typeName},
+		used:  map[string]bool{typeName: true},
+		queue: []*Schema{s},
+	}
+	var decls []string
+	for len(g.queue) > 0 {
+		next := g.queue[0]
+		g.queue = g.queue[1:]
+		decl, err := g.declaration(next, g.names[next])
+		if err != nil {
+			return nil, err
+		}
+		decls = append(decls, decl)
+	}
+	out := "// Code generated by jsonschema.GenerateTypeScript; DO NOT EDIT.\n\n" + strings.Join(decls, "\n")
+	return []byte(out), nil
+}
+
+// tsIdentifier matches the names which may be used unquoted in TypeScript.
+var tsIdentifier = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+
+type tsGenerator struct {
+	root *Schema
+
+	// names holds the name declared for each schema that's referred to.
+	names map[*Schema]string
+	used  map[string]bool
+
+	// queue holds the schemas which have been named but not declared.
+	queue []*Schema
+}
+
+// declaration returns the declaration of s as the given name.
+func (g *tsGenerator) declaration(s *Schema, name string) (string, error) {
+	var b strings.Builder
+	tsComment(&b, s.Description, "")
+	if tsInterface(s) {
+		body, err := g.members(s, "")
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(&b, "export interface %s %s\n", name, body)
+		return b.String(), nil
+	}
+	t, err := g.typeOf(s, "")
+	if err != nil {
+		return "", err
+	}
+	fmt.Fprintf(&b, "export type %s = %s;\n", name, t)
+	return b.String(), nil
+}
+
+// tsInterface reports whether s can be declared as an interface.
+func tsInterface(s *Schema) bool {
+	return s.ref() == "" && len(s.Enum) == 0 && len(s.AllOf) == 0 && len(s.AnyOf) == 0 && len(s.OneOf) == 0 &&
+		len(s.Properties) > 0 && (len(s.Type) == 0 || len(s.Type) == 1 && s.Type[0] == ObjectType)
+}
+
+// typeOf returns the TypeScript type of the values described by s, which is
+// nested to the given indent.
+func (g *tsGenerator) typeOf(s *Schema, indent string) (string, error) {
+	if s.ref() != "" {
+		return g.refName(s)
+	}
+	if len(s.Enum) > 0 {
+		literals := make([]string, len(s.Enum))
+		for i, v := range s.Enum {
+			b, err := json.Marshal(v)
+			if err != nil {
+				return "", fmt.Errorf("cannot generate TypeScript: invalid enum value %v: %v", v, err)
+			}
+			literals[i] = string(b)
+		}
+		return strings.Join(literals, " | "), nil
+	}
+	own, err := g.ownType(s, indent)
+	if err != nil {
+		return "", err
+	}
+	var terms []string
+	if own != "unknown" || len(s.AllOf)+len(s.AnyOf)+len(s.OneOf) == 0 {
+		terms = append(terms, own)
+	}
+	for _, sub := range s.AllOf {
+		t, err := g.typeOf(sub, indent)
+		if err != nil {
+			return "", err
+		}
+		terms = append(terms, t)
+	}
+	for _, alts := range [][]*Schema{s.AnyOf, s.OneOf} {
+		if len(alts) == 0 {
+			continue
+		}
+		union := make([]string, len(alts))
+		for i, alt := range alts {
+			if union[i], err = g.typeOf(alt, indent); err != nil {
+				return "", err
+			}
+		}
+		terms = append(terms, strings.Join(union, " | "))
+	}
+	if len(terms) == 1 {
+		return terms[0], nil
+	}
+	for i, t := range terms {
+		terms[i] = tsParens(t)
+	}
+	return strings.Join(terms, " & "), nil
+}
+
+// ownType returns the TypeScript type of the values described by the type
+// of s and the keywords that describe them, ignoring the schemas that s is
+// composed of.
+func (g *tsGenerator) ownType(s *Schema, indent string) (string, error) {
+	types := s.Type
+	if len(types) == 0 {
+		if describesObject(s) {
+			types = append(types, ObjectType)
+		}
+		if describesArray(s) {
+			types = append(types, ArrayType)
+		}
+	}
+	if len(types) == 0 {
+		return "unknown", nil
+	}
+	var union []string
+	seen := make(map[string]bool)
+	for _, t := range types {
+		var ts string
+		switch t {
+		case StringType:
+			ts = "string"
+		case IntegerType, NumberType:
+			ts = "number"
+		case BooleanType:
+			ts = "boolean"
+		case NullType:
+			ts = "null"
+		case ObjectType:
+			var err error
+			if ts, err = g.members(s, indent); err != nil {
+				return "", err
+			}
+		case ArrayType:
+			var err error
+			if ts, err = g.arrayType(s, indent); err != nil {
+				return "", err
+			}
+		default:
+			ts = "unknown"
+		}
+		if !seen[ts] {
+			seen[ts] = true
+			union = append(union, ts)
+		}
+	}
+	return strings.Join(union, " | "), nil
+}
+
+// members returns the object type literal for the properties of s, nested
+// to the given indent.
+func (g *tsGenerator) members(s *Schema, indent string) (string, error) {
+	var extra []*Schema
+	if s.AdditionalProperties != nil {
+		extra = append(extra, s.AdditionalProperties)
+	}
+	extra = append(extra, sortedPatternSchemas(s.PatternProperties)...)
+	if len(s.Properties) == 0 && len(extra) == 0 {
+		return "Record<string, never>", nil
+	}
+	required := make(map[string]bool)
+	for _, name := range s.Required {
+		required[name] = true
+	}
+	inner := indent + "  "
+	var b strings.Builder
+	b.WriteString("{\n")
+	for _, name := range orderedProperties(s) {
+		ps := s.Properties[name]
+		t, err := g.typeOf(ps, inner)
+		if err != nil {
+			return "", err
+		}
+		tsComment(&b, ps.Description, inner)
+		key := name
+		if !tsIdentifier.MatchString(name) {
+			key = strconv.Quote(name)
+		}
+		optional := "?"
+		if required[name] {
+			optional = ""
+		}
+		fmt.Fprintf(&b, "%s%s%s: %s;\n", inner, key, optional, t)
+	}
+	if len(extra) > 0 {
+		t := "unknown"
+		if len(s.Properties) == 0 {
+			union := make([]string, len(extra))
+			for i, es := range extra {
+				var err error
+				if union[i], err = g.typeOf(es, inner); err != nil {
+					return "", err
+				}
+			}
+			t = strings.Join(union, " | ")
+		}
+		fmt.Fprintf(&b, "%s[key: string]: %s;\n", inner, t)
+	}
+	b.WriteString(indent + "}")
+	return b.String(), nil
+}
+
+// arrayType returns the TypeScript type of the arrays described by s,
+// nested to the given indent.
+func (g *tsGenerator) arrayType(s *Schema, indent string) (string, error) {
+	if s.Items == nil || len(s.Items.Schemas) == 0 {
+		return "unknown[]", nil
+	}
+	if !s.Items.TupleMode {
+		t, err := g.typeOf(s.Items.Schemas[0], indent)
+		if err != nil {
+			return "", err
+		}
+		return tsParens(t) + "[]", nil
+	}
+	elems := make([]string, len(s.Items.Schemas))
+	for i, item := range s.Items.Schemas {
+		var err error
+		if elems[i], err = g.typeOf(item, indent); err != nil {
+			return "", err
+		}
+	}
+	if s.AdditionalItems != nil {
+		t, err := g.typeOf(s.AdditionalItems, indent)
+		if err != nil {
+			return "", err
+		}
+		elems = append(elems, "..."+tsParens(t)+"[]")
+	}
+	return "[" + strings.Join(elems, ", ") + "]", nil
+}
+
+// refName returns the name declared for the schema that s refers to,
+// naming it if it hasn't been named already.
+func (g *tsGenerator) refName(s *Schema) (string, error) {
+	target := refTarget(g.root, s)
+	if target == nil {
+		return "", fmt.Errorf("cannot generate TypeScript: cannot resolve reference %q", s.ref())
+	}
+	if name, ok := g.names[target]; ok {
+		return name, nil
+	}
+	base := pascalName(refName(s.ref()))
+	name := base
+	for i := 2; g.used[name]; i++ {
+		name = base + strconv.Itoa(i)
+	}
+	g.names[target] = name
+	g.used[name] = true
+	g.queue = append(g.queue, target)
+	return name, nil
+}
+
+// tsParens returns t in parentheses if it is a union or intersection, for
+// use inside another type.
+func tsParens(t string) string {
+	depth := 0
+	for _, r := range t {
+		switch r {
+		case '{', '[', '(', '<':
+			depth++
+		case '}', ']', ')', '>':
+			depth--
+		case '|', '&':
+			if depth == 0 {
+				return "(" + t + ")"
+			}
+		}
+	}
+	return t
+}
+
+// tsComment writes description to b as a doc comment at the given indent.
+func tsComment(b *strings.Builder, description, indent string) {
+	if description == "" {
+		return
+	}
+	lines := strings.Split(strings.TrimSpace(description), "\n")
+	if len(lines) == 1 {
+		fmt.Fprintf(b, "%s/** %s */\n", indent, strings.ReplaceAll(lines[0], "*/", "*\\/"))
+		return
+	}
+	fmt.Fprintf(b, "%s/**\n", indent)
+	for _, line := range lines {
+		line = strings.ReplaceAll(line, "*/", "*\\/")
+		fmt.Fprintf(b, "%s\n", strings.TrimRight(indent+" * "+line, " "))
+	}
+	fmt.Fprintf(b, "%s */\n", indent)
+}
@@ -0,0 +1,136 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type TypeScriptSuite struct{}
+
+var _ = gc.Suite(TypeScriptSuite{})
+
+const typeScriptSchema = `
+type: object
+description: A deployed application.
+order: [name, units]
+required: [name]
+properties:
+  name:
+    type: string
+    description: The name of the application.
+  units:
+    type: integer
+  channel:
+    enum: [stable, candidate, edge]
+  exposed:
+    type: [boolean, "null"]
+  endpoints:
+    type: array
+    items:
+      $ref: "#/definitions/endpoint"
+  config:
+    type: object
+    additionalProperties:
+      type: [string, number]
+  constraints:
+    type: array
+    items:
+    - type: string
+    - type: integer
+  instance-type:
+    $ref: "#/definitions/endpoint/properties/space"
+definitions:
+  endpoint:
+    type: object
+    required: [space]
+    properties:
+      space:
+        type: string
+      relations:
+        type: array
+        items:
+          $ref: "#/definitions/endpoint"
+`
+
+func (TypeScriptSuite) TestGenerateTypeScript(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(typeScriptSchema))
+	c.Assert(err, jc.ErrorIsNil)
+	out, err := GenerateTypeScript(s, "Application")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(out), gc.Equals, `// Code generated by jsonschema.GenerateTypeScript; DO NOT EDIT.
+
+/** A deployed application. */
+export interface Application {
+  /** The name of the application. */
+  name: string;
+  units?: number;
+  channel?: "stable" | "candidate" | "edge";
+  config?: {
+    [key: string]: string | number;
+  };
+  constraints?: [string, number];
+  endpoints?: Endpoint[];
+  exposed?: boolean | null;
+  "instance-type"?: Space;
+}
+
+export interface Endpoint {
+  relations?: Endpoint[];
+  space: string;
+}
+
+export type Space = string;
+`)
+}
+
+func (TypeScriptSuite) TestGenerateTypeScriptComposition(c *gc.C) {
+	s := &Schema{
+		Type: []Type{ObjectType},
+		Properties: map[string]*Schema{
+			"hosts": {AnyOf: []*Schema{
+				{Type: []Type{StringType}},
+				{Type: []Type{ArrayType}, Items: &ItemSpec{Schemas: []*Schema{{Type: []Type{StringType}}}}},
+			}},
+			"labels": {
+				Type:                 []Type{ObjectType},
+				AdditionalProperties: &Schema{Type: []Type{StringType}},
+				AllOf:                []*Schema{{Type: []Type{ObjectType}, Properties: map[string]*Schema{"app": {Type: []Type{StringType}}}}},
+			},
+			"any": {},
+		},
+	}
+	out, err := GenerateTypeScript(s, "Config")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(out), jc.Contains, "  any?: unknown;\n")
+	c.Check(string(out), jc.Contains, "  hosts?: string | string[];\n")
+	c.Check(string(out), jc.Contains, `  labels?: {
+    [key: string]: string;
+  } & {
+    app?: string;
+  };
+`)
+}
+
+func (TypeScriptSuite) TestGenerateTypeScriptErrors(c *gc.C) {
+	_, err := GenerateTypeScript(&Schema{}, "not-a-name")
+	c.Check(err, gc.ErrorMatches, `cannot generate TypeScript: invalid type name "not-a-name"`)
+	s := &Schema{Properties: map[string]*Schema{"a": {Reference: "#/definitions/missing"}}}
+	_, err = GenerateTypeScript(s, "Config")
+	c.Check(err, gc.ErrorMatches, `cannot generate TypeScript: cannot resolve reference "#/definitions/missing"`)
+}
+
+func (TypeScriptSuite) TestPascalName(c *gc.C) {
+	for name, want := range map[string]string{
+		"endpoint":      "Endpoint",
+		"instance-type": "InstanceType",
+		"v1.Config":     "V1Config",
+		"2fa":           "T2fa",
+	} {
+		c.Check(pascalName(name), gc.Equals, want)
+	}
+}
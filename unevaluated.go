@@ -0,0 +1,260 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"errors"
+	"reflect"
+
+	"github.com/lestrrat/go-jsschema"
+)
+
+// ErrUnevaluated is the error returned for a property that isn't allowed by
+// an unevaluatedProperties of false.
+var ErrUnevaluated = errors.New("unevaluated properties are not allowed")
+
+// ErrUnevaluatedItems is the error returned for an item that isn't allowed
+// by an unevaluatedItems of false.
+var ErrUnevaluatedItems = errors.New("unevaluated items are not allowed")
+
+// leftUnevaluated records whether the additional properties and items of
+// the schemas composed into another are left to be checked by its
+// unevaluatedProperties and unevaluatedItems.
+type leftUnevaluated struct {
+	properties, items bool
+}
+
+// add returns l, also leaving additional properties or items to s if it
+// has unevaluatedProperties or unevaluatedItems.
+func (l leftUnevaluated) add(s *Schema) leftUnevaluated {
+	return leftUnevaluated{
+		properties: l.properties || s.UnevaluatedProperties != nil,
+		items:      l.items || s.UnevaluatedItems != nil,
+	}
+}
+
+// open adjusts in, the internal form of s, to allow any of the additional
+// properties and items left unevaluated, if s doesn't say which it allows.
+func (l leftUnevaluated) open(s *Schema, in *schema.Schema) {
+	if l.properties && s.AdditionalProperties == nil {
+		in.AdditionalProperties = &schema.AdditionalProperties{}
+	}
+	if l.items && s.AdditionalItems == nil && !(len(s.PrefixItems) > 0 && s.Items != nil) {
+		in.AdditionalItems = &schema.AdditionalItems{}
+	}
+}
+
+// validateUnevaluated checks the properties of obj, found at path, that
+// aren't evaluated by s against s.UnevaluatedProperties.
+func (v *validation) validateUnevaluated(s *Schema, obj map[string]interface{}, path string) {
+	for _, name := range unevaluatedProperties(v.root, s, obj) {
+		ppath := propertyPath(path, name)
+		if errs := unevaluatedError(v.root, s.UnevaluatedProperties, obj[name], ppath, "unevaluatedProperties"); len(errs) > 0 {
+			v.errs = append(v.errs, errs...)
+			continue
+		}
+		v.validate(s.UnevaluatedProperties, obj[name], ppath)
+	}
+}
+
+// validateUnevaluatedItems checks the items of arr, found at path, that
+// aren't evaluated by s against s.UnevaluatedItems.
+func (v *validation) validateUnevaluatedItems(s *Schema, arr []interface{}, path string) {
+	for _, i := range unevaluatedItems(v.root, s, arr) {
+		ipath := itemPath(path, i)
+		if errs := unevaluatedError(v.root, s.UnevaluatedItems, arr[i], ipath, "unevaluatedItems"); len(errs) > 0 {
+			v.errs = append(v.errs, errs...)
+			continue
+		}
+		v.validate(s.UnevaluatedItems, arr[i], ipath)
+	}
+}
+
+// unevaluatedErrors returns the errors found by the keywords of
+// s.UnevaluatedProperties that are implemented by jsschema in the
+// properties of obj, found at path, that aren't evaluated by s. References
+// are resolved within root.
+func unevaluatedErrors(root, s *Schema, obj map[string]interface{}, path string) ValidationErrors {
+	if s.UnevaluatedProperties == nil {
+		return nil
+	}
+	var errs ValidationErrors
+	for _, name := range unevaluatedProperties(root, s, obj) {
+		errs = append(errs, unevaluatedError(root, s.UnevaluatedProperties, obj[name], propertyPath(path, name), "unevaluatedProperties")...)
+	}
+	return errs
+}
+
+// unevaluatedItemErrors returns the errors found by the keywords of
+// s.UnevaluatedItems in the items of arr that aren't evaluated by s, in the
+// same way as unevaluatedErrors.
+func unevaluatedItemErrors(root, s *Schema, arr []interface{}, path string) ValidationErrors {
+	if s.UnevaluatedItems == nil {
+		return nil
+	}
+	var errs ValidationErrors
+	for _, i := range unevaluatedItems(root, s, arr) {
+		errs = append(errs, unevaluatedError(root, s.UnevaluatedItems, arr[i], itemPath(path, i), "unevaluatedItems")...)
+	}
+	return errs
+}
+
+// unevaluatedError returns the errors found in x, the unevaluated property
+// or item found at path, by the keywords of us, given by the named keyword,
+// that are implemented by jsschema.
+func unevaluatedError(root, us *Schema, x interface{}, path, keyword string) ValidationErrors {
+	if rejectsAll(derefLocal(root, us)) {
+		rejected := ErrUnevaluated
+		if keyword == "unevaluatedItems" {
+			rejected = ErrUnevaluatedItems
+		}
+		return ValidationErrors{{Path: path, Keyword: keyword, Err: rejected}}
+	}
+	err := validateInternal(root, us, x)
+	if err == nil {
+		return nil
+	}
+	errs := explainError(root, us, x, path, err)
+	for _, e := range errs {
+		if e.Keyword == "" {
+			e.Keyword = keyword
+		}
+	}
+	return errs
+}
+
+// unevaluatedProperties returns the names of the properties of obj that
+// aren't evaluated by s, found within root, in sorted order, if s has
+// unevaluatedProperties. A property is evaluated by a schema which declares
+// it, or which has a pattern property or additionalProperties that applies
+// to it, or by any of the schemas that apply to obj as described by
+// applicableSchemas. A composed schema with unevaluatedProperties of its
+// own evaluates every property.
+func unevaluatedProperties(root, s *Schema, obj map[string]interface{}) []string {
+	s = derefLocal(root, s)
+	if s.UnevaluatedProperties == nil {
+		return nil
+	}
+	evaluated := make(map[string]bool)
+	applicableSchemas(root, s, obj, func(cs *Schema) {
+		for name := range obj {
+			if len(propertySchemas(cs, name)) > 0 || (cs != s && cs.UnevaluatedProperties != nil) {
+				evaluated[name] = true
+			}
+		}
+	})
+	var names []string
+	for _, name := range sortedObjectKeys(obj) {
+		if !evaluated[name] {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// unevaluatedItems returns the indexes of the items of arr that aren't
+// evaluated by s, found within root, in order, if s has unevaluatedItems.
+// An item is evaluated by a schema whose prefixItems or items apply to it,
+// or whose additionalItems do, or whose contains it matches, or by any of
+// the schemas that apply to arr as described by applicableSchemas. A
+// composed schema with unevaluatedItems of its own evaluates every item.
+func unevaluatedItems(root, s *Schema, arr []interface{}) []int {
+	s = derefLocal(root, s)
+	if s.UnevaluatedItems == nil {
+		return nil
+	}
+	evaluated := 0
+	contained := make(map[int]bool)
+	applicableSchemas(root, s, arr, func(cs *Schema) {
+		if cs.Contains != nil {
+			for _, i := range containedItems(root, cs, arr) {
+				contained[i] = true
+			}
+		}
+		n := 0
+		switch {
+		case cs != s && cs.UnevaluatedItems != nil:
+			n = len(arr)
+		case len(cs.PrefixItems) > 0:
+			n = len(cs.PrefixItems)
+			if cs.Items != nil {
+				n = len(arr)
+			}
+		case cs.Items == nil:
+		case !cs.Items.TupleMode || cs.AdditionalItems != nil:
+			n = len(arr)
+		default:
+			n = len(cs.Items.Schemas)
+		}
+		if n > evaluated {
+			evaluated = n
+		}
+	})
+	var indexes []int
+	for i := evaluated; i < len(arr); i++ {
+		if !contained[i] {
+			indexes = append(indexes, i)
+		}
+	}
+	return indexes
+}
+
+// applicableSchemas calls fn for s, found within root, and for each schema
+// composed into it which applies to x: any allOf schema, the anyOf and
+// oneOf alternatives that x matches, the if schema if x matches it, the
+// then or else schema chosen for x, and the schemas depending on the
+// properties x holds, following references.
+func applicableSchemas(root, s *Schema, x interface{}, fn func(cs *Schema)) {
+	obj, _ := asObject(x)
+	seen := make(map[*Schema]bool)
+	var add func(cs *Schema)
+	add = func(cs *Schema) {
+		cs = derefLocal(root, cs)
+		if seen[cs] {
+			return
+		}
+		seen[cs] = true
+		fn(cs)
+		for _, sub := range cs.AllOf {
+			add(sub)
+		}
+		for _, alts := range [][]*Schema{cs.AnyOf, cs.OneOf} {
+			for _, alt := range alts {
+				if matchesConstraint(root, alt, x) {
+					add(alt)
+				}
+			}
+		}
+		if cs.If != nil {
+			branch, keyword := chooseBranch(root, cs, x)
+			if keyword == "then" {
+				add(cs.If)
+			}
+			if branch != nil {
+				add(branch)
+			}
+		}
+		for _, name := range sortedDependents(cs) {
+			if _, ok := obj[name]; ok {
+				for _, dep := range cs.dependentSchemas()[name] {
+					add(dep)
+				}
+			}
+		}
+	}
+	add(s)
+}
+
+// matchesConstraint reports whether x matches s, found within root, taken
+// as a constraint in the same way as an if schema, so that the properties s
+// doesn't declare are allowed.
+func matchesConstraint(root, s *Schema, x interface{}) bool {
+	return validateInternal(root, &Schema{Not: s}, x) != nil
+}
+
+// rejectsAll reports whether s is the schema which allows no value, as
+// false is decoded where a schema may be given as a boolean.
+func rejectsAll(s *Schema) bool {
+	return s.Not != nil && reflect.DeepEqual(s.Not, &Schema{})
+}
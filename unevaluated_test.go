@@ -0,0 +1,221 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"encoding/json"
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type UnevaluatedSuite struct{}
+
+var _ = gc.Suite(UnevaluatedSuite{})
+
+const unevaluatedSchema = `
+type: object
+definitions:
+  base:
+    type: object
+    properties:
+      name: {type: string}
+allOf:
+- $ref: "#/definitions/base"
+- properties:
+    region: {type: string}
+anyOf:
+- properties:
+    token: {type: string}
+  required: [token]
+- properties:
+    user: {type: string}
+    password: {type: string}
+  required: [user, password]
+if:
+  properties:
+    kind: {enum: [lxd]}
+  required: [kind]
+then:
+  properties:
+    socket: {type: string}
+else:
+  properties:
+    endpoint: {type: string}
+unevaluatedProperties: false
+`
+
+func (UnevaluatedSuite) TestUnevaluatedProperties(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(unevaluatedSchema))
+	c.Assert(err, jc.ErrorIsNil)
+	for i, test := range []struct {
+		doc map[string]interface{}
+		err string
+	}{{
+		doc: map[string]interface{}{"name": "a", "region": "r", "token": "t"},
+	}, {
+		doc: map[string]interface{}{"user": "u", "password": "p"},
+	}, {
+		doc: map[string]interface{}{"token": "t", "kind": "lxd", "socket": "/s"},
+	}, {
+		doc: map[string]interface{}{"token": "t", "endpoint": "e"},
+	}, {
+		doc: map[string]interface{}{"token": "t", "nmae": "a"},
+		err: `nmae: unevaluated properties are not allowed`,
+	}, {
+		// The password is only evaluated by an alternative that
+		// doesn't match.
+		doc: map[string]interface{}{"token": "t", "password": "p"},
+		err: `password: unevaluated properties are not allowed`,
+	}, {
+		// The socket is only evaluated by the then schema, which
+		// doesn't apply.
+		doc: map[string]interface{}{"token": "t", "socket": "/s"},
+		err: `socket: unevaluated properties are not allowed`,
+	}} {
+		c.Logf("test %d: %v", i, test.doc)
+		err := s.Validate(test.doc)
+		if test.err == "" {
+			c.Check(err, jc.ErrorIsNil)
+			continue
+		}
+		c.Check(err, gc.ErrorMatches, test.err)
+		c.Check(err.(*ValidationError).Keyword, gc.Equals, "unevaluatedProperties")
+	}
+}
+
+func (UnevaluatedSuite) TestUnevaluatedPropertiesSchema(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(`
+type: object
+allOf:
+- properties:
+    name: {type: string}
+unevaluatedProperties: {type: integer, maximum: 10}
+`))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.Validate(map[string]interface{}{"name": "a", "count": 3}), jc.ErrorIsNil)
+	c.Check(s.Validate(map[string]interface{}{"name": "a", "count": "3"}), gc.ErrorMatches, `count: .*`)
+	c.Check(s.Validate(map[string]interface{}{"name": "a", "count": 30}), gc.ErrorMatches, `count: .*`)
+}
+
+func (UnevaluatedSuite) TestUnevaluatedPropertiesWithAdditionalProperties(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(`
+type: object
+allOf:
+- properties:
+    name: {type: string}
+  additionalProperties: true
+unevaluatedProperties: false
+`))
+	c.Assert(err, jc.ErrorIsNil)
+	// The allOf schema evaluates every property.
+	c.Check(s.Validate(map[string]interface{}{"name": "a", "other": 1}), jc.ErrorIsNil)
+}
+
+func (UnevaluatedSuite) TestUnevaluatedPropertiesWithOtherErrors(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(`
+type: object
+allOf:
+- properties:
+    port: {type: integer, maximum: 10}
+unevaluatedProperties: false
+`))
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.Validate(map[string]interface{}{"port": 30, "nmae": "a"})
+	c.Assert(err, gc.FitsTypeOf, ValidationErrors{})
+	var paths []string
+	for _, e := range err.(ValidationErrors) {
+		paths = append(paths, e.Path)
+	}
+	c.Check(paths, jc.SameContents, []string{"port", "nmae"})
+}
+
+func (UnevaluatedSuite) TestRoundTrip(c *gc.C) {
+	for _, text := range []string{
+		`{"type": "object", "unevaluatedProperties": false}`,
+		`{"type": "object", "unevaluatedProperties": true}`,
+	} {
+		c.Logf("schema %s", text)
+		s, err := FromJSON(strings.NewReader(text))
+		c.Assert(err, jc.ErrorIsNil)
+		data, err := json.Marshal(s)
+		c.Assert(err, jc.ErrorIsNil)
+		var out Schema
+		c.Assert(json.Unmarshal(data, &out), jc.ErrorIsNil)
+		c.Check(out.UnevaluatedProperties, jc.DeepEquals, s.UnevaluatedProperties)
+	}
+	s, err := FromJSON(strings.NewReader(`{"type": "object", "unevaluatedProperties": true}`))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.Validate(map[string]interface{}{"anything": "goes"}), jc.ErrorIsNil)
+}
+
+func (UnevaluatedSuite) TestUnevaluatedItems(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(`
+type: object
+properties:
+  tuple:
+    type: array
+    items: [{type: string}]
+    unevaluatedItems: false
+  composed:
+    type: array
+    allOf:
+    - items: [{type: string}, {type: integer}]
+    unevaluatedItems: {type: boolean}
+  alternatives:
+    type: array
+    anyOf:
+    - items: [{type: string}]
+    - items: [{type: integer}, {type: integer}]
+    unevaluatedItems: false
+  open:
+    type: array
+    allOf:
+    - items: [{type: integer}]
+      additionalItems: {type: integer}
+    unevaluatedItems: false
+`))
+	c.Assert(err, jc.ErrorIsNil)
+	for i, test := range []struct {
+		doc map[string]interface{}
+		err string
+	}{{
+		doc: map[string]interface{}{"tuple": []interface{}{"a"}},
+	}, {
+		doc: map[string]interface{}{"tuple": []interface{}{"a", "b"}},
+		err: `tuple\[1\]: unevaluated items are not allowed`,
+	}, {
+		doc: map[string]interface{}{"composed": []interface{}{"a", 1, true, false}},
+	}, {
+		doc: map[string]interface{}{"composed": []interface{}{"a", 1, "x"}},
+		err: `composed\[2\]: .*`,
+	}, {
+		doc: map[string]interface{}{"alternatives": []interface{}{1, 2}},
+	}, {
+		doc: map[string]interface{}{"alternatives": []interface{}{"a", 2}},
+		err: `alternatives\[1\]: unevaluated items are not allowed`,
+	}, {
+		doc: map[string]interface{}{"open": []interface{}{1, 2, 3}},
+	}} {
+		c.Logf("test %d: %v", i, test.doc)
+		err := s.Validate(test.doc)
+		if test.err == "" {
+			c.Check(err, jc.ErrorIsNil)
+			continue
+		}
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (UnevaluatedSuite) TestUnevaluatedItemsRoundTrip(c *gc.C) {
+	s, err := FromJSON(strings.NewReader(`{"type": "array", "items": [{"type": "string"}], "unevaluatedItems": false}`))
+	c.Assert(err, jc.ErrorIsNil)
+	data, err := json.Marshal(s)
+	c.Assert(err, jc.ErrorIsNil)
+	var out Schema
+	c.Assert(json.Unmarshal(data, &out), jc.ErrorIsNil)
+	c.Check(out.UnevaluatedItems, jc.DeepEquals, s.UnevaluatedItems)
+	c.Check(out.Validate([]interface{}{"a", "b"}), gc.ErrorMatches, `\[1\]: unevaluated items are not allowed`)
+}
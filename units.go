@@ -0,0 +1,180 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+)
+
+// Unit identifies the unit of a quantity. See Schema.Unit.
+type Unit string
+
+// Units understood by Coerce.
+const (
+	UnitMiB          Unit = "MiB"
+	UnitGiB          Unit = "GiB"
+	UnitSeconds      Unit = "s"
+	UnitMilliseconds Unit = "ms"
+)
+
+// Coerce converts the values in into to the canonical representation given
+// by their schemas, writing the results back into the document. A quantity
+// with a unit is converted to a number in that unit if its schema is numeric,
+// so that "2G" becomes 2048 for a property in MiB and "90s" becomes 90 for a
+// property in seconds, and to a string with a suffix if its schema is a
+// string, so that 2048 becomes "2G". Dates and times given in the lenient
+// forms allowed by ValidationOptions.LenientDates are converted to RFC 3339.
+// Nested objects and arrays are converted too, following references and
+// allOf schemas, and the schemas given by patternProperties and
+// additionalProperties.
+func (s *Schema) Coerce(into map[string]interface{}) (err error) {
+	defer recoverPanic(&err)
+	_, err = coerceValue(s, []*Schema{s}, into, "")
+	return err
+}
+
+// coerceValue converts x, found at path, to the representation given by
+// the schemas, found within root, which describe it. The first of them, or
+// of the allOf schemas they are composed of, to give a unit or a date format
+// decides how x is converted.
+func coerceValue(root *Schema, schemas []*Schema, x interface{}, path string) (interface{}, error) {
+	var composed []*Schema
+	for _, s := range schemas {
+		if s != nil {
+			composed = append(composed, composedSchemas(root, s, false)...)
+		}
+	}
+	switch x := x.(type) {
+	case map[string]interface{}:
+		for _, name := range sortedObjectKeys(x) {
+			var ps []*Schema
+			for _, cs := range composed {
+				ps = append(ps, propertySchemas(cs, name)...)
+			}
+			if len(ps) == 0 {
+				continue
+			}
+			v, err := coerceValue(root, ps, x[name], propertyPath(path, name))
+			if err != nil {
+				return nil, err
+			}
+			x[name] = v
+		}
+		return x, nil
+	case []interface{}:
+		for i, item := range x {
+			var is []*Schema
+			for _, cs := range composed {
+				is = append(is, itemSchema(cs, i))
+			}
+			item, err := coerceValue(root, is, item, itemPath(path, i))
+			if err != nil {
+				return nil, err
+			}
+			x[i] = item
+		}
+		return x, nil
+	}
+	for _, s := range composed {
+		if str, ok := x.(string); ok && isDateFormat(s.Format) {
+			v, err := normalizeDate(s.Format, str)
+			if err != nil {
+				return nil, validationError(path, "format", err)
+			}
+			return v, nil
+		}
+		if s.Unit != "" {
+			v, err := s.coerceQuantity(x)
+			if err != nil {
+				return nil, validationError(path, "unit", err)
+			}
+			return v, nil
+		}
+	}
+	return x, nil
+}
+
+// coerceQuantity converts x, which is a quantity with unit s.Unit, to the
+// representation given by the type of s.
+func (s *Schema) coerceQuantity(x interface{}) (interface{}, error) {
+	q, err := parseQuantity(s.Unit, x)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case hasType(s, IntegerType):
+		if q != math.Trunc(q) {
+			return nil, fmt.Errorf("%v is not a whole number of %s", x, s.Unit)
+		}
+		return int(q), nil
+	case hasType(s, NumberType):
+		return q, nil
+	case hasType(s, StringType):
+		return formatQuantity(s.Unit, q), nil
+	}
+	return x, nil
+}
+
+// parseQuantity returns the number of units in x, which is either a number
+// already in those units, or a string with a suffix such as "2G" or "90s".
+func parseQuantity(unit Unit, x interface{}) (float64, error) {
+	switch x := normalizeValue(x).(type) {
+	case float64:
+		return x, nil
+	case string:
+		if f, err := strconv.ParseFloat(x, 64); err == nil {
+			return f, nil
+		}
+		switch unit {
+		case UnitMiB, UnitGiB:
+			mib, ok := parseMiB(x)
+			if !ok {
+				return 0, fmt.Errorf("expected a size such as 512M or 8G, got %q", x)
+			}
+			if unit == UnitGiB {
+				return mib / 1024, nil
+			}
+			return mib, nil
+		case UnitSeconds, UnitMilliseconds:
+			d, err := time.ParseDuration(x)
+			if err != nil {
+				return 0, fmt.Errorf("expected a duration such as 90s or 5m, got %q", x)
+			}
+			return float64(d) / float64(unitDuration(unit)), nil
+		}
+		return 0, fmt.Errorf("unknown unit %q", unit)
+	}
+	return 0, fmt.Errorf("expected a quantity in %s, got %v", unit, x)
+}
+
+// formatQuantity returns q units as a string, using the largest suffix that
+// represents it exactly.
+func formatQuantity(unit Unit, q float64) string {
+	switch unit {
+	case UnitMiB, UnitGiB:
+		mib := q
+		if unit == UnitGiB {
+			mib = q * 1024
+		}
+		for _, s := range sizeSuffixes {
+			if n := mib / s.multiplier; n >= 1 && n == math.Trunc(n) {
+				return strconv.FormatFloat(n, 'f', -1, 64) + s.suffix
+			}
+		}
+		return strconv.FormatFloat(mib, 'f', -1, 64) + "M"
+	case UnitSeconds, UnitMilliseconds:
+		return time.Duration(q * float64(unitDuration(unit))).String()
+	}
+	return strconv.FormatFloat(q, 'f', -1, 64)
+}
+
+func unitDuration(unit Unit) time.Duration {
+	if unit == UnitMilliseconds {
+		return time.Millisecond
+	}
+	return time.Second
+}
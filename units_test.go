@@ -0,0 +1,127 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type UnitsSuite struct{}
+
+var _ = gc.Suite(UnitsSuite{})
+
+const unitsSchema = `
+type: object
+properties:
+  root-disk:
+    type: integer
+    unit: MiB
+  volume-size:
+    type: number
+    unit: GiB
+  display-size:
+    type: string
+    unit: MiB
+  timeout:
+    type: integer
+    unit: s
+  poll-interval:
+    type: string
+    unit: ms
+  volumes:
+    type: array
+    items:
+      type: object
+      properties:
+        size:
+          type: integer
+          unit: MiB
+`
+
+func (UnitsSuite) TestCoerce(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(unitsSchema))
+	c.Assert(err, jc.ErrorIsNil)
+
+	doc := map[string]interface{}{
+		"root-disk":     "2G",
+		"volume-size":   "512M",
+		"display-size":  3072,
+		"timeout":       "1m30s",
+		"poll-interval": 1500,
+		"volumes": []interface{}{
+			map[string]interface{}{"size": "1T"},
+			map[string]interface{}{"size": 100},
+		},
+		"other": "2G",
+	}
+	err = s.Coerce(doc)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(doc, jc.DeepEquals, map[string]interface{}{
+		"root-disk":     2048,
+		"volume-size":   0.5,
+		"display-size":  "3G",
+		"timeout":       90,
+		"poll-interval": "1.5s",
+		"volumes": []interface{}{
+			map[string]interface{}{"size": 1024 * 1024},
+			map[string]interface{}{"size": 100},
+		},
+		"other": "2G",
+	})
+	delete(doc, "other")
+	c.Check(s.Validate(doc), jc.ErrorIsNil)
+}
+
+func (UnitsSuite) TestCoerceErrors(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(unitsSchema))
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.Coerce(map[string]interface{}{"root-disk": "lots"})
+	c.Check(err, gc.ErrorMatches, `root-disk: expected a size such as 512M or 8G, got "lots"`)
+	err = s.Coerce(map[string]interface{}{"timeout": "1500ms "})
+	c.Check(err, gc.ErrorMatches, `timeout: expected a duration such as 90s or 5m, got "1500ms "`)
+	err = s.Coerce(map[string]interface{}{"timeout": "1500ms"})
+	c.Check(err, gc.ErrorMatches, `timeout: 1500ms is not a whole number of s`)
+	err = s.Coerce(map[string]interface{}{"volumes": []interface{}{
+		map[string]interface{}{"size": true},
+	}})
+	c.Check(err, gc.ErrorMatches, `volumes\[0\].size: expected a quantity in MiB, got true`)
+}
+
+func (UnitsSuite) TestCoerceComposed(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(`
+type: object
+definitions:
+  size: {type: integer, unit: MiB}
+properties:
+  disk: {$ref: "#/definitions/size"}
+  limits:
+    type: object
+    additionalProperties: {type: integer, unit: s}
+  volume:
+    allOf:
+    - type: object
+      properties:
+        size: {$ref: "#/definitions/size"}
+patternProperties:
+  "-memory$": {type: integer, unit: MiB}
+`))
+	c.Assert(err, jc.ErrorIsNil)
+	doc := map[string]interface{}{
+		"disk":         "2G",
+		"limits":       map[string]interface{}{"start": "1m", "stop": "10s"},
+		"volume":       map[string]interface{}{"size": "1G"},
+		"agent-memory": "512M",
+	}
+	c.Assert(s.Coerce(doc), jc.ErrorIsNil)
+	c.Check(doc, jc.DeepEquals, map[string]interface{}{
+		"disk":         2048,
+		"limits":       map[string]interface{}{"start": 60, "stop": 10},
+		"volume":       map[string]interface{}{"size": 1024},
+		"agent-memory": 512,
+	})
+}
@@ -0,0 +1,167 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Upstream describes a schema published by a third party, such as the
+// cloud-init configuration schema or a Kubernetes CRD, which is vendored
+// into this package's format.
+type Upstream struct {
+	// URL is where the upstream schema is published, in json or yaml. It
+	// may hold a Kubernetes custom resource definition, in which case the
+	// openAPIV3Schema of one of its versions is used.
+	URL string
+
+	// Version names the version of a custom resource definition whose
+	// schema is used. If it is empty, the storage version is used.
+	Version string
+
+	// Overlay, if not nil, is applied to the upstream schema once it has
+	// been fetched. See ApplyOverlay.
+	Overlay *SchemaPatch
+}
+
+// upstreamTimeout limits the time taken to fetch an upstream schema when
+// no loader is given to Upstream.Fetch.
+const upstreamTimeout = time.Minute
+
+// Fetch retrieves the upstream schema using the given loader, which must
+// allow URL, and returns it with the overlay applied. If l is nil, a loader
+// allowing only the scheme and host of URL is used, with a timeout of a
+// minute and a size limit of DefaultMaxSchemaSize.
+func (u Upstream) Fetch(l *HTTPLoader) (*Schema, error) {
+	if l == nil {
+		parsed, err := url.Parse(u.URL)
+		if err != nil {
+			return nil, err
+		}
+		l = &HTTPLoader{
+			Policy: HostPolicy{
+				Schemes:    []string{parsed.Scheme},
+				AllowHosts: []string{parsed.Hostname()},
+			},
+			Timeout: upstreamTimeout,
+		}
+	}
+	data, err := l.fetch(u.URL)
+	if err != nil {
+		return nil, err
+	}
+	// YAML is a superset of json, so this handles both.
+	v, err := readYAML(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse schema from %s: %v", u.URL, err)
+	}
+	if isCRD(v) {
+		if v, err = crdSchema(v, u.Version); err != nil {
+			return nil, fmt.Errorf("cannot use schema from %s: %v", u.URL, err)
+		}
+	}
+	s, err := FromGo(v)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse schema from %s: %v", u.URL, err)
+	}
+	if u.Overlay == nil {
+		return s, nil
+	}
+	return ApplyOverlay(s, *u.Overlay)
+}
+
+// isCRD reports whether v holds a Kubernetes custom resource definition.
+func isCRD(v interface{}) bool {
+	obj, _ := v.(map[string]interface{})
+	return obj["kind"] == "CustomResourceDefinition"
+}
+
+// crdSchema returns the openAPIV3Schema of the named version of the custom
+// resource definition crd, or of its storage version if version is empty.
+// Definitions from apiextensions.k8s.io/v1beta1 may give a single schema for
+// every version under spec.validation.
+func crdSchema(crd interface{}, version string) (interface{}, error) {
+	spec, _ := crd.(map[string]interface{})["spec"].(map[string]interface{})
+	versions, _ := spec["versions"].([]interface{})
+	found := version == "" && len(versions) == 0
+	var schema map[string]interface{}
+	for _, v := range versions {
+		v, _ := v.(map[string]interface{})
+		if version != "" && v["name"] != version || version == "" && v["storage"] != true {
+			continue
+		}
+		found = true
+		schema, _ = v["schema"].(map[string]interface{})
+		break
+	}
+	if !found {
+		if version == "" {
+			return nil, fmt.Errorf("no storage version in custom resource definition")
+		}
+		return nil, fmt.Errorf("no version %q in custom resource definition", version)
+	}
+	if schema == nil {
+		schema, _ = spec["validation"].(map[string]interface{})
+	}
+	openAPI, ok := schema["openAPIV3Schema"]
+	if !ok {
+		return nil, fmt.Errorf("no openAPIV3Schema in custom resource definition")
+	}
+	return openAPI, nil
+}
+
+// WriteYAML writes s to w as yaml, with keys in sorted order.
+func WriteYAML(w io.Writer, s *Schema) error {
+	b, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	var v interface{}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(v); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+// WriteGo writes Go source to w declaring, in the named package, a function
+// called name which returns s. The source is marked as generated from the
+// given origin, which is usually the upstream URL.
+func WriteGo(w io.Writer, s *Schema, pkg, name, origin string) error {
+	b, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	literal := "`" + string(b) + "`"
+	if bytes.ContainsRune(b, '`') {
+		literal = strconv.Quote(string(b))
+	}
+	var src strings.Builder
+	fmt.Fprintf(&src, "// Code generated from %s; DO NOT EDIT.\n\n", origin)
+	fmt.Fprintf(&src, "package %s\n\n", pkg)
+	fmt.Fprintf(&src, "import (\n\t\"strings\"\n\n\t\"github.com/juju/jsonschema\"\n)\n\n")
+	fmt.Fprintf(&src, "// %s returns the schema vendored from %s.\n", name, origin)
+	fmt.Fprintf(&src, "func %s() (*jsonschema.Schema, error) {\n", name)
+	fmt.Fprintf(&src, "\treturn jsonschema.FromJSON(strings.NewReader(%s))\n}\n", literal)
+	formatted, err := format.Source([]byte(src.String()))
+	if err != nil {
+		return fmt.Errorf("cannot format generated source: %v", err)
+	}
+	_, err = w.Write(formatted)
+	return err
+}
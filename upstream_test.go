@@ -0,0 +1,168 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type UpstreamSuite struct{}
+
+var _ = gc.Suite(UpstreamSuite{})
+
+const upstreamSchema = `{
+  "type": "object",
+  "properties": {
+    "hostname": {"type": "string", "description": "The ` + "`hostname`" + ` to set."}
+  }
+}`
+
+func serveSchema(c *gc.C, body string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/schema.json" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, body)
+	}))
+}
+
+func (UpstreamSuite) TestFetch(c *gc.C) {
+	srv := serveSchema(c, upstreamSchema)
+	defer srv.Close()
+
+	u := Upstream{
+		URL: srv.URL + "/schema.json",
+		Overlay: &SchemaPatch{Schema{
+			Required: []string{"hostname"},
+		}},
+	}
+	s, err := u.Fetch(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.Required, jc.DeepEquals, []string{"hostname"})
+	c.Check(s.Properties["hostname"].Type, jc.DeepEquals, []Type{StringType})
+}
+
+func (UpstreamSuite) TestFetchNotFound(c *gc.C) {
+	srv := serveSchema(c, upstreamSchema)
+	defer srv.Close()
+
+	_, err := Upstream{URL: srv.URL + "/missing.json"}.Fetch(nil)
+	c.Check(err, gc.ErrorMatches, `cannot load .*/missing.json: 404 Not Found`)
+}
+
+func (UpstreamSuite) TestFetchWithLoader(c *gc.C) {
+	srv := serveSchema(c, upstreamSchema)
+	defer srv.Close()
+
+	u := Upstream{URL: srv.URL + "/schema.json"}
+	_, err := u.Fetch(&HTTPLoader{HTTPClient: srv.Client()})
+	c.Check(err, gc.ErrorMatches, `cannot load .*: host not allowed`)
+	c.Check(errors.Is(err, ErrHostNotAllowed), jc.IsTrue)
+
+	l := &HTTPLoader{
+		Policy:     HostPolicy{Schemes: []string{"http"}, AllowHosts: []string{"127.0.0.1"}},
+		HTTPClient: srv.Client(),
+		MaxSize:    10,
+	}
+	_, err = u.Fetch(l)
+	c.Check(err, gc.ErrorMatches, `cannot load .*: .*exceeds.*`)
+
+	l.MaxSize = 0
+	s, err := u.Fetch(l)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.Properties["hostname"].Type, jc.DeepEquals, []Type{StringType})
+}
+
+const upstreamCRD = `
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: widgets.example.com
+spec:
+  group: example.com
+  versions:
+  - name: v1alpha1
+    served: true
+    storage: false
+    schema:
+      openAPIV3Schema:
+        type: object
+        properties:
+          size: {type: string}
+  - name: v1
+    served: true
+    storage: true
+    schema:
+      openAPIV3Schema:
+        type: object
+        properties:
+          size: {type: integer}
+`
+
+func (UpstreamSuite) TestFetchCRD(c *gc.C) {
+	srv := serveSchema(c, upstreamCRD)
+	defer srv.Close()
+
+	s, err := Upstream{URL: srv.URL + "/schema.json"}.Fetch(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.Properties["size"].Type, jc.DeepEquals, []Type{IntegerType})
+
+	s, err = Upstream{URL: srv.URL + "/schema.json", Version: "v1alpha1"}.Fetch(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.Properties["size"].Type, jc.DeepEquals, []Type{StringType})
+
+	_, err = Upstream{URL: srv.URL + "/schema.json", Version: "v2"}.Fetch(nil)
+	c.Check(err, gc.ErrorMatches, `cannot use schema from .*: no version "v2" in custom resource definition`)
+}
+
+func (UpstreamSuite) TestCRDSchemaV1beta1(c *gc.C) {
+	crd := map[string]interface{}{
+		"kind": "CustomResourceDefinition",
+		"spec": map[string]interface{}{
+			"version": "v1",
+			"validation": map[string]interface{}{
+				"openAPIV3Schema": map[string]interface{}{"type": "object"},
+			},
+		},
+	}
+	c.Assert(isCRD(crd), jc.IsTrue)
+	v, err := crdSchema(crd, "")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(v, jc.DeepEquals, map[string]interface{}{"type": "object"})
+	_, err = crdSchema(map[string]interface{}{"kind": "CustomResourceDefinition"}, "")
+	c.Check(err, gc.ErrorMatches, `no openAPIV3Schema in custom resource definition`)
+}
+
+func (UpstreamSuite) TestWriteYAML(c *gc.C) {
+	var buf bytes.Buffer
+	err := WriteYAML(&buf, objExample)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s, err := FromYAML(&buf)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s, jc.DeepEquals, objExample)
+}
+
+func (UpstreamSuite) TestWriteGo(c *gc.C) {
+	s, err := FromJSON(strings.NewReader(upstreamSchema))
+	c.Assert(err, jc.ErrorIsNil)
+
+	var buf bytes.Buffer
+	err = WriteGo(&buf, s, "cloudinit", "Schema", "https://example.com/schema.json")
+	c.Assert(err, jc.ErrorIsNil)
+	src := buf.String()
+	c.Check(src, jc.HasPrefix, "// Code generated from https://example.com/schema.json; DO NOT EDIT.\n\npackage cloudinit\n")
+	c.Check(src, jc.Contains, "func Schema() (*jsonschema.Schema, error) {")
+	// The description contains backquotes, so a quoted string is used.
+	c.Check(src, jc.Contains, `strings.NewReader("{\n`)
+}
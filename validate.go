@@ -0,0 +1,366 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ValidationOptions holds values supplied by the caller of ValidateWith,
+// such as the name of the cloud or the version of the controller, which custom
+// validators may need to make their decision.
+type ValidationOptions struct {
+	// Values holds arbitrary caller-supplied values, keyed by name.
+	Values map[string]interface{}
+
+	// FeatureFlags holds the names of the enabled feature flags. Properties
+	// with a feature-flag keyword may only be set when their flag is
+	// enabled.
+	FeatureFlags []string
+
+	// Profile holds the name of the validation profile to apply, if any.
+	// See Schema.Profiles.
+	Profile string
+
+	// Role holds the role of the user supplying the document. When set,
+	// properties with an access keyword naming another role may not be
+	// set.
+	Role string
+
+	// Audit, if set, records every validation failure.
+	Audit AuditSink
+
+	// AuditKey holds the secret key used to identify documents in the
+	// records given to Audit. See AuditRecord.DocumentHash.
+	AuditKey []byte
+
+	// Cache, if set, holds the results of earlier validations, which are
+	// returned instead of validating the same document again.
+	Cache *ResultCache
+
+	// ExpensiveBudget limits the expensive validators and formats run by
+	// a single call to ValidateWith. Once it has been used up, further
+	// expensive checks are skipped with a warning.
+	ExpensiveBudget Budget
+
+	// Language holds the language tag, such as "fr" or "pt-BR", of the
+	// language in which to describe errors where the schema gives
+	// translations, as it may for pattern descriptions.
+	Language string
+
+	// Warn, if set, is called with any warnings issued during validation.
+	Warn func(warning error)
+
+	// LenientDates allows values with the date and date-time formats to
+	// be given in forms that are commonly typed by hand but that RFC 3339
+	// doesn't allow, such as "2026-10-14 09:30". See Schema.Coerce.
+	LenientDates bool
+
+	// AllowNonFinite allows numbers in the document to be NaN or infinite.
+	// By default they are rejected, as they can't be represented in json
+	// and compare unexpectedly against minimum and maximum.
+	AllowNonFinite bool
+
+	// Documents holds the other documents being validated alongside this
+	// one by ValidateBatch, keyed by name. See Schema.KeyOf.
+	Documents map[string]map[string]interface{}
+
+	// Loader, if set, is used to load the schemas referred to by any
+	// external references in the schema which weren't resolved when it
+	// was decoded. See DecodeOptions.Loader.
+	Loader Loader
+}
+
+// Budget limits the work done by expensive checks. A zero field sets no
+// limit.
+type Budget struct {
+	// MaxChecks holds the number of expensive checks that may be run.
+	MaxChecks int
+
+	// MaxDuration holds the total time that expensive checks may take.
+	MaxDuration time.Duration
+}
+
+// exhausted reports whether the budget has been used up by the given number
+// of checks taking the given time.
+func (b Budget) exhausted(checks int, elapsed time.Duration) bool {
+	return (b.MaxChecks > 0 && checks >= b.MaxChecks) ||
+		(b.MaxDuration > 0 && elapsed >= b.MaxDuration)
+}
+
+func (opts ValidationOptions) warn(warning error) {
+	if opts.Warn != nil {
+		opts.Warn(warning)
+	}
+}
+
+// FeatureEnabled reports whether the named feature flag is enabled.
+func (opts ValidationOptions) FeatureEnabled(flag string) bool {
+	for _, f := range opts.FeatureFlags {
+		if f == flag {
+			return true
+		}
+	}
+	return false
+}
+
+// Value returns the named value from opts.Values, or nil if it isn't set.
+func (opts ValidationOptions) Value(name string) interface{} {
+	return opts.Values[name]
+}
+
+// ValidatorFunc checks that value satisfies a custom constraint, returning an
+// error describing the problem if it does not.
+type ValidatorFunc func(opts ValidationOptions, value interface{}) error
+
+type registeredValidator struct {
+	f         ValidatorFunc
+	expensive bool
+}
+
+var (
+	validatorsMu sync.RWMutex
+	validators   = make(map[string]registeredValidator)
+)
+
+// RegisterValidator registers a custom validator that schemas may refer to by
+// name in their validators keyword. Registering a validator with the name of
+// an existing one replaces it.
+func RegisterValidator(name string, f ValidatorFunc) {
+	registerValidator(name, registeredValidator{f: f})
+}
+
+// RegisterExpensiveValidator registers a custom validator in the same way as
+// RegisterValidator, marking it as expensive. Expensive checks are limited
+// by ValidationOptions.ExpensiveBudget.
+func RegisterExpensiveValidator(name string, f ValidatorFunc) {
+	registerValidator(name, registeredValidator{f: f, expensive: true})
+}
+
+func registerValidator(name string, v registeredValidator) {
+	validatorsMu.Lock()
+	defer validatorsMu.Unlock()
+	validators[name] = v
+}
+
+func lookupValidator(name string) (registeredValidator, bool) {
+	validatorsMu.RLock()
+	defer validatorsMu.RUnlock()
+	v, ok := validators[name]
+	return v, ok
+}
+
+// validation holds the state for checking the keywords which are implemented
+// by this package, rather than by the underlying jsschema validator.
+type validation struct {
+	opts ValidationOptions
+
+	// root holds the schema being validated against, which references are
+	// resolved within.
+	root *Schema
+
+	// errs holds the errors found so far.
+	errs ValidationErrors
+
+	// explanation explains the errors found by jsschema within root.
+	explanation *explanation
+
+	// expensiveChecks and expensiveTime hold the number of expensive
+	// checks made so far, and the time taken by them.
+	expensiveChecks int
+	expensiveTime   time.Duration
+}
+
+// validate checks x, found at the given path in the document, against the
+// keywords in s that are implemented by this package, adding any errors
+// found to v.errs. The document must already have been found valid by
+// jsschema, so x is known to have the right shape for s.
+func (v *validation) validate(s *Schema, x interface{}, path string) {
+	if s == nil {
+		return
+	}
+	s = derefLocal(v.root, s)
+	// There's no point spending more time on a value that is already too
+	// big.
+	if err := checkTotalSize(s, x); err != nil {
+		v.fail(path, "max-total-size", err)
+		return
+	}
+	if err := checkKeys(s, x); err != nil {
+		v.fail(path, "max-keys", err)
+		return
+	}
+	for _, name := range s.Validators {
+		rv, ok := lookupValidator(name)
+		if !ok {
+			v.fail(path, "validators", fmt.Errorf("unknown validator %q", name))
+			continue
+		}
+		v.check(path, "validators", name, rv.expensive, func() error {
+			return rv.f(v.opts, x)
+		})
+	}
+	if str, ok := x.(string); ok && s.Format != "" {
+		if rf, ok := v.opts.lookupFormat(s.Format); ok {
+			v.check(path, "format", string(s.Format), rf.expensive, func() error {
+				return rf.f(str)
+			})
+		}
+	}
+	v.errs = append(v.errs, constErrors([]*Schema{s}, x, path)...)
+	v.errs = append(v.errs, multipleOfErrors([]*Schema{s}, x, path)...)
+	if s.KeyOf != "" && v.opts.Documents != nil {
+		if err := v.opts.checkKeyOf(s.KeyOf, x); err != nil {
+			v.fail(path, "key-of", err)
+		}
+	}
+	for _, sub := range s.AllOf {
+		v.validate(sub, x, path)
+	}
+	if s.hasConditional() {
+		v.validateConditional(s, x, path)
+	}
+	if obj, ok := asObject(x); ok {
+		v.errs = append(v.errs, missingDependents(s, obj, path, make(map[string]bool))...)
+		if s.PropertyNames != nil {
+			v.errs = append(v.errs, propertyNameErrors(v.root, s, obj, path)...)
+			for _, name := range sortedObjectKeys(obj) {
+				v.validate(s.PropertyNames, name, propertyPath(path, name))
+			}
+		}
+		if s.UnevaluatedProperties != nil {
+			v.validateUnevaluated(s, obj, path)
+		}
+		for _, name := range sortedDependents(s) {
+			if _, ok := obj[name]; ok {
+				for _, dep := range s.dependentSchemas()[name] {
+					v.validate(dep, x, path)
+				}
+			}
+		}
+		if s.Range {
+			if err := checkRange(obj); err != nil {
+				v.fail(path, "range", err)
+			}
+		}
+		for _, name := range objectKeysInOrder(s, obj) {
+			schemas := propertySchemas(s, name)
+			for _, ps := range schemas {
+				if ps.FeatureFlag != "" && !v.opts.FeatureEnabled(ps.FeatureFlag) {
+					v.fail(propertyPath(path, name), "feature-flag", fmt.Errorf(
+						"cannot be set unless feature flag %q is enabled", ps.FeatureFlag,
+					))
+					continue
+				}
+				if v.opts.Role != "" && !ps.accessibleBy(v.opts.Role) {
+					v.fail(propertyPath(path, name), "access", fmt.Errorf(
+						"cannot be set without %q access", ps.Access,
+					))
+					continue
+				}
+				n := len(v.errs)
+				if len(schemas) > 1 {
+					// jsschema only checks a property against one of
+					// the schemas that apply to it.
+					v.validateInternal(ps, obj[name], propertyPath(path, name))
+				}
+				v.validate(ps, obj[name], propertyPath(path, name))
+				for i := n; i < len(v.errs); i++ {
+					v.errs[i] = provenanceError(ps, v.errs[i])
+				}
+			}
+		}
+	}
+	if arr, ok := asArray(x); ok {
+		if s.ItemKey != "" {
+			if err := checkItemKeys(s, arr); err != nil {
+				v.fail(path, "item-key", err)
+			}
+		}
+		if s.SetOf {
+			if err := checkSetItems(s, arr); err != nil {
+				v.fail(path, "set-of", err)
+			}
+		}
+		v.errs = append(v.errs, containsErrors(v.root, s, arr, path)...)
+		if s.UnevaluatedItems != nil {
+			v.validateUnevaluatedItems(s, arr, path)
+		}
+		for i, item := range arr {
+			v.validate(itemSchema(s, i), item, itemPath(path, i))
+		}
+	}
+}
+
+// validateInternal checks x, found at path, against the keywords in s that
+// are implemented by jsschema, adding any errors found to v.errs.
+func (v *validation) validateInternal(s *Schema, x interface{}, path string) {
+	if err := v.explanation.validate(s, x); err != nil {
+		v.errs = append(v.errs, v.explanation.explain(s, x, path, err)...)
+	}
+}
+
+// fail records that the value found at path was rejected by the given
+// keyword.
+func (v *validation) fail(path, keyword string, err error) {
+	v.errs = append(v.errs, &ValidationError{Path: path, Keyword: keyword, Err: err})
+}
+
+// check runs the named check, which implements the given keyword, on the
+// value found at path. If the check is expensive and the budget in v.opts has
+// been used up, it is skipped and a warning issued instead.
+func (v *validation) check(path, keyword, name string, expensive bool, f func() error) {
+	if !expensive {
+		if err := f(); err != nil {
+			v.fail(path, keyword, err)
+		}
+		return
+	}
+	if v.opts.ExpensiveBudget.exhausted(v.expensiveChecks, v.expensiveTime) {
+		v.opts.warn(validationError(path, keyword, fmt.Errorf("%s check skipped: expensive check budget exhausted", name)))
+		return
+	}
+	start := time.Now()
+	err := f()
+	v.expensiveChecks++
+	v.expensiveTime += time.Since(start)
+	if err != nil {
+		v.fail(path, keyword, err)
+	}
+}
+
+// propertySchemas returns the schemas in s that apply to the named property
+// of an object, with those matched by patternProperties in order of their
+// patterns.
+func propertySchemas(s *Schema, name string) []*Schema {
+	var schemas []*Schema
+	if ps, ok := s.Properties[name]; ok {
+		schemas = append(schemas, ps)
+	}
+	for _, re := range sortedPatterns(s.PatternProperties) {
+		if re.MatchString(name) {
+			schemas = append(schemas, s.PatternProperties[re])
+		}
+	}
+	if len(schemas) == 0 && s.AdditionalProperties != nil {
+		schemas = append(schemas, s.AdditionalProperties)
+	}
+	return schemas
+}
+
+// propertyPath returns the path of the named property of the object found
+// at path.
+func propertyPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+// itemPath returns the path of the i'th item of the array found at path.
+func itemPath(path string, i int) string {
+	return fmt.Sprintf("%s[%d]", path, i)
+}
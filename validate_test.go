@@ -0,0 +1,152 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type ValidateSuite struct{}
+
+var _ = gc.Suite(ValidateSuite{})
+
+func init() {
+	RegisterValidator("test-min-controller", func(opts ValidationOptions, value interface{}) error {
+		version, _ := opts.Value("controller-version").(string)
+		if version < "3.1" {
+			return errors.New("requires controller version 3.1 or later")
+		}
+		return nil
+	})
+	RegisterValidator("test-lowercase", func(_ ValidationOptions, value interface{}) error {
+		if s, ok := value.(string); ok && strings.ToLower(s) != s {
+			return errors.New("must be lower case")
+		}
+		return nil
+	})
+}
+
+const validatorsExample = `
+type: object
+properties:
+  new-feature:
+    type: boolean
+    validators: [test-min-controller]
+  tags:
+    type: array
+    items:
+      type: string
+      validators: [test-lowercase]
+`
+
+func (ValidateSuite) TestValidateWith(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(validatorsExample))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.Properties["new-feature"].Validators, jc.DeepEquals, []string{"test-min-controller"})
+
+	doc := map[string]interface{}{"new-feature": true}
+	err = s.ValidateWith(ValidationOptions{
+		Values: map[string]interface{}{"controller-version": "3.1"},
+	}, doc)
+	c.Check(err, jc.ErrorIsNil)
+
+	err = s.ValidateWith(ValidationOptions{
+		Values: map[string]interface{}{"controller-version": "2.9"},
+	}, doc)
+	c.Check(err, gc.ErrorMatches, `new-feature: requires controller version 3.1 or later`)
+
+	err = s.Validate(doc)
+	c.Check(err, gc.ErrorMatches, `new-feature: requires controller version 3.1 or later`)
+
+	// Validators only apply to values that are present.
+	err = s.Validate(map[string]interface{}{})
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (ValidateSuite) TestValidatorItems(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(validatorsExample))
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.Validate(map[string]interface{}{"tags": []string{"a", "b"}})
+	c.Check(err, jc.ErrorIsNil)
+	err = s.Validate(map[string]interface{}{"tags": []string{"a", "B"}})
+	c.Check(err, gc.ErrorMatches, `tags\[1\]: must be lower case`)
+}
+
+func (ValidateSuite) TestUnknownValidator(c *gc.C) {
+	s := &Schema{
+		Type:       []Type{StringType},
+		Validators: []string{"no-such-validator"},
+	}
+	err := s.Validate("x")
+	c.Check(err, gc.ErrorMatches, `unknown validator "no-such-validator"`)
+}
+
+func (ValidateSuite) TestValidatorsRunAfterSchema(c *gc.C) {
+	s := &Schema{
+		Type:       []Type{StringType},
+		MinLength:  Int(2),
+		Validators: []string{"test-lowercase"},
+	}
+	err := s.Validate("A")
+	c.Check(err, gc.ErrorMatches, `.*shorter than minLength 2`)
+}
+
+func (ValidateSuite) TestExpensiveBudget(c *gc.C) {
+	calls := 0
+	RegisterExpensiveValidator("test-expensive", func(_ ValidationOptions, v interface{}) error {
+		calls++
+		if v == "bad" {
+			return errors.New("bad value")
+		}
+		return nil
+	})
+	s := &Schema{
+		Type: []Type{ArrayType},
+		Items: &ItemSpec{Schemas: []*Schema{{
+			Type:       []Type{StringType},
+			Validators: []string{"test-expensive"},
+		}}},
+	}
+	doc := []interface{}{"a", "b", "bad"}
+
+	// Without a budget every check is made.
+	c.Check(s.Validate(doc), gc.ErrorMatches, `\[2\]: bad value`)
+	c.Check(calls, gc.Equals, 3)
+
+	calls = 0
+	var warnings []string
+	opts := ValidationOptions{
+		ExpensiveBudget: Budget{MaxChecks: 2},
+		Warn: func(w error) {
+			warnings = append(warnings, w.Error())
+		},
+	}
+	c.Check(s.ValidateWith(opts, doc), jc.ErrorIsNil)
+	c.Check(calls, gc.Equals, 2)
+	c.Check(warnings, jc.DeepEquals, []string{
+		`[2]: test-expensive check skipped: expensive check budget exhausted`,
+	})
+
+	// The budget applies to each call separately.
+	calls = 0
+	c.Check(s.ValidateWith(opts, []interface{}{"bad"}), gc.ErrorMatches, `\[0\]: bad value`)
+	c.Check(calls, gc.Equals, 1)
+}
+
+func (ValidateSuite) TestExpensiveFormat(c *gc.C) {
+	RegisterExpensiveFormat("test-expensive", func(string) error {
+		return errors.New("never valid")
+	})
+	s := &Schema{Type: []Type{StringType}, Format: "test-expensive"}
+	c.Check(s.Validate("x"), gc.ErrorMatches, "never valid")
+	// The first check is always made, as no time has been spent yet.
+	opts := ValidationOptions{ExpensiveBudget: Budget{MaxDuration: time.Nanosecond}}
+	c.Check(s.ValidateWith(opts, "x"), gc.ErrorMatches, "never valid")
+}
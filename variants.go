@@ -0,0 +1,131 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+// Variant returns the schema selected when the named property of s has the
+// given value, or nil if there is no such variant.
+func (s *Schema) Variant(property string, value interface{}) *Schema {
+	ps, ok := s.Properties[property]
+	if !ok {
+		return nil
+	}
+	key, ok := value.(string)
+	if !ok {
+		return nil
+	}
+	return ps.Variants[key]
+}
+
+// WithVariants returns s with the variants selected by the values in x merged
+// into it, including for any objects nested within x. If no variants are
+// selected, s itself is returned. This is what Validate checks x against, and
+// can be used to find which further properties should be requested once a
+// choice has been made.
+func (s *Schema) WithVariants(x interface{}) *Schema {
+	if s == nil {
+		return nil
+	}
+	switch x := x.(type) {
+	case map[string]interface{}:
+		return s.objectWithVariants(x)
+	case []interface{}:
+		if s.Items == nil || s.Items.TupleMode || len(s.Items.Schemas) != 1 || len(s.PrefixItems) > 0 {
+			return s
+		}
+		item := s.Items.Schemas[0]
+		for _, v := range x {
+			if item.WithVariants(v) != item {
+				return s.tupleWithVariants(x)
+			}
+		}
+	}
+	return s
+}
+
+// tupleWithVariants returns s with its items described positionally, as each
+// item of x may select different variants.
+func (s *Schema) tupleWithVariants(x []interface{}) *Schema {
+	item := s.Items.Schemas[0]
+	out := *s
+	out.Items = &ItemSpec{TupleMode: true}
+	for _, v := range x {
+		out.Items.Schemas = append(out.Items.Schemas, item.WithVariants(v))
+	}
+	out.AdditionalItems = item
+	return &out
+}
+
+func (s *Schema) objectWithVariants(x map[string]interface{}) *Schema {
+	var out *Schema
+	clone := func() {
+		if out == nil {
+			out = new(Schema)
+			*out = *s
+			out.Properties = make(map[string]*Schema)
+			for name, ps := range s.Properties {
+				out.Properties[name] = ps
+			}
+		}
+	}
+	for _, name := range orderedProperties(s) {
+		variant := s.Variant(name, x[name])
+		if variant == nil {
+			continue
+		}
+		clone()
+		mergeObjectSchema(out, variant)
+	}
+	props := s.Properties
+	if out != nil {
+		props = out.Properties
+	}
+	for name, ps := range props {
+		v, ok := x[name]
+		if !ok {
+			continue
+		}
+		if vs := ps.WithVariants(v); vs != ps {
+			clone()
+			out.Properties[name] = vs
+		}
+	}
+	if out == nil {
+		return s
+	}
+	return out
+}
+
+// mergeObjectSchema merges the properties, required and order keywords of
+// extra into s. The properties map of s is modified in place.
+func mergeObjectSchema(s, extra *Schema) {
+	for name, ps := range extra.Properties {
+		if s.Properties == nil {
+			s.Properties = make(map[string]*Schema)
+		}
+		s.Properties[name] = ps
+	}
+	s.Required = appendMissing(s.Required, extra.Required...)
+	s.Order = appendMissing(s.Order, extra.Order...)
+}
+
+// appendMissing appends the values in add that are not already in l.
+func appendMissing(l []string, add ...string) []string {
+	if len(add) == 0 {
+		return l
+	}
+	out := append([]string(nil), l...)
+	for _, a := range add {
+		found := false
+		for _, v := range out {
+			if v == a {
+				found = true
+				break
+			}
+		}
+		if !found {
+			out = append(out, a)
+		}
+	}
+	return out
+}
@@ -0,0 +1,129 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type VariantsSuite struct{}
+
+var _ = gc.Suite(VariantsSuite{})
+
+const variantsExample = `
+type: object
+required: [auth-type]
+properties:
+  auth-type:
+    type: string
+    enum: [oauth2, userpass]
+    variants:
+      oauth2:
+        required: [client-id]
+        order: [client-id, client-secret]
+        properties:
+          client-id:
+            type: string
+          client-secret:
+            type: string
+            secret: true
+      userpass:
+        required: [username, password]
+        properties:
+          username:
+            type: string
+          password:
+            type: string
+            secret: true
+`
+
+func (VariantsSuite) TestVariant(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(variantsExample))
+	c.Assert(err, jc.ErrorIsNil)
+
+	oauth := s.Variant("auth-type", "oauth2")
+	c.Assert(oauth, gc.NotNil)
+	c.Check(oauth.Required, jc.DeepEquals, []string{"client-id"})
+	c.Check(s.Variant("auth-type", "kerberos"), gc.IsNil)
+	c.Check(s.Variant("auth-type", 1), gc.IsNil)
+	c.Check(s.Variant("username", "oauth2"), gc.IsNil)
+}
+
+func (VariantsSuite) TestWithVariants(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(variantsExample))
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(s.WithVariants(map[string]interface{}{}), gc.Equals, s)
+	c.Check(s.WithVariants("not an object"), gc.Equals, s)
+
+	merged := s.WithVariants(map[string]interface{}{"auth-type": "userpass"})
+	c.Check(merged.Required, jc.DeepEquals, []string{"auth-type", "username", "password"})
+	c.Check(sortedKeys(merged.Properties), jc.DeepEquals, []string{"auth-type", "password", "username"})
+	// The original schema is unchanged.
+	c.Check(s.Required, jc.DeepEquals, []string{"auth-type"})
+	c.Check(s.Properties, gc.HasLen, 1)
+}
+
+func (VariantsSuite) TestValidateVariants(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(variantsExample))
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.Validate(map[string]interface{}{
+		"auth-type": "oauth2",
+		"client-id": "abc",
+	})
+	c.Check(err, jc.ErrorIsNil)
+
+	err = s.Validate(map[string]interface{}{
+		"auth-type": "userpass",
+		"username":  "bob",
+		"password":  "secret",
+	})
+	c.Check(err, jc.ErrorIsNil)
+
+	err = s.Validate(map[string]interface{}{
+		"auth-type": "userpass",
+		"username":  "bob",
+	})
+	c.Check(err, gc.ErrorMatches, `.*password.*`)
+
+	err = s.Validate(map[string]interface{}{
+		"auth-type": "oauth2",
+		"client-id": 1,
+	})
+	c.Check(err, gc.ErrorMatches, `.*client-id.*`)
+}
+
+func (VariantsSuite) TestValidateNestedVariants(c *gc.C) {
+	cred, err := FromYAML(strings.NewReader(variantsExample))
+	c.Assert(err, jc.ErrorIsNil)
+	s := &Schema{
+		Type: []Type{ObjectType},
+		Properties: map[string]*Schema{
+			"credentials": {
+				Type:  []Type{ArrayType},
+				Items: &ItemSpec{Schemas: []*Schema{cred}},
+			},
+		},
+	}
+
+	err = s.Validate(map[string]interface{}{
+		"credentials": []interface{}{
+			map[string]interface{}{"auth-type": "oauth2", "client-id": "abc"},
+			map[string]interface{}{"auth-type": "userpass", "username": "bob", "password": "x"},
+		},
+	})
+	c.Check(err, jc.ErrorIsNil)
+
+	err = s.Validate(map[string]interface{}{
+		"credentials": []interface{}{
+			map[string]interface{}{"auth-type": "oauth2", "client-id": "abc"},
+			map[string]interface{}{"auth-type": "userpass", "username": "bob"},
+		},
+	})
+	c.Check(err, gc.NotNil)
+}
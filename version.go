@@ -0,0 +1,162 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ReaderVersion is the version of the schema format understood by this
+// package. It is incremented whenever keywords are added that older versions
+// of the package would silently ignore, and the new keywords are recorded in
+// keywordVersions, so that schemas using them are marshaled with the
+// min-reader-version they need (see Schema.MinReaderVersion).
+const ReaderVersion = 2
+
+// numericExclusiveBoundsVersion is the reader version which introduced the
+// numeric form of exclusiveMinimum and exclusiveMaximum.
+const numericExclusiveBoundsVersion = 2
+
+// keywordVersions maps each keyword added since the first reader version to
+// the reader version which introduced it. The numeric form of
+// exclusiveMinimum and exclusiveMaximum was introduced by
+// numericExclusiveBoundsVersion.
+var keywordVersions = map[string]int{
+	"$id":                   2,
+	"$anchor":               2,
+	"$dynamicRef":           2,
+	"$dynamicAnchor":        2,
+	"$recursiveRef":         2,
+	"$recursiveAnchor":      2,
+	"$defs":                 2,
+	"titles":                2,
+	"descriptions":          2,
+	"pattern-description":   2,
+	"pattern-descriptions":  2,
+	"enum-labels":           2,
+	"enum-descriptions":     2,
+	"group":                 2,
+	"groups":                2,
+	"visible-when":          2,
+	"default-when":          2,
+	"unit":                  2,
+	"range":                 2,
+	"variants":              2,
+	"profiles":              2,
+	"validators":            2,
+	"feature-flag":          2,
+	"provenance":            2,
+	"access":                2,
+	"key-of":                2,
+	"item-key":              2,
+	"set-of":                2,
+	"propertyNames":         2,
+	"dependentRequired":     2,
+	"dependentSchemas":      2,
+	"if":                    2,
+	"then":                  2,
+	"else":                  2,
+	"prefixItems":           2,
+	"unevaluatedProperties": 2,
+	"unevaluatedItems":      2,
+	"contains":              2,
+	"minContains":           2,
+	"maxContains":           2,
+	"const":                 2,
+	"examples":              2,
+	"max-total-size":        2,
+	"max-keys":              2,
+}
+
+// knownExtras holds the json keys of all the fields on Schema, which are the
+// keys that may legitimately be found in jsschema.Schema.Extras.
+var knownExtras = func() map[string]bool {
+	known := make(map[string]bool)
+	t := reflect.TypeOf(Schema{})
+	for i := 0; i < t.NumField(); i++ {
+		name := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]
+		if name != "" && name != "-" {
+			known[name] = true
+		}
+	}
+	return known
+}()
+
+// fieldKeyword returns the keyword held by the given field of Schema: the
+// name given by its keyword tag, for fields holding another form of a
+// keyword, or otherwise its json name. It returns "" if the field doesn't
+// hold a keyword.
+func fieldKeyword(f reflect.StructField) string {
+	if k := f.Tag.Get("keyword"); k != "" {
+		return k
+	}
+	if name := strings.Split(f.Tag.Get("json"), ",")[0]; name != "-" {
+		return name
+	}
+	return ""
+}
+
+// RequiredReaderVersion returns the lowest reader version which understands
+// every keyword used by s and the schemas nested within it.
+func (s *Schema) RequiredReaderVersion() int {
+	required := 1
+	walkSchema(s, func(s *Schema) {
+		if v := s.readerVersion(); v > required {
+			required = v
+		}
+	})
+	return required
+}
+
+// readerVersion returns the lowest reader version which understands every
+// keyword used by s itself, leaving aside the schemas nested within it.
+// Each schema is marshaled with a min-reader-version of at least this.
+func (s *Schema) readerVersion() int {
+	required := 1
+	v := reflect.ValueOf(s).Elem()
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		if v.Field(i).IsZero() {
+			continue
+		}
+		name := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]
+		if keywordVersions[name] > required {
+			required = keywordVersions[name]
+		}
+	}
+	if (s.ExclusiveMinimumValue != nil || s.ExclusiveMaximumValue != nil) && numericExclusiveBoundsVersion > required {
+		required = numericExclusiveBoundsVersion
+	}
+	return required
+}
+
+// CompatibilityWarnings returns a warning for every part of s that this
+// version of the package does not fully understand: schemas that require a
+// newer reader, and keywords that are preserved but otherwise ignored.
+// A schema written by a newer version of this package can always be loaded,
+// but callers should surface these warnings, as validation may be less strict
+// than the author intended.
+func (s *Schema) CompatibilityWarnings() []string {
+	var warnings []string
+	walkSchema(s, func(s *Schema) {
+		if s.MinReaderVersion > ReaderVersion {
+			warnings = append(warnings, fmt.Sprintf(
+				"schema requires reader version %d, this reader is version %d",
+				s.MinReaderVersion, ReaderVersion,
+			))
+		}
+		keys := make([]string, 0, len(s.Unknown))
+		for k := range s.Unknown {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			warnings = append(warnings, fmt.Sprintf("unknown keyword %q ignored", k))
+		}
+	})
+	return warnings
+}
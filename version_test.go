@@ -0,0 +1,159 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"encoding/json"
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type VersionSuite struct{}
+
+var _ = gc.Suite(VersionSuite{})
+
+// newerSchema is a schema as it might be written by a future version of this
+// package, using keywords we don't know about yet.
+const newerSchema = `
+{
+  "type": "object",
+  "min-reader-version": 99,
+  "properties": {
+    "payload": {
+      "type": "string",
+      "secret": true,
+      "rotate-every": "24h"
+    }
+  },
+  "ui-layout": {"columns": 2}
+}
+`
+
+func (VersionSuite) TestNewerSchemaLoads(c *gc.C) {
+	s, err := FromJSON(strings.NewReader(newerSchema))
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(s.MinReaderVersion, gc.Equals, 99)
+	c.Check(s.Unknown, jc.DeepEquals, map[string]interface{}{
+		"ui-layout": map[string]interface{}{"columns": float64(2)},
+	})
+	payload := s.Properties["payload"]
+	c.Check(payload.Secret, jc.IsTrue)
+	c.Check(payload.Unknown, jc.DeepEquals, map[string]interface{}{
+		"rotate-every": "24h",
+	})
+
+	// The keywords we do understand are still enforced.
+	c.Check(s.Validate(map[string]interface{}{"payload": "x"}), jc.ErrorIsNil)
+	c.Check(s.Validate(map[string]interface{}{"payload": 1}), gc.NotNil)
+}
+
+func (VersionSuite) TestCompatibilityWarnings(c *gc.C) {
+	s, err := FromJSON(strings.NewReader(newerSchema))
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(s.CompatibilityWarnings(), jc.DeepEquals, []string{
+		"schema requires reader version 99, this reader is version 2",
+		`unknown keyword "ui-layout" ignored`,
+		`unknown keyword "rotate-every" ignored`,
+	})
+	c.Check(objExample.CompatibilityWarnings(), gc.HasLen, 0)
+}
+
+func (VersionSuite) TestUnknownKeywordsRoundTrip(c *gc.C) {
+	s, err := FromJSON(strings.NewReader(newerSchema))
+	c.Assert(err, jc.ErrorIsNil)
+
+	b, err := json.Marshal(s)
+	c.Assert(err, jc.ErrorIsNil)
+	var m map[string]interface{}
+	err = json.Unmarshal(b, &m)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(m["ui-layout"], jc.DeepEquals, map[string]interface{}{"columns": float64(2)})
+	c.Check(m["min-reader-version"], gc.Equals, float64(99))
+
+	s2 := &Schema{}
+	err = json.Unmarshal(b, s2)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s2, jc.DeepEquals, s)
+}
+
+// version1Keywords holds the keywords understood by the first reader version.
+// It must not change: keywords added later belong in keywordVersions.
+var version1Keywords = []string{
+	"id", "title", "description", "default", "type", "$schema", "definitions",
+	"$ref", "format", "multipleOf", "minimum", "maximum", "exclusiveMinimum",
+	"exclusiveMaximum", "maxLength",
+	"minLength", "pattern", "additionalItems", "items", "minItems", "maxItems",
+	"uniqueItems", "maxProperties", "minProperties", "required",
+	"dependencies", "properties", "additionalProperties", "patternProperties",
+	"enum", "allOf", "anyOf", "oneOf", "not", "immutable", "secret", "env-vars",
+	"example", "order", "singular", "plural", "prompt-default", "path-for",
+	"xml", "min-reader-version",
+}
+
+func (VersionSuite) TestKeywordVersions(c *gc.C) {
+	versioned := make(map[string]bool)
+	for _, k := range version1Keywords {
+		versioned[k] = true
+	}
+	latest := 1
+	for k, v := range keywordVersions {
+		c.Check(v > 1 && v <= ReaderVersion, jc.IsTrue, gc.Commentf("keyword %q has version %d", k, v))
+		if v > latest {
+			latest = v
+		}
+		versioned[k] = true
+	}
+	c.Check(latest, gc.Equals, ReaderVersion)
+	for k := range knownExtras {
+		c.Check(versioned[k], jc.IsTrue, gc.Commentf("keyword %q has no reader version", k))
+	}
+}
+
+func (VersionSuite) TestRequiredReaderVersion(c *gc.C) {
+	c.Check(objExample.RequiredReaderVersion(), gc.Equals, 1)
+	s := &Schema{
+		Type: []Type{ObjectType},
+		Properties: map[string]*Schema{
+			"name": {Type: []Type{StringType}, Const: "x"},
+		},
+	}
+	c.Check(s.RequiredReaderVersion(), gc.Equals, 2)
+	c.Check((&Schema{ExclusiveMaximumValue: Float(1)}).RequiredReaderVersion(), gc.Equals, numericExclusiveBoundsVersion)
+}
+
+func (VersionSuite) TestMarshalReaderVersion(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(`
+type: object
+properties:
+  name: {type: string, const: x}
+  pair:
+    type: array
+    prefixItems: [{type: string}]
+  plain: {type: string}
+if: {required: [name]}
+then: {required: [pair]}
+`))
+	c.Assert(err, jc.ErrorIsNil)
+	data, err := json.Marshal(s)
+	c.Assert(err, jc.ErrorIsNil)
+	var m map[string]interface{}
+	c.Assert(json.Unmarshal(data, &m), jc.ErrorIsNil)
+	props := m["properties"].(map[string]interface{})
+	// Each schema claims the version required by its own keywords.
+	c.Check(m["min-reader-version"], gc.Equals, 2.0)
+	c.Check(props["name"].(map[string]interface{})["min-reader-version"], gc.Equals, 2.0)
+	c.Check(props["pair"].(map[string]interface{})["min-reader-version"], gc.Equals, 2.0)
+	c.Check(props["plain"].(map[string]interface{})["min-reader-version"], gc.IsNil)
+
+	// The version implied by the keywords isn't kept when decoding, so the
+	// schema survives a round trip unchanged.
+	var out Schema
+	c.Assert(json.Unmarshal(data, &out), jc.ErrorIsNil)
+	c.Check(out.MinReaderVersion, gc.Equals, 0)
+	c.Check(&out, jc.DeepEquals, s)
+}
@@ -0,0 +1,83 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+// Condition holds the values that properties of an object must have for the
+// condition to hold, keyed by property name.
+type Condition map[string]interface{}
+
+// Matches reports whether every property named in c has the expected value in
+// doc. An empty condition always matches.
+func (c Condition) Matches(doc map[string]interface{}) bool {
+	for name, want := range c {
+		got, ok := doc[name]
+		if !ok || !valuesEqual(got, want) {
+			return false
+		}
+	}
+	return true
+}
+
+// ConditionalDefault holds a default value which applies when a condition
+// holds. See Schema.DefaultWhen.
+type ConditionalDefault struct {
+	// When holds the condition, in terms of the other properties of the
+	// object.
+	When Condition `json:"when"`
+
+	// Value holds the default value to use when the condition holds.
+	Value interface{} `json:"value"`
+}
+
+// defaultFor returns the default value of a property with schema s, given
+// the values of the other properties of the object in doc.
+func (s *Schema) defaultFor(doc map[string]interface{}) interface{} {
+	for _, d := range s.DefaultWhen {
+		if d.When.Matches(doc) {
+			return d.Value
+		}
+	}
+	return s.Default
+}
+
+// VisibleProperties returns the names of the properties of s that are
+// relevant given the values already present in doc, ordered according to
+// s.Order. A property is visible if it has no visible-when condition, or if
+// its condition matches doc and every property the condition refers to is
+// itself visible.
+func (s *Schema) VisibleProperties(doc map[string]interface{}) []string {
+	var visible []string
+	for _, name := range orderedProperties(s) {
+		if s.isVisible(name, doc, make(map[string]bool)) {
+			visible = append(visible, name)
+		}
+	}
+	return visible
+}
+
+// isVisible reports whether the named property is visible, using visiting to
+// guard against conditions which refer to each other.
+func (s *Schema) isVisible(name string, doc map[string]interface{}, visiting map[string]bool) bool {
+	ps, ok := s.Properties[name]
+	if !ok {
+		// Conditions on properties that aren't defined by the schema can
+		// only be satisfied by the document.
+		return true
+	}
+	if visiting[name] {
+		return false
+	}
+	visiting[name] = true
+	defer delete(visiting, name)
+
+	if !ps.VisibleWhen.Matches(doc) {
+		return false
+	}
+	for dep := range ps.VisibleWhen {
+		if !s.isVisible(dep, doc, visiting) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,80 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type VisibleSuite struct{}
+
+var _ = gc.Suite(VisibleSuite{})
+
+const visibleExample = `
+type: object
+order: [use-proxy, proxy-host, proxy-port, proxy-auth, proxy-password]
+properties:
+  use-proxy:
+    type: boolean
+  proxy-host:
+    type: string
+    visible-when:
+      use-proxy: true
+  proxy-port:
+    type: integer
+    visible-when:
+      use-proxy: true
+  proxy-auth:
+    type: string
+    enum: [none, basic]
+    visible-when:
+      use-proxy: true
+  proxy-password:
+    type: string
+    visible-when:
+      proxy-auth: basic
+`
+
+func (VisibleSuite) TestVisibleProperties(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(visibleExample))
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(s.VisibleProperties(nil), jc.DeepEquals, []string{"use-proxy"})
+	c.Check(s.VisibleProperties(map[string]interface{}{
+		"use-proxy": false,
+	}), jc.DeepEquals, []string{"use-proxy"})
+	c.Check(s.VisibleProperties(map[string]interface{}{
+		"use-proxy": true,
+	}), jc.DeepEquals, []string{"use-proxy", "proxy-host", "proxy-port", "proxy-auth"})
+	c.Check(s.VisibleProperties(map[string]interface{}{
+		"use-proxy":  true,
+		"proxy-auth": "basic",
+	}), jc.DeepEquals, []string{"use-proxy", "proxy-host", "proxy-port", "proxy-auth", "proxy-password"})
+
+	// proxy-password depends on proxy-auth, which is hidden.
+	c.Check(s.VisibleProperties(map[string]interface{}{
+		"use-proxy":  false,
+		"proxy-auth": "basic",
+	}), jc.DeepEquals, []string{"use-proxy"})
+}
+
+func (VisibleSuite) TestConditionMatchesNumbers(c *gc.C) {
+	cond := Condition{"replicas": float64(3), "zones": []interface{}{"a"}}
+	c.Check(cond.Matches(map[string]interface{}{"replicas": 3, "zones": []string{"a"}}), jc.IsTrue)
+	c.Check(cond.Matches(map[string]interface{}{"replicas": 4, "zones": []string{"a"}}), jc.IsFalse)
+	c.Check(Condition(nil).Matches(nil), jc.IsTrue)
+}
+
+func (VisibleSuite) TestVisibleWhenCycle(c *gc.C) {
+	s := &Schema{
+		Properties: map[string]*Schema{
+			"a": {VisibleWhen: Condition{"b": "x"}},
+			"b": {VisibleWhen: Condition{"a": "x"}},
+		},
+	}
+	c.Check(s.VisibleProperties(map[string]interface{}{"a": "x", "b": "x"}), gc.HasLen, 0)
+}
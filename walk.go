@@ -0,0 +1,244 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// walkSchema calls fn for s and every schema nested within it. Each schema is
+// visited once, even if it is reachable from several places.
+func walkSchema(s *Schema, fn func(*Schema)) {
+	seen := make(map[*Schema]bool)
+	var walk func(s *Schema)
+	walk = func(s *Schema) {
+		if s == nil || seen[s] {
+			return
+		}
+		seen[s] = true
+		fn(s)
+		for _, sub := range subschemas(s) {
+			walk(sub)
+		}
+	}
+	walk(s)
+}
+
+// subschemas returns the schemas directly nested within s, in a stable order.
+func subschemas(s *Schema) []*Schema {
+	var subs []*Schema
+	subs = append(subs, sortedSchemas(s.Definitions)...)
+	subs = append(subs, sortedSchemas(s.Defs)...)
+	subs = append(subs, sortedSchemas(s.Properties)...)
+	subs = append(subs, sortedPatternSchemas(s.PatternProperties)...)
+	subs = append(subs, s.AdditionalProperties)
+	subs = append(subs, s.PropertyNames)
+	subs = append(subs, sortedSchemas(s.Dependencies.Schemas)...)
+	subs = append(subs, sortedSchemas(s.DependentSchemas)...)
+	subs = append(subs, s.PrefixItems...)
+	if s.Items != nil {
+		subs = append(subs, s.Items.Schemas...)
+	}
+	subs = append(subs, s.AdditionalItems, s.Contains)
+	subs = append(subs, s.AllOf...)
+	subs = append(subs, s.AnyOf...)
+	subs = append(subs, s.OneOf...)
+	subs = append(subs, s.Not)
+	subs = append(subs, s.If, s.Then, s.Else)
+	subs = append(subs, s.UnevaluatedProperties, s.UnevaluatedItems)
+	subs = append(subs, sortedSchemas(s.Variants)...)
+	subs = append(subs, sortedSchemas(s.Profiles)...)
+	return subs
+}
+
+// sortedKeys returns the keys of m in sorted order.
+func sortedKeys(m map[string]*Schema) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// orderedProperties returns the names of the properties of s, in the order
+// given by s.Order followed by any remaining properties in sorted order.
+func orderedProperties(s *Schema) []string {
+	names := make([]string, 0, len(s.Properties))
+	seen := make(map[string]bool)
+	for _, name := range s.Order {
+		if _, ok := s.Properties[name]; ok && !seen[name] {
+			names = append(names, name)
+			seen[name] = true
+		}
+	}
+	for _, name := range sortedKeys(s.Properties) {
+		if !seen[name] {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+func sortedSchemas(m map[string]*Schema) []*Schema {
+	var schemas []*Schema
+	for _, k := range sortedKeys(m) {
+		schemas = append(schemas, m[k])
+	}
+	return schemas
+}
+
+func sortedPatternSchemas(m map[*regexp.Regexp]*Schema) []*Schema {
+	var schemas []*Schema
+	for _, re := range sortedPatterns(m) {
+		schemas = append(schemas, m[re])
+	}
+	return schemas
+}
+
+// sortedPatterns returns the keys of m, sorted by their source.
+func sortedPatterns(m map[*regexp.Regexp]*Schema) []*regexp.Regexp {
+	res := make([]*regexp.Regexp, 0, len(m))
+	for re := range m {
+		res = append(res, re)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].String() < res[j].String()
+	})
+	return res
+}
+
+// rewriteSubschemas replaces every schema directly nested within s with the
+// result of calling fn on it. The maps and slices holding the subschemas are
+// replaced rather than modified, so s may be a shallow copy of another schema.
+func rewriteSubschemas(s *Schema, fn func(*Schema) *Schema) {
+	s.Definitions = rewriteSchemaMap(s.Definitions, fn)
+	s.Defs = rewriteSchemaMap(s.Defs, fn)
+	s.Properties = rewriteSchemaMap(s.Properties, fn)
+	if s.PatternProperties != nil {
+		m := make(map[*regexp.Regexp]*Schema)
+		for re, sub := range s.PatternProperties {
+			m[re] = fn(sub)
+		}
+		s.PatternProperties = m
+	}
+	s.AdditionalProperties = rewriteSchema(s.AdditionalProperties, fn)
+	s.PropertyNames = rewriteSchema(s.PropertyNames, fn)
+	s.Dependencies.Schemas = rewriteSchemaMap(s.Dependencies.Schemas, fn)
+	s.DependentSchemas = rewriteSchemaMap(s.DependentSchemas, fn)
+	if s.Items != nil {
+		s.Items = &ItemSpec{
+			TupleMode: s.Items.TupleMode,
+			Schemas:   rewriteSchemaList(s.Items.Schemas, fn),
+		}
+	}
+	s.PrefixItems = rewriteSchemaList(s.PrefixItems, fn)
+	s.AdditionalItems = rewriteSchema(s.AdditionalItems, fn)
+	s.Contains = rewriteSchema(s.Contains, fn)
+	s.AllOf = rewriteSchemaList(s.AllOf, fn)
+	s.AnyOf = rewriteSchemaList(s.AnyOf, fn)
+	s.OneOf = rewriteSchemaList(s.OneOf, fn)
+	s.Not = rewriteSchema(s.Not, fn)
+	s.If = rewriteSchema(s.If, fn)
+	s.Then = rewriteSchema(s.Then, fn)
+	s.Else = rewriteSchema(s.Else, fn)
+	s.UnevaluatedProperties = rewriteSchema(s.UnevaluatedProperties, fn)
+	s.UnevaluatedItems = rewriteSchema(s.UnevaluatedItems, fn)
+	s.Variants = rewriteSchemaMap(s.Variants, fn)
+	s.Profiles = rewriteSchemaMap(s.Profiles, fn)
+}
+
+func rewriteSchema(s *Schema, fn func(*Schema) *Schema) *Schema {
+	if s == nil {
+		return nil
+	}
+	return fn(s)
+}
+
+func rewriteSchemaMap(m map[string]*Schema, fn func(*Schema) *Schema) map[string]*Schema {
+	if m == nil {
+		return nil
+	}
+	out := make(map[string]*Schema)
+	for k, s := range m {
+		out[k] = rewriteSchema(s, fn)
+	}
+	return out
+}
+
+func rewriteSchemaList(l []*Schema, fn func(*Schema) *Schema) []*Schema {
+	if l == nil {
+		return nil
+	}
+	out := make([]*Schema, len(l))
+	for i, s := range l {
+		out[i] = rewriteSchema(s, fn)
+	}
+	return out
+}
+
+// cloneSchema returns a deep copy of s. Values such as defaults and enums are
+// shared with the original, but every nested schema is copied.
+func cloneSchema(s *Schema) *Schema {
+	cache := make(map[*Schema]*Schema)
+	var clone func(s *Schema) *Schema
+	clone = func(s *Schema) *Schema {
+		if c, ok := cache[s]; ok {
+			return c
+		}
+		c := new(Schema)
+		*c = *s
+		cache[s] = c
+		rewriteSubschemas(c, clone)
+		return c
+	}
+	out := rewriteSchema(s, clone)
+	// References resolved to schemas within s must refer to their copies.
+	for _, c := range cache {
+		if target, ok := cache[c.target]; ok {
+			c.target = target
+		}
+	}
+	return out
+}
+
+// sortedObjectKeys returns the keys of the object m in sorted order.
+func sortedObjectKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// refName returns the last element of the json pointer in ref.
+func refName(ref string) string {
+	return unescapePointer(ref[strings.LastIndex(ref, "/")+1:])
+}
+
+// unescapePointer unescapes an element of a json pointer.
+func unescapePointer(s string) string {
+	return strings.NewReplacer("~1", "/", "~0", "~").Replace(s)
+}
+
+// pascalName returns name converted to a type name for generated code, as
+// in "instance-type" to "InstanceType", prefixed with T if it would
+// otherwise not start with a letter.
+func pascalName(name string) string {
+	var b strings.Builder
+	for _, word := range strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		b.WriteString(strings.ToUpper(word[:1]) + word[1:])
+	}
+	out := b.String()
+	if out == "" || !unicode.IsLetter(rune(out[0])) {
+		out = "T" + out
+	}
+	return out
+}
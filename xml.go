@@ -0,0 +1,183 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// XMLMapping describes how a property is read from an XML document.
+type XMLMapping struct {
+	// Name is the element or attribute name holding the value. It defaults to
+	// the property name.
+	Name string `json:"name,omitempty"`
+
+	// Attribute specifies that the value is held in an attribute of the
+	// parent element rather than in a child element.
+	Attribute bool `json:"attribute,omitempty"`
+
+	// Wrapped specifies that the items of an array are held inside a single
+	// wrapping element, rather than being repeated directly in the parent.
+	Wrapped bool `json:"wrapped,omitempty"`
+}
+
+// xmlElement is a generic representation of an element in an XML document.
+type xmlElement struct {
+	XMLName  xml.Name
+	Attrs    []xml.Attr   `xml:",any,attr"`
+	Children []xmlElement `xml:",any"`
+	Text     string       `xml:",chardata"`
+}
+
+// DecodeXML returns a document created from the xml value in r, using the xml
+// mappings defined in s to decide which elements and attributes populate
+// which properties. The mapping is best-effort: elements and attributes that
+// are not described by the schema are ignored, and the result should be
+// passed to Validate to check that it conforms to s.
+func (s *Schema) DecodeXML(r io.Reader) (interface{}, error) {
+	var root xmlElement
+	if err := xml.NewDecoder(r).Decode(&root); err != nil {
+		return nil, err
+	}
+	return fromXMLElement(s, &root)
+}
+
+func fromXMLElement(s *Schema, e *xmlElement) (interface{}, error) {
+	switch {
+	case hasType(s, ObjectType) || len(s.Properties) > 0:
+		return fromXMLObject(s, e)
+	case hasType(s, ArrayType):
+		// An array which isn't a property of an object can only be held in
+		// its own (wrapping) element.
+		return fromXMLItems(s, e.Children, itemXMLName(s, ""))
+	}
+	return fromXMLText(s, e.XMLName.Local, e.Text)
+}
+
+func fromXMLObject(s *Schema, e *xmlElement) (interface{}, error) {
+	out := make(map[string]interface{})
+	for property, ps := range s.Properties {
+		name := property
+		if ps.XML != nil && ps.XML.Name != "" {
+			name = ps.XML.Name
+		}
+		if ps.XML != nil && ps.XML.Attribute {
+			for _, attr := range e.Attrs {
+				if attr.Name.Local != name {
+					continue
+				}
+				v, err := fromXMLText(ps, name, attr.Value)
+				if err != nil {
+					return nil, err
+				}
+				out[property] = v
+				break
+			}
+			continue
+		}
+		if hasType(ps, ArrayType) {
+			children := e.Children
+			itemName := name
+			if ps.XML != nil && ps.XML.Wrapped {
+				wrapper := findXMLChild(e, name)
+				if wrapper == nil {
+					continue
+				}
+				children = wrapper.Children
+				itemName = itemXMLName(ps, "")
+			}
+			items, err := fromXMLItems(ps, children, itemName)
+			if err != nil {
+				return nil, err
+			}
+			if len(items) > 0 {
+				out[property] = items
+			}
+			continue
+		}
+		child := findXMLChild(e, name)
+		if child == nil {
+			continue
+		}
+		v, err := fromXMLElement(ps, child)
+		if err != nil {
+			return nil, err
+		}
+		out[property] = v
+	}
+	return out, nil
+}
+
+// fromXMLItems converts all the elements called name into items of the array
+// described by s.
+func fromXMLItems(s *Schema, children []xmlElement, name string) ([]interface{}, error) {
+	var items []interface{}
+	for i := range children {
+		child := &children[i]
+		if name != "" && child.XMLName.Local != name {
+			continue
+		}
+		v, err := fromXMLElement(itemSchema(s, len(items)), child)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, v)
+	}
+	return items, nil
+}
+
+// fromXMLText converts the text held by an element or attribute into a value
+// of the type described by s.
+func fromXMLText(s *Schema, name, text string) (interface{}, error) {
+	text = strings.TrimSpace(text)
+	switch {
+	case hasType(s, StringType) || len(s.Type) == 0:
+		return text, nil
+	case hasType(s, IntegerType):
+		i, err := strconv.Atoi(text)
+		if err != nil {
+			return nil, fmt.Errorf("xml value for %q: expected integer, got %q", name, text)
+		}
+		return i, nil
+	case hasType(s, NumberType):
+		f, err := strconv.ParseFloat(text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("xml value for %q: expected number, got %q", name, text)
+		}
+		return f, nil
+	case hasType(s, BooleanType):
+		b, err := strconv.ParseBool(text)
+		if err != nil {
+			return nil, fmt.Errorf("xml value for %q: expected boolean, got %q", name, text)
+		}
+		return b, nil
+	case hasType(s, NullType):
+		return nil, nil
+	}
+	return text, nil
+}
+
+func findXMLChild(e *xmlElement, name string) *xmlElement {
+	for i := range e.Children {
+		if e.Children[i].XMLName.Local == name {
+			return &e.Children[i]
+		}
+	}
+	return nil
+}
+
+// itemXMLName returns the element name used for the items of the array
+// described by s, defaulting to def.
+func itemXMLName(s *Schema, def string) string {
+	if s.Items != nil && len(s.Items.Schemas) > 0 {
+		if m := s.Items.Schemas[0].XML; m != nil && m.Name != "" {
+			return m.Name
+		}
+	}
+	return def
+}
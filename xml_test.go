@@ -0,0 +1,109 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type XMLSuite struct{}
+
+var _ = gc.Suite(XMLSuite{})
+
+const xmlEndpointSchema = `
+type: object
+properties:
+  name:
+    type: string
+    xml:
+      attribute: true
+  port:
+    type: integer
+    xml:
+      name: Port
+  secure:
+    type: boolean
+  regions:
+    type: array
+    xml:
+      wrapped: true
+    items:
+      type: string
+      xml:
+        name: region
+  tags:
+    type: array
+    xml:
+      name: tag
+    items:
+      type: string
+  auth:
+    type: object
+    properties:
+      type:
+        type: string
+        xml:
+          attribute: true
+`
+
+const xmlEndpoint = `
+<endpoint name="keystone">
+  <Port>5000</Port>
+  <secure>true</secure>
+  <regions>
+    <region>north</region>
+    <region>south</region>
+  </regions>
+  <tag>a</tag>
+  <tag>b</tag>
+  <auth type="userpass"/>
+  <unknown>ignored</unknown>
+</endpoint>
+`
+
+func (XMLSuite) TestFromXML(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(xmlEndpointSchema))
+	c.Assert(err, jc.ErrorIsNil)
+
+	doc, err := s.DecodeXML(strings.NewReader(xmlEndpoint))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(doc, jc.DeepEquals, map[string]interface{}{
+		"name":    "keystone",
+		"port":    5000,
+		"secure":  true,
+		"regions": []interface{}{"north", "south"},
+		"tags":    []interface{}{"a", "b"},
+		"auth": map[string]interface{}{
+			"type": "userpass",
+		},
+	})
+	c.Check(s.Validate(doc), jc.ErrorIsNil)
+}
+
+func (XMLSuite) TestFromXMLMissingValues(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(xmlEndpointSchema))
+	c.Assert(err, jc.ErrorIsNil)
+
+	doc, err := s.DecodeXML(strings.NewReader(`<endpoint/>`))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(doc, jc.DeepEquals, map[string]interface{}{})
+}
+
+func (XMLSuite) TestFromXMLBadValue(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(xmlEndpointSchema))
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.DecodeXML(strings.NewReader(`<endpoint><Port>http</Port></endpoint>`))
+	c.Check(err, gc.ErrorMatches, `xml value for "Port": expected integer, got "http"`)
+}
+
+func (XMLSuite) TestXMLRoundTrip(c *gc.C) {
+	s, err := FromYAML(strings.NewReader(xmlEndpointSchema))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.Properties["name"].XML, jc.DeepEquals, &XMLMapping{Attribute: true})
+	c.Check(s.Properties["regions"].XML, jc.DeepEquals, &XMLMapping{Wrapped: true})
+}
@@ -0,0 +1,180 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+// YAMLFile holds a schema loaded from a yaml file along with the layout of
+// the file, such as its comments and the order of its keys, so that tools
+// which edit schemas can write them back without losing the annotations
+// added by people.
+type YAMLFile struct {
+	doc    yaml.Node
+	schema *Schema
+}
+
+// LoadYAMLFile returns the schema in the yaml file read from r, preserving
+// its layout.
+func LoadYAMLFile(r io.Reader) (*YAMLFile, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	f := &YAMLFile{}
+	if err := yaml.Unmarshal(data, &f.doc); err != nil {
+		return nil, err
+	}
+	if f.schema, err = FromYAML(bytes.NewReader(data)); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// Schema returns the schema held in f.
+func (f *YAMLFile) Schema() *Schema {
+	return f.schema
+}
+
+// SetSchema replaces the schema held in f with s. The layout of the parts of
+// the file whose values are unchanged is preserved, as are the comments on
+// any keys that remain. Keys added by s follow the existing ones, in sorted
+// order.
+func (f *YAMLFile) SetSchema(s *Schema) error {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	root := &f.doc
+	if root.Kind == 0 {
+		root.Kind = yaml.DocumentNode
+	}
+	if len(root.Content) == 0 {
+		root.Content = []*yaml.Node{{}}
+	}
+	if err := mergeNode(root.Content[0], v); err != nil {
+		return fmt.Errorf("cannot update yaml file: %v", err)
+	}
+	f.schema = s
+	return nil
+}
+
+// Bytes returns the yaml form of the file.
+func (f *YAMLFile) Bytes() ([]byte, error) {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&f.doc); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// mergeNode updates node so that it holds the json value v, keeping as much
+// of its existing layout as possible.
+func mergeNode(node *yaml.Node, v interface{}) error {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		if node.Kind == yaml.MappingNode {
+			return mergeMapping(node, v)
+		}
+	case []interface{}:
+		if node.Kind == yaml.SequenceNode {
+			return mergeSequence(node, v)
+		}
+	default:
+		var old interface{}
+		if node.Kind == yaml.ScalarNode && node.Decode(&old) == nil && valuesEqual(old, v) {
+			return nil
+		}
+	}
+	return replaceNode(node, v)
+}
+
+func mergeMapping(node *yaml.Node, m map[string]interface{}) error {
+	seen := make(map[string]bool)
+	content := node.Content[:0:0]
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		v, ok := m[key.Value]
+		if !ok {
+			continue
+		}
+		if err := mergeNode(value, v); err != nil {
+			return err
+		}
+		seen[key.Value] = true
+		content = append(content, key, value)
+	}
+	keys := make([]string, 0, len(m))
+	for k, v := range m {
+		// A Schema doesn't distinguish between a missing
+		// additionalProperties and false, and is marshaled with false,
+		// so don't add it to files that leave it out.
+		if k == "additionalProperties" && v == false {
+			continue
+		}
+		if !seen[k] {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		value := &yaml.Node{}
+		if err := value.Encode(m[k]); err != nil {
+			return err
+		}
+		content = append(content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: k}, value)
+	}
+	node.Content = content
+	return nil
+}
+
+func mergeSequence(node *yaml.Node, l []interface{}) error {
+	if len(node.Content) > len(l) {
+		node.Content = node.Content[:len(l)]
+	}
+	for i, v := range l {
+		if i < len(node.Content) {
+			if err := mergeNode(node.Content[i], v); err != nil {
+				return err
+			}
+			continue
+		}
+		item := &yaml.Node{}
+		if err := item.Encode(v); err != nil {
+			return err
+		}
+		node.Content = append(node.Content, item)
+	}
+	return nil
+}
+
+// replaceNode replaces the value held in node with v, keeping its comments.
+func replaceNode(node *yaml.Node, v interface{}) error {
+	var replacement yaml.Node
+	if err := replacement.Encode(v); err != nil {
+		return err
+	}
+	replacement.HeadComment = node.HeadComment
+	replacement.LineComment = node.LineComment
+	replacement.FootComment = node.FootComment
+	*node = replacement
+	return nil
+}
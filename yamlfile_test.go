@@ -0,0 +1,74 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package jsonschema
+
+import (
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type YAMLFileSuite struct{}
+
+var _ = gc.Suite(YAMLFileSuite{})
+
+const commentedSchema = `# Settings for the database charm.
+type: object
+properties:
+  # The port is shared with the proxy, so check with the networking
+  # team before changing it.
+  port:
+    type: integer
+    default: 5432 # the postgres default
+  name:
+    type: string
+    description: The name of the database.
+`
+
+func (YAMLFileSuite) TestRoundTrip(c *gc.C) {
+	f, err := LoadYAMLFile(strings.NewReader(commentedSchema))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(f.Schema().Properties["port"].Default, gc.Equals, float64(5432))
+
+	err = f.SetSchema(f.Schema())
+	c.Assert(err, jc.ErrorIsNil)
+	data, err := f.Bytes()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(data), gc.Equals, commentedSchema)
+}
+
+func (YAMLFileSuite) TestSetSchema(c *gc.C) {
+	f, err := LoadYAMLFile(strings.NewReader(commentedSchema))
+	c.Assert(err, jc.ErrorIsNil)
+	s := cloneSchema(f.Schema())
+	s.Properties["port"].Default = 6432
+	delete(s.Properties, "name")
+	s.Properties["user"] = &Schema{Type: []Type{StringType}, Secret: true}
+	s.Required = []string{"user"}
+
+	err = f.SetSchema(s)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(f.Schema(), gc.Equals, s)
+	data, err := f.Bytes()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(data), gc.Equals, `# Settings for the database charm.
+type: object
+properties:
+  # The port is shared with the proxy, so check with the networking
+  # team before changing it.
+  port:
+    type: integer
+    default: 6432 # the postgres default
+  user:
+    secret: true
+    type: string
+required:
+  - user
+`)
+
+	reloaded, err := FromYAML(strings.NewReader(string(data)))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(reloaded.Properties["user"].Secret, jc.IsTrue)
+}